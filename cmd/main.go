@@ -5,12 +5,15 @@ import (
 	"flag"
 	"fmt"
 	"log-generator/internal/config"
+	"log-generator/internal/generator"
 	"log-generator/internal/monitor"
 	"log-generator/internal/worker"
 	"log-generator/pkg/metrics"
 	"os"
 	"os/signal"
 	"runtime"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -25,6 +28,54 @@ type AppConfig struct {
 	LogLevel          string
 	Profile           string  // EPS 프로파일
 	TargetEPS         int     // 커스텀 EPS
+	SyslogFormat      string  // syslog 형식 (rfc3164, rfc5424)
+	UseBOM            bool    // RFC 5424 MSG 앞 BOM 추가
+	FacilityWeights   string  // 퍼실리티 가중치 (user=40,daemon=40,...)
+	SeverityWeights   string  // 심각도 가중치 (info=70,notice=20,...)
+	LogFormats        string  // 로그 형식 목록 (쉼표 구분, 워커별 순환 배정)
+	HostnamePrefix    string  // 호스트명 접두사 (빈 값 = 기본 호스트 풀)
+	Services          string  // 서비스 목록 (쉼표 구분, 빈 값 = 기본 서비스 풀)
+	WebStatusWeights  string  // 웹 접근 로그 상태 코드 클래스 가중치 (2xx=80,5xx=2,...)
+	IISHeaderInterval int     // IIS #Fields 지시문 간격 (줄 수)
+	CEFVendor         string  // CEF Device Vendor
+	CEFProduct        string  // CEF Device Product
+	LEEFDelimiter     string  // LEEF 2.0 속성 구분자 (단일 문자 또는 xHH)
+	AuditKeys         string  // auditd 규칙 키 (쉼표 구분, 빈 값 = 이벤트별 기본 키)
+	DNSSuspiciousRatio float64 // DNS 로그 DGA/터널링 도메인 비율 (0~1)
+	VPCFlowFields     string  // VPC 흐름 로그 사용자 지정 필드 (쉼표 구분, 빈 값 = v2~v5 전체)
+	FlowPort          int     // NetFlow/IPFIX 수집기 포트 (0 = 2055/4739)
+	FlowTemplateRefresh int // NetFlow v9/IPFIX 템플릿 재전송 간격 (패킷)
+	GELFCompression   string  // GELF 압축 방식 (none, gzip, zlib)
+	GELFChunkSize     int     // GELF UDP 청크 크기 (바이트)
+	GELFPort          int     // GELF 입력 UDP 포트
+	TemplateFile      string  // 사용자 정의 메시지 템플릿 파일 (빈 값 = 내장 메시지)
+	StackTraceLanguage string // 스택 트레이스 언어 (java, python, mixed)
+	StackTraceDepth   int     // 스택 트레이스 최상위 예외 프레임 수
+	SessionCount      int     // 세션 형식의 동시 세션 수
+	SessionDuration   int     // 세션 평균 지속 시간 (초)
+	Transport         string  // 전송 프로토콜 (udp, tcp)
+	Framing           string  // 프레이밍 방식 (auto, lf, octet, escape, continuation)
+	MaxDatagram       int     // UDP 데이터그램 최대 바이트 수
+	FuzzRatio         float64 // 비정상 메시지 퍼징 비율 (0~1, 0 = 끔)
+	FuzzMutations     string  // 사용할 퍼징 변형 (쉼표 구분, 빈 값 = 전체)
+	SizeDistribution  string  // 메시지 크기 분포 (fixed, uniform, normal, lognormal / 빈 값 = 패딩 끔)
+	SizeMean          int     // 평균 메시지 크기 (바이트)
+	SizeStdDev        int     // 메시지 크기 표준편차 (바이트)
+	SizeMin           int     // 최소 메시지 크기 (바이트)
+	SizeMax           int     // 최대 메시지 크기 (바이트)
+	TargetBytes       string  // 초당 바이트 목표 (예: 500MB/s, 빈 값 = EPS 프로파일 사용)
+	InventoryHosts    int     // 인벤토리 호스트 수 (0 = 인벤토리 끔)
+	InventoryPattern  string  // 인벤토리 호스트 이름 패턴 ({role}, {n})
+	InventoryRoles    string  // 역할 비율 (web=40,db=20,...)
+	InventoryCIDRs    string  // 역할별 대역 (web=10.10.0.0/16,...)
+	InventoryRestart  int     // 서비스 평균 재시작 간격 (분, 0 = 재시작 없음)
+	InventoryExport   string  // 인벤토리 CSV 내보내기 경로 (빈 값 = 내보내지 않음)
+	ScenarioFile      string  // 공격 시나리오 정의 JSON 파일 (빈 값 = 내장 라이브러리)
+	ScenarioRatio     float64 // 로그 한 건당 시나리오 시작 확률 (0~0.01, 0 = 끔)
+	ScenarioInterval  int     // 전체 워커 기준 시나리오 시작 간격 (초, 0 = 끔)
+	Scenarios         string  // 주입할 시나리오 이름 (쉼표 구분, 빈 값 = 전체)
+	GroundTruth       string  // 주입 메시지 정답 파일 경로 (NDJSON, 빈 값 = 기록 안 함)
+	Watermark         bool    // 메시지마다 실행 ID/워커 ID/일련번호 워터마크 추가
 }
 
 // LogGenerator - 400만 EPS 로그 생성기 메인 애플리케이션
@@ -40,6 +91,8 @@ type LogGenerator struct {
 	cancel           context.CancelFunc
 	startTime        time.Time
 	isRunning        bool
+	targetBytes      int64 // 초당 바이트 목표 (0 = 사용 안 함)
+	groundTruth      *worker.GroundTruthWriter // 주입 메시지 정답 기록기 (nil = 기록 안 함)
 }
 
 func main() {
@@ -111,6 +164,102 @@ func parseFlags() *AppConfig {
 		"EPS 프로파일 (100k, 500k, 1m, 2m, 4m, custom)")
 	flag.IntVar(&config.TargetEPS, "eps", 0,
 		"커스텀 목표 EPS (profile=custom일 때 사용)")
+	flag.StringVar(&config.SyslogFormat, "syslog-format", "rfc3164",
+		"syslog 형식 (rfc3164, rfc5424)")
+	flag.BoolVar(&config.UseBOM, "bom", false,
+		"RFC 5424 메시지 앞에 UTF-8 BOM 추가")
+	flag.StringVar(&config.FacilityWeights, "facility-weights", "",
+		"서비스 매핑이 없는 로그의 퍼실리티 가중치 (예: user=40,daemon=40,local0=20)")
+	flag.StringVar(&config.SeverityWeights, "severity-weights", "",
+		"심각도 가중치 (syslog, ecs, gelf 형식에만 적용, 예: info=70,notice=20,warning=8,err=2)")
+	flag.StringVar(&config.LogFormats, "formats", "syslog",
+		"로그 형식 목록, 쉼표 구분 (사용 가능: "+strings.Join(generator.ListFormats(), ", ")+")")
+	flag.StringVar(&config.HostnamePrefix, "hostname-prefix", "",
+		"호스트명 접두사 (예: server → server01..server20)")
+	flag.StringVar(&config.Services, "services", "",
+		"서비스 목록, 쉼표 구분 (예: systemd,kernel,sshd)")
+	flag.StringVar(&config.WebStatusWeights, "web-status-weights", "",
+		"웹 접근 로그 상태 코드 클래스 가중치 (예: 2xx=80,3xx=10,4xx=8,5xx=2)")
+	flag.IntVar(&config.IISHeaderInterval, "iis-header-interval", 1000,
+		"IIS #Fields 지시문 전송 간격 (줄 수, 첫 줄과 이 간격마다 전송)")
+	flag.StringVar(&config.CEFVendor, "cef-vendor", "LogGen",
+		"CEF 헤더 Device Vendor")
+	flag.StringVar(&config.CEFProduct, "cef-product", "Security Gateway",
+		"CEF 헤더 Device Product")
+	flag.StringVar(&config.LEEFDelimiter, "leef-delimiter", "^",
+		"LEEF 2.0 속성 구분자 (단일 문자 또는 x09 같은 16진수 표기)")
+	flag.StringVar(&config.AuditKeys, "audit-keys", "",
+		"auditd 규칙 키 목록, 쉼표 구분 (예: exec,identity / 빈 값 = 이벤트별 기본 키)")
+	flag.Float64Var(&config.DNSSuspiciousRatio, "dns-suspicious-ratio", 0.02,
+		"DNS 로그 중 DGA/터널링 의심 도메인 비율 (0~1)")
+	flag.StringVar(&config.VPCFlowFields, "vpc-flow-fields", "",
+		"vpcflow_custom 필드 목록, 쉼표 구분 (예: version,interface-id,srcaddr,dstaddr,action / 빈 값 = v2~v5 전체)")
+	flag.IntVar(&config.FlowPort, "flow-port", 0,
+		"NetFlow/IPFIX 수집기 UDP 포트 (0 = NetFlow 2055, IPFIX 4739)")
+	flag.IntVar(&config.FlowTemplateRefresh, "flow-template-refresh", 20,
+		"NetFlow v9/IPFIX 템플릿 재전송 간격 (패킷 수)")
+	flag.StringVar(&config.GELFCompression, "gelf-compression", "none",
+		"GELF 압축 방식 (none, gzip, zlib)")
+	flag.IntVar(&config.GELFChunkSize, "gelf-chunk-size", 1420,
+		"GELF UDP 청크 크기, 이보다 큰 메시지는 청크로 분할 (128~8192, LAN은 8154 권장)")
+	flag.IntVar(&config.GELFPort, "gelf-port", 12201,
+		"GELF 입력 UDP 포트")
+	flag.StringVar(&config.TemplateFile, "template-file", "",
+		"메시지 템플릿 파일 경로 (한 줄에 템플릿 하나, syslog/ecs/gelf 메시지를 대체)")
+	flag.StringVar(&config.StackTraceLanguage, "stacktrace-language", "mixed",
+		"stacktrace 형식의 예외 언어 (java, python, mixed)")
+	flag.IntVar(&config.StackTraceDepth, "stacktrace-depth", 12,
+		"stacktrace 형식의 최상위 예외 프레임 수 (1~256)")
+	flag.IntVar(&config.SessionCount, "session-count", 1000,
+		"session 형식의 워커당 동시 로그인 세션 수")
+	flag.IntVar(&config.SessionDuration, "session-duration", 300,
+		"session 형식의 평균 세션 지속 시간 (초)")
+	flag.StringVar(&config.Transport, "transport", "udp",
+		"전송 프로토콜 (udp, tcp / NetFlow, IPFIX, GELF는 항상 UDP)")
+	flag.StringVar(&config.Framing, "framing", "auto",
+		"프레이밍 방식 (auto, lf, octet, escape, continuation / auto = TCP는 octet, UDP는 escape)")
+	flag.IntVar(&config.MaxDatagram, "max-datagram", worker.MaxUDPDatagram,
+		"UDP 데이터그램 최대 바이트 수 (배치를 나눠 보내는 기준, 한 건이 넘는 로그는 버림)")
+	flag.Float64Var(&config.FuzzRatio, "fuzz-ratio", 0,
+		"비정상 메시지로 변형할 로그 비율 (0~1, 0 = 퍼징 끔)")
+	flag.StringVar(&config.FuzzMutations, "fuzz-mutations", "",
+		"사용할 퍼징 변형 (쉼표 구분, 빈 값 = 전체: "+strings.Join(generator.ListMutations(), ", ")+" / oversized는 -transport tcp 필요)")
+	flag.StringVar(&config.SizeDistribution, "size-dist", "",
+		"메시지 크기 분포 (fixed, uniform, normal, lognormal / 빈 값 = 원래 크기), 작은 메시지를 key=value 패딩으로 늘림")
+	flag.IntVar(&config.SizeMean, "size-mean", 450,
+		"평균 메시지 크기 (바이트, fixed/normal/lognormal)")
+	flag.IntVar(&config.SizeStdDev, "size-stddev", 300,
+		"메시지 크기 표준편차 (바이트, normal/lognormal)")
+	flag.IntVar(&config.SizeMin, "size-min", 0,
+		"최소 메시지 크기 (바이트)")
+	flag.IntVar(&config.SizeMax, "size-max", 8192,
+		"최대 메시지 크기 (바이트, 최대 1MiB)")
+	flag.StringVar(&config.TargetBytes, "target-bytes", "",
+		"초당 바이트 목표 (예: 500MB/s, 1.5GiB/s), 지정하면 평균 메시지 크기로 목표 EPS를 환산해 -profile/-eps 대신 사용")
+	flag.IntVar(&config.InventoryHosts, "inventory-hosts", 0,
+		"역할 기반 호스트 인벤토리 호스트 수 (0 = 끔, 지정하면 -hostname-prefix 무시)")
+	flag.StringVar(&config.InventoryPattern, "inventory-pattern", "{role}{n}",
+		"인벤토리 호스트 이름 패턴 ({role} = web/db/cache/dc/fw, {n} = 역할 내 번호)")
+	flag.StringVar(&config.InventoryRoles, "inventory-roles", "",
+		"인벤토리 역할 비율 (예: web=50,db=20,cache=10,dc=5,firewall=15 / 빈 값 = 기본 비율)")
+	flag.StringVar(&config.InventoryCIDRs, "inventory-cidrs", "",
+		"역할별 IPv4 대역 (예: web=172.16.10.0/24,db=172.16.20.0/24 / 빈 값 = 기본 대역)")
+	flag.IntVar(&config.InventoryRestart, "inventory-restart", 0,
+		"인벤토리 서비스의 평균 재시작 간격 (분, 재시작 시 PID 변경 / 0 = 재시작 없음)")
+	flag.StringVar(&config.InventoryExport, "inventory-export", "",
+		"시작 시 인벤토리를 CSV로 저장할 경로 (SIEM 자산 DB 적재용)")
+	flag.StringVar(&config.ScenarioFile, "scenario-file", "",
+		"공격 시나리오 정의 JSON 파일 경로 (빈 값 = 내장 라이브러리)")
+	flag.Float64Var(&config.ScenarioRatio, "scenario-ratio", 0,
+		"로그 한 건마다 공격 시나리오를 시작할 확률 (0~0.01, 예: 0.0001 = 0.01%, 0 = 끔)")
+	flag.IntVar(&config.ScenarioInterval, "scenario-interval", 0,
+		"전체 워커 기준 공격 시나리오 시작 간격 (초, 예: 300 = 5분마다 하나, 0 = 끔)")
+	flag.StringVar(&config.Scenarios, "scenarios", "",
+		"주입할 공격 시나리오 (쉼표 구분, 빈 값 = 전체 / 내장: "+strings.Join(generator.BuiltinScenarioNames(), ", ")+")")
+	flag.StringVar(&config.GroundTruth, "ground-truth", "",
+		"주입한 시나리오/퍼징 메시지의 정답 파일 경로 (NDJSON, SIEM 탐지율/지연 채점용)")
+	flag.BoolVar(&config.Watermark, "watermark", false,
+		"메시지마다 실행 ID, 워커 ID, 워커별 일련번호 추가 (RFC 5424는 SD, GELF는 _lg_run/_lg_worker/_lg_seq, 그 외 lg_run/lg_worker/lg_seq, NetFlow/IPFIX에는 넣지 않음, 종단 간 유실/중복 집계용)")
 	
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
@@ -127,15 +276,191 @@ func parseFlags() *AppConfig {
 	
 	flag.Parse()
 	
-	// 커스텀 프로파일 검증
-	if config.Profile == "custom" && config.TargetEPS == 0 {
+	// 커스텀 프로파일 검증 (바이트 목표가 있으면 EPS는 환산값 사용)
+	if config.Profile == "custom" && config.TargetEPS == 0 && config.TargetBytes == "" {
 		fmt.Println("⚠️  custom 프로파일에는 -eps 플래그가 필요합니다")
 		os.Exit(1)
 	}
 	
+	// 로그 출력 옵션 검증
+	options, err := config.generatorOptions()
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	if err := generator.ValidateFormats(splitList(config.LogFormats)); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	if err := options.Priority.ValidateFormats(splitList(config.LogFormats)); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	for _, warning := range generator.InjectionWarnings(splitList(config.LogFormats), options) {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+	if err := config.transportOptions().Validate(); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	if err := options.Size.ValidateLimit(config.transportOptions().MessageLimit()); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := config.targetBytesPerSec(); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		os.Exit(1)
+	}
+	
 	return config
 }
 
+// generatorOptions - 명령행 설정에서 로그 생성기 출력 옵션 구성
+func (c *AppConfig) generatorOptions() (generator.GeneratorOptions, error) {
+	options := generator.DefaultGeneratorOptions()
+	
+	syslogFormat, err := generator.ParseSyslogFormat(c.SyslogFormat)
+	if err != nil {
+		return options, err
+	}
+	options.SyslogFormat = syslogFormat
+	options.UseBOM = c.UseBOM
+	options.HostnamePrefix = c.HostnamePrefix
+	options.Services = splitList(c.Services)
+	
+	facilityWeights, err := generator.ParseWeights(c.FacilityWeights)
+	if err != nil {
+		return options, err
+	}
+	if len(facilityWeights) > 0 {
+		options.Priority.FacilityWeights = facilityWeights
+	}
+	
+	severityWeights, err := generator.ParseWeights(c.SeverityWeights)
+	if err != nil {
+		return options, err
+	}
+	if len(severityWeights) > 0 {
+		options.Priority.SeverityWeights = severityWeights
+	}
+	
+	webStatusWeights, err := generator.ParseWeights(c.WebStatusWeights)
+	if err != nil {
+		return options, err
+	}
+	options.Web.StatusWeights = webStatusWeights
+	options.Web.IISHeaderInterval = c.IISHeaderInterval
+	options.CEF.Vendor = c.CEFVendor
+	options.CEF.Product = c.CEFProduct
+	options.LEEF.Delimiter = c.LEEFDelimiter
+	options.Audit.Keys = splitList(c.AuditKeys)
+	options.DNS.SuspiciousRatio = c.DNSSuspiciousRatio
+	options.VPCFlow.Fields = splitList(c.VPCFlowFields)
+	options.Flow.Port = c.FlowPort
+	options.Flow.TemplateRefresh = c.FlowTemplateRefresh
+	options.GELF = generator.GELFOptions{
+		Compression: c.GELFCompression,
+		ChunkSize:   c.GELFChunkSize,
+		Port:        c.GELFPort,
+	}
+	if c.TemplateFile != "" {
+		if options.Templates, err = generator.LoadTemplateFile(c.TemplateFile); err != nil {
+			return options, err
+		}
+	}
+	options.StackTrace = generator.StackTraceOptions{
+		Language: c.StackTraceLanguage,
+		Depth:    c.StackTraceDepth,
+	}
+	options.Session = generator.SessionOptions{
+		Count:    c.SessionCount,
+		Duration: c.SessionDuration,
+	}
+	if c.SizeDistribution != "" {
+		options.Size = generator.SizeOptions{
+			Distribution: c.SizeDistribution,
+			Mean:         c.SizeMean,
+			StdDev:       c.SizeStdDev,
+			Min:          c.SizeMin,
+			Max:          c.SizeMax,
+		}
+	}
+	options.Fuzz = generator.FuzzOptions{
+		Ratio:     c.FuzzRatio,
+		Mutations: splitList(c.FuzzMutations),
+	}
+	if c.InventoryHosts > 0 {
+		if options.Inventory, err = c.inventory(); err != nil {
+			return options, err
+		}
+	}
+	scenarioOptions := generator.ScenarioOptions{
+		File:     c.ScenarioFile,
+		Ratio:    c.ScenarioRatio,
+		Interval: c.ScenarioInterval,
+		Names:    splitList(c.Scenarios),
+	}
+	if err := scenarioOptions.Validate(); err != nil {
+		return options, err
+	}
+	if scenarioOptions.Enabled() {
+		if options.Scenarios, err = generator.NewScenarioLibrary(scenarioOptions); err != nil {
+			return options, err
+		}
+	}
+	
+	return options, options.Validate()
+}
+
+// inventory - 명령행 설정에서 호스트 인벤토리 생성
+func (c *AppConfig) inventory() (*generator.Inventory, error) {
+	roles, err := generator.ParseWeights(c.InventoryRoles)
+	if err != nil {
+		return nil, err
+	}
+	cidrs, err := generator.ParseRoleCIDRs(c.InventoryCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	return generator.NewInventory(generator.InventoryOptions{
+		Hosts:          c.InventoryHosts,
+		Pattern:        c.InventoryPattern,
+		Roles:          roles,
+		CIDRs:          cidrs,
+		RestartMinutes: c.InventoryRestart,
+	})
+}
+
+// exportInventory - 인벤토리를 CSV 파일로 저장
+func exportInventory(inventory *generator.Inventory, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("인벤토리 파일 생성 실패: %v", err)
+	}
+	if err := inventory.WriteCSV(file); err != nil {
+		file.Close()
+		return fmt.Errorf("인벤토리 CSV 저장 실패: %v", err)
+	}
+	return file.Close()
+}
+
+// transportOptions - 명령행 설정에서 전송 옵션 구성
+func (c *AppConfig) transportOptions() worker.TransportOptions {
+	return worker.TransportOptions{
+		Protocol:    c.Transport,
+		Framing:     c.Framing,
+		MaxDatagram: c.MaxDatagram,
+	}
+}
+
+// targetBytesPerSec - 초당 바이트 목표 파싱 (지정하지 않으면 0)
+func (c *AppConfig) targetBytesPerSec() (int64, error) {
+	if c.TargetBytes == "" {
+		return 0, nil
+	}
+	return config.ParseByteRate(c.TargetBytes)
+}
+
 // NewLogGenerator - 로그 생성기 애플리케이션 생성
 func NewLogGenerator(appConfig *AppConfig) (*LogGenerator, error) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -164,6 +489,68 @@ func NewLogGenerator(appConfig *AppConfig) (*LogGenerator, error) {
 	// 프로파일 기반 워커 풀 초기화
 	app.workerPool = worker.NewWorkerPoolWithProfile(appConfig.TargetHost, profile)
 	
+	// 로그 출력 형식 설정
+	generatorOptions, err := appConfig.generatorOptions()
+	if err != nil {
+		return nil, err
+	}
+	if err := app.workerPool.SetGeneratorOptions(generatorOptions); err != nil {
+		return nil, err
+	}
+	if appConfig.InventoryExport != "" {
+		if generatorOptions.Inventory == nil {
+			return nil, fmt.Errorf("-inventory-export에는 -inventory-hosts가 필요합니다")
+		}
+		if err := exportInventory(generatorOptions.Inventory, appConfig.InventoryExport); err != nil {
+			return nil, err
+		}
+		fmt.Printf("🗂️  인벤토리 %d대를 %s에 저장했습니다\n", len(generatorOptions.Inventory.Hosts()), appConfig.InventoryExport)
+	}
+	if err := app.workerPool.SetLogFormats(splitList(appConfig.LogFormats)); err != nil {
+		return nil, err
+	}
+	if err := app.workerPool.SetTransportOptions(appConfig.transportOptions()); err != nil {
+		return nil, err
+	}
+	if appConfig.GroundTruth != "" {
+		if generatorOptions.Scenarios == nil && generatorOptions.Fuzz.Ratio == 0 {
+			return nil, fmt.Errorf("-ground-truth에는 -scenario-ratio, -scenario-interval 또는 -fuzz-ratio가 필요합니다")
+		}
+		if app.groundTruth, err = worker.CreateGroundTruthFile(appConfig.GroundTruth); err != nil {
+			return nil, err
+		}
+		if err := app.workerPool.SetGroundTruth(app.groundTruth); err != nil {
+			return nil, err
+		}
+	}
+	if appConfig.Watermark {
+		// 정답 파일과 같은 실행 ID를 써서 두 기록을 함께 조회할 수 있게 함
+		runID := worker.NewRunID()
+		if app.groundTruth != nil {
+			runID = app.groundTruth.RunID()
+		}
+		if err := app.workerPool.SetWatermark(runID); err != nil {
+			return nil, err
+		}
+		fmt.Printf("🔖 메시지 워터마크 실행 ID: %s\n", runID)
+	}
+	
+	// 바이트 목표를 평균 메시지 크기로 나눠 목표 EPS 프로파일로 환산
+	if app.targetBytes, err = appConfig.targetBytesPerSec(); err != nil {
+		return nil, err
+	}
+	if app.targetBytes > 0 {
+		eps, size, err := app.workerPool.EPSForByteRate(app.targetBytes)
+		if err != nil {
+			return nil, err
+		}
+		profile = config.CalculateCustomProfile(eps)
+		profile.Description = fmt.Sprintf("%s (평균 %.0f바이트 × %d EPS)", config.FormatByteRate(app.targetBytes), size, eps)
+		if err := app.workerPool.SetProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+	
 	// 대시보드 초기화 (옵션)
 	if appConfig.EnableDashboard {
 		app.dashboard = monitor.NewDashboardServer(
@@ -241,6 +628,9 @@ func (lg *LogGenerator) Start() error {
 	
 	fmt.Println("=" + repeatString("=", 60))
 	fmt.Printf("🎯 목표: %s EPS 달성\n", formatNumber(int64(profile.TargetEPS)))
+	if lg.targetBytes > 0 {
+		fmt.Printf("🎯 바이트 목표: %s\n", config.FormatByteRate(lg.targetBytes))
+	}
 	fmt.Println("📊 실시간 모니터링 시작...")
 	fmt.Println()
 	
@@ -310,6 +700,11 @@ func (lg *LogGenerator) printQuickStats(metrics metrics.PerformanceMetrics) {
 		profile.WorkerCount,
 		metrics.CPUUsagePercent,
 		metrics.MemoryUsageMB)
+	if lg.targetBytes > 0 {
+		fmt.Printf("    바이트: %s / %s\n",
+			config.FormatByteRate(lg.workerPool.GetMetrics().BytesPerSec),
+			config.FormatByteRate(lg.targetBytes))
+	}
 }
 
 // Stop - 애플리케이션 정지
@@ -334,6 +729,13 @@ func (lg *LogGenerator) Stop() error {
 		}
 	}
 	
+	// 정답 파일 닫기 (워커 풀 정지 후, 모든 전송이 끝난 다음)
+	if lg.groundTruth != nil {
+		if err := lg.groundTruth.Close(); err != nil {
+			fmt.Printf("⚠️  정답 파일 저장 오류: %v\n", err)
+		}
+	}
+	
 	// 2. 대시보드 정지
 	if lg.dashboard != nil {
 		err := lg.dashboard.Stop()
@@ -374,6 +776,8 @@ func (lg *LogGenerator) printSystemInfo() {
 	fmt.Printf("   EPS 프로파일: %s (%s)\n", profile.Name, profile.Description)
 	fmt.Printf("   워커 수: %d, 배치 크기: %d, 타이머: %dμs\n", 
 		profile.WorkerCount, profile.BatchSize, profile.TickerInterval)
+	fmt.Printf("   syslog 형식: %s, 로그 형식: %s\n", lg.workerPool.GetGeneratorOptions().SyslogFormat,
+		strings.Join(lg.workerPool.GetLogFormats(), ", "))
 	if lg.config.TestDurationMin > 0 {
 		fmt.Printf("   테스트 시간: %d분\n", lg.config.TestDurationMin)
 	}
@@ -405,6 +809,45 @@ func (lg *LogGenerator) printFinalReport() {
 	fmt.Printf("   패킷 손실률: %.2f%%\n", finalMetrics.PacketLoss)
 	profile := lg.workerPool.GetProfile()
 	fmt.Printf("   활성 워커: %d/%d\n", finalMetrics.ActiveWorkers, profile.WorkerCount)
+	if oversized := lg.workerPool.GetOversized(); oversized > 0 {
+		fmt.Printf("   ⚠️  최대 데이터그램 초과로 버린 로그: %s개\n", formatNumber(oversized))
+	}
+	
+	// 퍼징 변형 통계
+	if mutations := lg.workerPool.GetMutationCounts(); len(mutations) > 0 {
+		fmt.Println("   🧪 퍼징 변형:")
+		for _, label := range generator.SortedCountKeys(mutations) {
+			fmt.Printf("      %-18s %s개\n", label, formatNumber(mutations[label]))
+		}
+	}
+	
+	// 공격 시나리오 주입 통계
+	if scenarios := lg.workerPool.GetScenarioCounts(); len(scenarios) > 0 {
+		fmt.Println("   🎯 공격 시나리오:")
+		for _, name := range generator.SortedCountKeys(scenarios) {
+			fmt.Printf("      %-24s %s회\n", name, formatNumber(scenarios[name]))
+		}
+	}
+	if sequences := lg.workerPool.GetLastSequences(); sequences != nil {
+		var total uint64
+		for _, seq := range sequences {
+			total += seq
+		}
+		fmt.Printf("   🔖 워터마크: 실행 ID %s, 워커 %d개, 번호를 매긴 메시지 %s건\n",
+			lg.workerPool.GetWatermarkRunID(), len(sequences), formatNumber(int64(total)))
+		ids := make([]int, 0, len(sequences))
+		for id := range sequences {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			fmt.Printf("      워커 %-3d 마지막 일련번호 %d\n", id, sequences[id])
+		}
+	}
+	if lg.groundTruth != nil {
+		fmt.Printf("   📑 정답 기록: %s건 → %s (실행 ID %s)\n",
+			formatNumber(lg.groundTruth.Count()), lg.config.GroundTruth, lg.groundTruth.RunID())
+	}
 	
 	// 성과 평가
 	if achievement >= 95 {
@@ -446,6 +889,17 @@ func formatNumber(n int64) string {
 	return fmt.Sprintf("%d", n)
 }
 
+// splitList - 쉼표 구분 목록 파싱 (공백/빈 항목 제거)
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func repeatString(s string, count int) string {
 	result := ""
 	for i := 0; i < count; i++ {
@@ -18,7 +18,9 @@ type WebApp struct {
 func main() {
 	// 명령행 파라미터 파싱
 	var port int
+	var dataDir string
 	flag.IntVar(&port, "port", 8080, "웹 서버 포트")
+	flag.StringVar(&dataDir, "data-dir", "", "API로 지정하는 파일(정답, 템플릿, 시나리오)의 디렉터리 (빈 값 = 파일 지정 불가)")
 	flag.Parse()
 
 	fmt.Println(`
@@ -38,6 +40,7 @@ func main() {
 		controlServer: monitor.NewControlServer(port),
 		port:          port,
 	}
+	app.controlServer.SetDataDir(dataDir)
 
 	// 서버 시작
 	err := app.Start()
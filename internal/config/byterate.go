@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits - 바이트 단위 배수 (KB/MB/GB/TB는 10진, KiB/MiB/GiB/TiB는 2진)
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ParseByteRate - "500MB/s", "1.5GiB", "250000" 형태의 초당 바이트 파싱
+//
+// 끝의 "/s"나 "ps"는 생략할 수 있고 단위는 대소문자를 구분하지 않는다.
+func ParseByteRate(value string) (int64, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	text = strings.TrimSuffix(text, "/s")
+	if strings.HasSuffix(text, "bps") {
+		text = strings.TrimSuffix(text, "ps")
+	}
+
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(text)
+	}
+	multiplier, ok := byteUnits[strings.TrimSpace(text[end:])]
+	if !ok {
+		return 0, fmt.Errorf("알 수 없는 바이트 단위: %s (B, KB, MB, GB, TB, KiB, MiB, GiB, TiB)", value)
+	}
+	number, err := strconv.ParseFloat(text[:end], 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("잘못된 바이트 속도: %s", value)
+	}
+
+	rate := number * multiplier
+	if rate < 1 || rate > math.MaxInt64/2 {
+		return 0, fmt.Errorf("바이트 속도 범위를 벗어났습니다: %s", value)
+	}
+	return int64(rate), nil
+}
+
+// FormatByteRate - 초당 바이트를 10진 단위 문자열로 표시 (예: 512.0 MB/s)
+func FormatByteRate(bytesPerSec int64) string {
+	rate := float64(bytesPerSec)
+	for _, unit := range []string{"B", "KB", "MB", "GB"} {
+		if rate < 1000 {
+			return fmt.Sprintf("%.1f %s/s", rate, unit)
+		}
+		rate /= 1000
+	}
+	return fmt.Sprintf("%.1f TB/s", rate)
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseByteRate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"250000", 250000},
+		{"1", 1},
+		{"500MB/s", 500e6},
+		{"500mbps", 500e6},
+		{"500 MB", 500e6},
+		{"  2gb/s ", 2e9},
+		{"1.5GiB", 1.5 * (1 << 30)},
+		{"64KiB/s", 64 << 10},
+		{"10k", 10e3},
+		{"3t", 3e12},
+		{"1TiB", 1 << 40},
+		{"100B/s", 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseByteRate(tt.value)
+			if err != nil {
+				t.Fatalf("ParseByteRate(%q) 오류: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseByteRate(%q) = %d, 기대값 %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteRateErrors(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"MB/s",
+		"abc",
+		"0",
+		"0.5",
+		"-5MB",
+		"10XB",
+		"1e9",
+		"1.2.3MB",
+		"10000000TB",
+	} {
+		t.Run(value, func(t *testing.T) {
+			if got, err := ParseByteRate(value); err == nil {
+				t.Fatalf("ParseByteRate(%q) = %d, 오류를 기대함", value, got)
+			}
+		})
+	}
+}
+
+// FormatByteRate 출력은 소수 한 자리로 반올림되므로 다시 파싱하면 단위의 0.05배 안에서 일치해야 한다
+func TestByteRateRoundTrip(t *testing.T) {
+	tests := []struct {
+		rate int64
+		text string
+		unit float64
+	}{
+		{1, "1.0 B/s", 1},
+		{999, "999.0 B/s", 1},
+		{1000, "1.0 KB/s", 1e3},
+		{123456, "123.5 KB/s", 1e3},
+		{500e6, "500.0 MB/s", 1e6},
+		{1 << 30, "1.1 GB/s", 1e9},
+		{12345678901, "12.3 GB/s", 1e9},
+		{25e12, "25.0 TB/s", 1e12},
+		{1500e12, "1500.0 TB/s", 1e12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			text := FormatByteRate(tt.rate)
+			if text != tt.text {
+				t.Fatalf("FormatByteRate(%d) = %q, 기대값 %q", tt.rate, text, tt.text)
+			}
+			got, err := ParseByteRate(text)
+			if err != nil {
+				t.Fatalf("ParseByteRate(%q) 오류: %v", text, err)
+			}
+			if diff := math.Abs(float64(got - tt.rate)); diff > 0.05*tt.unit {
+				t.Fatalf("ParseByteRate(%q) = %d, 원래 값 %d (차이 %.0f)", text, got, tt.rate, diff)
+			}
+		})
+	}
+}
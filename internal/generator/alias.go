@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// aliasTable - Walker/Vose 별칭 테이블 (항목 수와 무관하게 O(1) 가중치 선택)
+//
+// 항목이 수십 개 이상이거나 로그마다 호출되는 핫 패스에서 cumulativeTable 대신 사용한다.
+type aliasTable struct {
+	prob  []float64 // 칸 i를 그대로 선택할 확률
+	alias []int     // 선택하지 않을 때 대신 고르는 항목
+}
+
+// newAliasTable - 인덱스별 가중치로 별칭 테이블 생성 (0 가중치 항목은 선택되지 않음)
+func newAliasTable(weights []float64, kind string) (aliasTable, error) {
+	n := len(weights)
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return aliasTable{}, fmt.Errorf("%s 가중치는 음수일 수 없습니다", kind)
+		}
+		total += weight
+	}
+	if n == 0 || total <= 0 {
+		return aliasTable{}, fmt.Errorf("%s 가중치 합이 0입니다", kind)
+	}
+
+	table := aliasTable{prob: make([]float64, n), alias: make([]int, n)}
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, weight := range weights {
+		scaled[i] = weight * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		table.prob[s] = scaled[s]
+		table.alias[s] = l
+
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// 부동소수점 오차로 남은 칸은 자기 자신을 확률 1로 선택
+	for _, i := range large {
+		table.prob[i], table.alias[i] = 1, i
+	}
+	for _, i := range small {
+		table.prob[i], table.alias[i] = 1, i
+	}
+	return table, nil
+}
+
+// pick - 가중치에 따라 인덱스 선택 (난수 한 번으로 칸과 동전 던지기를 함께 결정)
+func (t aliasTable) pick(rng *rand.Rand) int {
+	r := rng.Float64() * float64(len(t.prob))
+	i := int(r)
+	if r-float64(i) < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
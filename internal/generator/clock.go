@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"sync"
+	"time"
+)
+
+// logClock - 모든 포맷터가 공유하는 타임스탬프 캐시 (1초마다 갱신하여 CPU 절약)
+type logClock struct {
+	mutex   sync.RWMutex
+	now     time.Time
+	rfc3164 string // 기존 syslog 헤더용 (UTC, 밀리초)
+	clf     string // Apache/Nginx 접근 로그 ([10/Oct/2000:13:55:36 -0700])
+	w3c     string // IIS W3C 확장 로그 (UTC "2006-01-02 15:04:05")
+	leef    string // QRadar LEEF devTime (devTimeFormat=MMM dd yyyy HH:mm:ss.SSS zzz)
+	snare   string // Snare MSWinEventLog 날짜 ("Mon Jan 02 15:04:05 2006")
+
+	// RFC 3339 + 로컬 오프셋: 초까지와 오프셋만 캐시하고 마이크로초는 메시지마다 채움
+	rfc5424Sec    int64  // 캐시한 초 (Unix)
+	rfc5424Prefix string // "2006-01-02T15:04:05"
+	rfc5424Zone   string // "-07:00"
+}
+
+var (
+	sharedClock     = &logClock{}
+	sharedClockOnce sync.Once
+)
+
+// getClock - 공유 클럭 반환 (최초 호출 시 갱신 고루틴 시작)
+func getClock() *logClock {
+	sharedClockOnce.Do(func() {
+		sharedClock.update()
+
+		go func() {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				sharedClock.update()
+			}
+		}()
+	})
+	return sharedClock
+}
+
+func (c *logClock) update() {
+	now := time.Now()
+	rfc3164 := now.UTC().Format("2006-01-02T15:04:05.000Z")
+	clf := now.Format("02/Jan/2006:15:04:05 -0700")
+	w3c := now.UTC().Format("2006-01-02 15:04:05")
+	leef := now.Format(leefTimeLayout)
+	snare := now.Format("Mon Jan 02 15:04:05 2006")
+
+	c.mutex.Lock()
+	c.now = now
+	c.rfc3164 = rfc3164
+	c.setRFC5424(now)
+	c.clf = clf
+	c.w3c = w3c
+	c.leef = leef
+	c.snare = snare
+	c.mutex.Unlock()
+}
+
+// Now - 캐시된 현재 시각 (최대 1초 지연)
+func (c *logClock) Now() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.now
+}
+
+// RFC3164 - 캐시된 syslog 헤더 타임스탬프
+func (c *logClock) RFC3164() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.rfc3164
+}
+
+// setRFC5424 - RFC 5424 타임스탬프의 초 단위 캐시 갱신 (호출자가 쓰기 락 보유, 이미 더 최근이면 유지)
+func (c *logClock) setRFC5424(now time.Time) {
+	if sec := now.Unix(); sec > c.rfc5424Sec {
+		c.rfc5424Sec = sec
+		c.rfc5424Prefix = now.Format("2006-01-02T15:04:05")
+		c.rfc5424Zone = now.Format("-07:00")
+	}
+}
+
+// AppendRFC5424 - RFC 3339 타임스탬프 추가 (로컬 오프셋, 마이크로초는 호출 시각)
+//
+// 갱신 고루틴보다 먼저 다음 초에 들어서면 그 자리에서 캐시를 갱신하므로 초와 소수부가 어긋나지 않는다.
+func (c *logClock) AppendRFC5424(buffer []byte) []byte {
+	now := time.Now()
+	sec := now.Unix()
+
+	c.mutex.RLock()
+	cached, prefix, zone := c.rfc5424Sec, c.rfc5424Prefix, c.rfc5424Zone
+	c.mutex.RUnlock()
+	if cached != sec {
+		c.mutex.Lock()
+		c.setRFC5424(now)
+		cached, prefix, zone = c.rfc5424Sec, c.rfc5424Prefix, c.rfc5424Zone
+		c.mutex.Unlock()
+		if cached != sec {
+			// 다른 고루틴이 이미 다음 초로 갱신함 (이 시각은 직접 형식화)
+			return now.AppendFormat(buffer, "2006-01-02T15:04:05.000000-07:00")
+		}
+	}
+
+	buffer = append(buffer, prefix...)
+	buffer = append(buffer, '.')
+	micros := now.Nanosecond() / 1000
+	for div := 100000; div > 0; div /= 10 {
+		buffer = append(buffer, byte('0'+micros/div%10))
+	}
+	return append(buffer, zone...)
+}
+
+// CLF - 캐시된 Common Log Format 타임스탬프 (대괄호 제외)
+func (c *logClock) CLF() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.clf
+}
+
+// W3C - 캐시된 IIS W3C 날짜/시간 ("date time" 두 필드)
+func (c *logClock) W3C() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.w3c
+}
+
+// LEEF - 캐시된 LEEF devTime 값
+func (c *logClock) LEEF() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.leef
+}
+
+// Snare - 캐시된 Snare 날짜 필드
+func (c *logClock) Snare() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.snare
+}
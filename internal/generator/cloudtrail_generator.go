@@ -0,0 +1,567 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CloudTrail 레코드 상수
+const (
+	cloudTrailEventVersion = "1.09"
+	cloudTrailTimeLayout   = "2006-01-02T15:04:05Z"
+	cloudTrailHomeRegion   = "ap-northeast-2"
+	cloudTrailGlobalRegion = "us-east-1" // IAM/콘솔 로그인 등 글로벌 서비스
+	cloudTrailTrailName    = "management-events"
+)
+
+// 합성 AWS 계정 ID (VPC 흐름 로그와 공유, 실제 계정과 무관)
+var awsAccountIDs = []string{"418273645091", "730215948362", "926183047514"}
+
+// cloudTrailIdentity - userIdentity.type
+type cloudTrailIdentity int
+
+const (
+	cloudTrailIAMUser     cloudTrailIdentity = iota // 액세스 키/콘솔 사용자
+	cloudTrailAssumedRole                           // 인스턴스/람다/SSO 역할 세션
+	cloudTrailAWSService                            // 서비스가 대신 호출 (invokedBy)
+	cloudTrailRoot                                  // 루트 계정
+)
+
+// cloudTrailAction - 이벤트 템플릿 (eventSource + eventName + 호출 주체)
+type cloudTrailAction struct {
+	source       string
+	name         string
+	identity     cloudTrailIdentity
+	readOnly     bool
+	data         bool   // 데이터 이벤트 (S3 객체 수준)
+	errorCode    string // 실패 시 errorCode
+	errorMessage string // 비어 있으면 권한 거부 메시지 생성
+	failRate     float64
+	weight       float64
+}
+
+var cloudTrailActions = []cloudTrailAction{
+	{source: "s3.amazonaws.com", name: "GetObject", identity: cloudTrailAssumedRole, readOnly: true, data: true, errorCode: "NoSuchKey", errorMessage: "The specified key does not exist.", failRate: 0.03, weight: 20},
+	{source: "kms.amazonaws.com", name: "Decrypt", identity: cloudTrailAssumedRole, readOnly: true, weight: 14},
+	{source: "sts.amazonaws.com", name: "AssumeRole", identity: cloudTrailAWSService, readOnly: true, weight: 12},
+	{source: "ec2.amazonaws.com", name: "DescribeInstances", identity: cloudTrailAssumedRole, readOnly: true, weight: 10},
+	{source: "s3.amazonaws.com", name: "PutObject", identity: cloudTrailAssumedRole, data: true, errorCode: "AccessDenied", failRate: 0.02, weight: 8},
+	{source: "logs.amazonaws.com", name: "CreateLogStream", identity: cloudTrailAssumedRole, errorCode: "ResourceAlreadyExistsException", errorMessage: "The specified log stream already exists", failRate: 0.02, weight: 6},
+	{source: "sts.amazonaws.com", name: "GetCallerIdentity", identity: cloudTrailIAMUser, readOnly: true, weight: 4},
+	{source: "s3.amazonaws.com", name: "ListBuckets", identity: cloudTrailIAMUser, readOnly: true, errorCode: "AccessDenied", failRate: 0.05, weight: 4},
+	{source: "signin.amazonaws.com", name: "ConsoleLogin", identity: cloudTrailIAMUser, errorMessage: "Failed authentication", failRate: 0.15, weight: 3},
+	{source: "ec2.amazonaws.com", name: "RunInstances", identity: cloudTrailAssumedRole, errorCode: "Client.UnauthorizedOperation", failRate: 0.1, weight: 1},
+	{source: "ec2.amazonaws.com", name: "AuthorizeSecurityGroupIngress", identity: cloudTrailIAMUser, errorCode: "Client.UnauthorizedOperation", failRate: 0.2, weight: 1},
+	{source: "iam.amazonaws.com", name: "CreateAccessKey", identity: cloudTrailIAMUser, errorCode: "AccessDenied", failRate: 0.4, weight: 0.5},
+	{source: "iam.amazonaws.com", name: "AttachUserPolicy", identity: cloudTrailIAMUser, errorCode: "AccessDenied", failRate: 0.5, weight: 0.3},
+	{source: "signin.amazonaws.com", name: "ConsoleLogin", identity: cloudTrailRoot, weight: 0.2},
+	{source: "cloudtrail.amazonaws.com", name: "StopLogging", identity: cloudTrailRoot, weight: 0.1},
+}
+
+// IAM 사용자
+var cloudTrailUserNames = []string{"alice", "bob", "jsmith", "deploy", "jenkins", "svc_backup", "terraform"}
+
+// cloudTrailRoleTemplate - 역할과 세션 이름 (service가 있으면 서비스가 AssumeRole 호출)
+var cloudTrailRoleTemplates = []struct {
+	name, session, service string
+}{
+	{"app-server-role", "", "ec2.amazonaws.com"}, // 세션 이름 = 인스턴스 ID
+	{"orders-lambda-role", "orders-handler", "lambda.amazonaws.com"},
+	{"payments-task-role", "", "ecs-tasks.amazonaws.com"}, // 세션 이름 = 태스크 ID
+	{"AWSReservedSSO_AdministratorAccess_5e2b8c1f0a4d7e93", "alice@corp.example.com", ""},
+	{"github-actions-deploy", "GitHubActions", ""},
+}
+
+// 사용자 에이전트
+var (
+	cloudTrailCLIAgents = []string{
+		"aws-cli/2.15.8 Python/3.11.6 Linux/6.2.0-1017-aws exe/x86_64.ubuntu.22 prompt/off command/s3.ls",
+		"aws-cli/2.13.25 Python/3.11.5 Darwin/23.1.0 exe/arm64 prompt/off command/sts.get-caller-identity",
+		"APN/1.0 HashiCorp/1.0 Terraform/1.6.6 (+https://www.terraform.io) terraform-provider-aws/5.31.0",
+	}
+	cloudTrailSDKAgents = []string{
+		"Boto3/1.34.11 md/Botocore#1.34.11 ua/2.0 os/linux#5.10.201-191.748.amzn2.x86_64 md/arch#x86_64 lang/python#3.11.6 cfg/retry-mode#legacy Botocore/1.34.11",
+		"aws-sdk-go-v2/1.24.0 os/linux lang/go#1.21.5 md/GOOS#linux md/GOARCH#amd64 api/ec2#1.141.0",
+		"aws-sdk-java/2.21.42 Linux/5.10.201-191.748.amzn2.x86_64 OpenJDK_64-Bit_Server_VM/17.0.9+8-LTS Java/17.0.9 vendor/Amazon.com_Inc. exec-env/AWS_ECS_FARGATE",
+		"aws-sdk-nodejs/2.1531.0 linux/v18.19.0 exec-env/AWS_Lambda_nodejs18.x promise",
+	}
+	cloudTrailConsoleAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+// S3 객체 키 접두사
+var cloudTrailKeyPrefixes = []string{"uploads/", "exports/orders-", "config/", "backups/db-", "static/js/"}
+
+// cloudTrailUser - IAM 사용자
+type cloudTrailUser struct {
+	name        string
+	principalID string
+	accessKeyID string
+}
+
+// cloudTrailRole - 역할과 현재 세션
+type cloudTrailRole struct {
+	name        string
+	session     string
+	service     string
+	principalID string // AROA... (세션 이름 제외)
+	accessKeyID string // 세션 임시 자격 증명 (ASIA...)
+}
+
+// cloudTrailAccount - 계정별 주체와 공인 IP
+type cloudTrailAccount struct {
+	id      string
+	users   []cloudTrailUser
+	roles   []cloudTrailRole
+	buckets []string
+	natIPs  []string // 역할 세션이 호출하는 NAT 게이트웨이 주소
+}
+
+// CloudTrailGenerator - AWS CloudTrail 레코드 생성기
+//
+// 레코드 하나(S3 로그 파일 Records 배열의 원소)를 한 줄 JSON으로 출력하며,
+// header가 설정되면 syslog MSG로 감싸 전송한다. 계정, IAM 사용자, 역할은
+// 생성기 초기화 시 합성되며 AWS 접근은 필요 없다.
+type CloudTrailGenerator struct {
+	name     string
+	header   *syslogWrapper // nil이면 JSON 원문
+	accounts []cloudTrailAccount
+	actions  cumulativeTable
+	clients  []string // IAM 사용자/루트의 출발지 주소
+	clock    *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newCloudTrailGenerator - CloudTrail 생성기 초기화 (embedded = syslog 헤더 포함)
+func newCloudTrailGenerator(name string, embedded bool, options GeneratorOptions) (*CloudTrailGenerator, error) {
+	weights := make(map[int]float64, len(cloudTrailActions))
+	for i, action := range cloudTrailActions {
+		weights[i] = action.weight
+	}
+	actions, err := newCumulativeTable(weights, "CloudTrail 이벤트")
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &CloudTrailGenerator{
+		name:     name,
+		accounts: make([]cloudTrailAccount, len(awsAccountIDs)),
+		actions:  actions,
+		clients:  newIPPool(rng, []string{"203.0", "198.51", "121.134"}, 64),
+		clock:    getClock(),
+		rng:      rng,
+	}
+	if embedded {
+		if gen.header, err = newSyslogWrapper(options); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, id := range awsAccountIDs {
+		account := &gen.accounts[i]
+		account.id = id
+		for _, user := range cloudTrailUserNames {
+			account.users = append(account.users, cloudTrailUser{
+				name:        user,
+				principalID: string(appendAWSID(nil, rng, "AIDA")),
+				accessKeyID: string(appendAWSID(nil, rng, "AKIA")),
+			})
+		}
+		for _, template := range cloudTrailRoleTemplates {
+			role := cloudTrailRole{
+				name:        template.name,
+				session:     template.session,
+				service:     template.service,
+				principalID: string(appendAWSID(nil, rng, "AROA")),
+				accessKeyID: string(appendAWSID(nil, rng, "ASIA")),
+			}
+			switch template.service {
+			case "ec2.amazonaws.com":
+				role.session = "i-" + string(appendLowerHex(nil, rng, 17))
+			case "ecs-tasks.amazonaws.com":
+				role.session = string(appendLowerHex(nil, rng, 32))
+			}
+			account.roles = append(account.roles, role)
+		}
+		account.buckets = []string{"corp-app-assets-" + id, "corp-exports-" + id, "corp-backups-" + id}
+		account.natIPs = []string{
+			"192.0.2." + strconv.Itoa(1+rng.Intn(254)),
+			"192.0.2." + strconv.Itoa(1+rng.Intn(254)),
+		}
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("cloudtrail", func(options GeneratorOptions) (LogFormatter, error) {
+		return newCloudTrailGenerator("cloudtrail", false, options)
+	})
+	RegisterFormatter("cloudtrail_syslog", func(options GeneratorOptions) (LogFormatter, error) {
+		return newCloudTrailGenerator("cloudtrail_syslog", true, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *CloudTrailGenerator) Name() string {
+	return g.name
+}
+
+// cloudTrailEvent - 렌더링 중인 이벤트
+type cloudTrailEvent struct {
+	action  *cloudTrailAction
+	account *cloudTrailAccount
+	user    *cloudTrailUser
+	role    *cloudTrailRole // AssumedRole 호출자 또는 AssumeRole 대상
+	arn     string          // 호출자 ARN (권한 거부 메시지)
+	region  string
+	failed  bool
+	now     time.Time
+}
+
+// Generate - LogFormatter 구현
+func (g *CloudTrailGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	action := &cloudTrailActions[g.actions.pick(g.rng)]
+	account := &g.accounts[skewedIndex(g.rng, len(g.accounts))]
+	event := cloudTrailEvent{
+		action:  action,
+		account: account,
+		region:  cloudTrailHomeRegion,
+		failed:  action.failRate > 0 && g.rng.Float64() < action.failRate,
+		now:     g.clock.Now().UTC(),
+	}
+	switch {
+	case action.source == "iam.amazonaws.com" || action.source == "signin.amazonaws.com":
+		event.region = cloudTrailGlobalRegion
+	case g.rng.Intn(10) == 0:
+		event.region = cloudTrailGlobalRegion
+	}
+
+	if g.header != nil {
+		severity := 6
+		if event.failed {
+			severity = 4
+		}
+		buffer = g.header.appendHeader(buffer, 1, severity, g.header.pickHost(g.rng), "cloudtrail", "", "")
+	}
+	buffer = g.appendRecord(buffer, &event)
+	return finishBuffer(buffer)
+}
+
+// appendRecord - CloudTrail 레코드 JSON (CloudTrail 필드 순서)
+func (g *CloudTrailGenerator) appendRecord(buffer []byte, event *cloudTrailEvent) []byte {
+	action := event.action
+	buffer = append(buffer, `{"eventVersion":"`+cloudTrailEventVersion+`",`...)
+	buffer, sourceIP, userAgent := g.appendIdentity(buffer, event)
+	buffer = append(buffer, `,"eventTime":"`...)
+	buffer = event.now.AppendFormat(buffer, cloudTrailTimeLayout)
+	buffer = append(buffer, `",`...)
+	buffer = appendJSONField(buffer, "eventSource", action.source)
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "eventName", action.name)
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "awsRegion", event.region)
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "sourceIPAddress", sourceIP)
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "userAgent", userAgent)
+
+	if event.failed {
+		if action.errorCode != "" {
+			buffer = append(buffer, ',')
+			buffer = appendJSONField(buffer, "errorCode", action.errorCode)
+		}
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "errorMessage", g.errorMessage(event))
+	}
+
+	bucket, key := "", ""
+	if action.source == "s3.amazonaws.com" {
+		bucket = event.account.buckets[g.rng.Intn(len(event.account.buckets))]
+		key = g.objectKey(event.now)
+	}
+	buffer = append(buffer, `,"requestParameters":`...)
+	buffer = g.appendRequestParameters(buffer, event, bucket, key)
+	buffer = append(buffer, `,"responseElements":`...)
+	buffer = g.appendResponseElements(buffer, event)
+	if action.name == "ConsoleLogin" {
+		buffer = append(buffer, `,"additionalEventData":{"LoginTo":"https://console.aws.amazon.com/console/home","MobileVersion":"No","MFAUsed":"`...)
+		if event.action.identity == cloudTrailRoot || g.rng.Intn(4) != 0 {
+			buffer = append(buffer, `Yes"}`...)
+		} else {
+			buffer = append(buffer, `No"}`...)
+		}
+	}
+
+	// S3는 16자리 요청 ID, 그 외 서비스는 UUID
+	buffer = append(buffer, `,"requestID":"`...)
+	if action.source == "s3.amazonaws.com" {
+		buffer = appendAWSID(buffer, g.rng, "")
+	} else {
+		buffer = appendUUID(buffer, g.rng)
+	}
+	buffer = append(buffer, `","eventID":"`...)
+	buffer = appendUUID(buffer, g.rng)
+	buffer = append(buffer, `","readOnly":`...)
+	buffer = strconv.AppendBool(buffer, action.readOnly)
+
+	if action.data {
+		buffer = append(buffer, `,"resources":[{"type":"AWS::S3::Object","ARN":"arn:aws:s3:::`...)
+		buffer = append(buffer, bucket...)
+		buffer = append(buffer, '/')
+		buffer = appendJSONEscaped(buffer, key)
+		buffer = append(buffer, `"},{"accountId":"`...)
+		buffer = append(buffer, event.account.id...)
+		buffer = append(buffer, `","type":"AWS::S3::Bucket","ARN":"arn:aws:s3:::`...)
+		buffer = append(buffer, bucket...)
+		buffer = append(buffer, `"}]`...)
+	}
+
+	if action.name == "ConsoleLogin" {
+		buffer = append(buffer, `,"eventType":"AwsConsoleSignIn"`...)
+	} else {
+		buffer = append(buffer, `,"eventType":"AwsApiCall"`...)
+	}
+	buffer = append(buffer, `,"managementEvent":`...)
+	buffer = strconv.AppendBool(buffer, !action.data)
+	buffer = append(buffer, `,"recipientAccountId":"`...)
+	buffer = append(buffer, event.account.id...)
+	if action.data {
+		buffer = append(buffer, `","eventCategory":"Data"}`...)
+	} else {
+		buffer = append(buffer, `","eventCategory":"Management"}`...)
+	}
+	return buffer
+}
+
+// appendIdentity - userIdentity 객체 (출발지 주소와 사용자 에이전트 반환)
+func (g *CloudTrailGenerator) appendIdentity(buffer []byte, event *cloudTrailEvent) ([]byte, string, string) {
+	account := event.account
+	sourceIP := g.clients[skewedIndex(g.rng, len(g.clients))]
+	userAgent := cloudTrailCLIAgents[g.rng.Intn(len(cloudTrailCLIAgents))]
+	if event.action.source == "signin.amazonaws.com" {
+		userAgent = cloudTrailConsoleAgent
+	}
+
+	buffer = append(buffer, `"userIdentity":{"type":"`...)
+	switch event.action.identity {
+	case cloudTrailIAMUser:
+		user := &account.users[skewedIndex(g.rng, len(account.users))]
+		event.user = user
+		event.arn = "arn:aws:iam::" + account.id + ":user/" + user.name
+		buffer = append(buffer, `IAMUser",`...)
+		buffer = appendJSONField(buffer, "principalId", user.principalID)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "arn", event.arn)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "accountId", account.id)
+		if event.action.name != "ConsoleLogin" {
+			buffer = append(buffer, ',')
+			buffer = appendJSONField(buffer, "accessKeyId", user.accessKeyID)
+		}
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "userName", user.name)
+		buffer = append(buffer, '}')
+
+	case cloudTrailAssumedRole:
+		role := &account.roles[g.rng.Intn(len(account.roles))]
+		event.role = role
+		event.arn = "arn:aws:sts::" + account.id + ":assumed-role/" + role.name + "/" + role.session
+		buffer = append(buffer, `AssumedRole",`...)
+		buffer = appendJSONField(buffer, "principalId", role.principalID+":"+role.session)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "arn", event.arn)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "accountId", account.id)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "accessKeyId", role.accessKeyID)
+		buffer = append(buffer, `,"sessionContext":{"sessionIssuer":{"type":"Role",`...)
+		buffer = appendJSONField(buffer, "principalId", role.principalID)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "arn", "arn:aws:iam::"+account.id+":role/"+role.name)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "accountId", account.id)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "userName", role.name)
+		// 세션은 매시 정각에 갱신된 것으로 간주
+		buffer = append(buffer, `},"attributes":{"creationDate":"`...)
+		buffer = event.now.Truncate(time.Hour).AppendFormat(buffer, cloudTrailTimeLayout)
+		buffer = append(buffer, `","mfaAuthenticated":"false"}}}`...)
+
+		switch {
+		case role.service != "":
+			sourceIP = account.natIPs[g.rng.Intn(len(account.natIPs))]
+			userAgent = cloudTrailSDKAgents[g.rng.Intn(len(cloudTrailSDKAgents))]
+		case strings.HasPrefix(role.name, "AWSReservedSSO_"):
+			userAgent = cloudTrailConsoleAgent
+		}
+
+	case cloudTrailAWSService:
+		// 서비스가 대상 역할을 맡는 AssumeRole
+		role := &account.roles[g.rng.Intn(3)]
+		event.role = role
+		buffer = append(buffer, `AWSService",`...)
+		buffer = appendJSONField(buffer, "invokedBy", role.service)
+		buffer = append(buffer, '}')
+		sourceIP, userAgent = role.service, role.service
+
+	default:
+		event.arn = "arn:aws:iam::" + account.id + ":root"
+		buffer = append(buffer, `Root",`...)
+		buffer = appendJSONField(buffer, "principalId", account.id)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "arn", event.arn)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "accountId", account.id)
+		buffer = append(buffer, '}')
+		sourceIP = g.clients[g.rng.Intn(len(g.clients))]
+		userAgent = cloudTrailConsoleAgent
+	}
+	return buffer, sourceIP, userAgent
+}
+
+// errorMessage - 실패 메시지 (권한 거부는 호출자 ARN과 액션 포함)
+func (g *CloudTrailGenerator) errorMessage(event *cloudTrailEvent) string {
+	action := event.action
+	switch {
+	case action.errorMessage != "":
+		return action.errorMessage
+	case action.source == "s3.amazonaws.com":
+		return "Access Denied"
+	}
+	operation := strings.TrimSuffix(action.source, ".amazonaws.com") + ":" + action.name
+	message := "User: " + event.arn + " is not authorized to perform: " + operation +
+		" because no identity-based policy allows the " + operation + " action"
+	if action.errorCode == "Client.UnauthorizedOperation" {
+		return "You are not authorized to perform this operation. " + message
+	}
+	return message
+}
+
+// objectKey - S3 객체 키
+func (g *CloudTrailGenerator) objectKey(now time.Time) string {
+	prefix := cloudTrailKeyPrefixes[g.rng.Intn(len(cloudTrailKeyPrefixes))]
+	switch prefix {
+	case "uploads/":
+		return prefix + string(appendUUID(nil, g.rng)) + ".jpg"
+	case "exports/orders-", "backups/db-":
+		return prefix + now.Format("2006-01-02") + ".csv.gz"
+	case "config/":
+		return prefix + "app.json"
+	}
+	return prefix + "main." + string(appendLowerHex(nil, g.rng, 8)) + ".js"
+}
+
+// appendRequestParameters - 이벤트별 requestParameters (없으면 null)
+func (g *CloudTrailGenerator) appendRequestParameters(buffer []byte, event *cloudTrailEvent, bucket, key string) []byte {
+	account := event.account
+	switch event.action.name {
+	case "GetObject", "PutObject":
+		buffer = append(buffer, '{')
+		buffer = appendJSONField(buffer, "bucketName", bucket)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "Host", bucket+".s3."+event.region+".amazonaws.com")
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "key", key)
+		return append(buffer, '}')
+	case "ListBuckets":
+		return append(buffer, `{"Host":"s3.amazonaws.com"}`...)
+	case "Decrypt":
+		return append(buffer, `{"encryptionAlgorithm":"SYMMETRIC_DEFAULT"}`...)
+	case "AssumeRole":
+		buffer = append(buffer, '{')
+		buffer = appendJSONField(buffer, "roleArn", "arn:aws:iam::"+account.id+":role/"+event.role.name)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "roleSessionName", event.role.session)
+		return append(buffer, `,"durationSeconds":3600}`...)
+	case "DescribeInstances":
+		return append(buffer, `{"instancesSet":{},"filterSet":{}}`...)
+	case "CreateLogStream":
+		buffer = append(buffer, `{"logGroupName":"/aws/lambda/orders-handler","logStreamName":"`...)
+		buffer = event.now.AppendFormat(buffer, "2006/01/02")
+		buffer = append(buffer, `/[$LATEST]`...)
+		buffer = appendLowerHex(buffer, g.rng, 32)
+		return append(buffer, `"}`...)
+	case "RunInstances":
+		buffer = append(buffer, `{"instancesSet":{"items":[{"imageId":"ami-`...)
+		buffer = appendLowerHex(buffer, g.rng, 17)
+		return append(buffer, `","minCount":1,"maxCount":1}]},"instanceType":"m5.large","monitoring":{"enabled":false},"disableApiTermination":false}`...)
+	case "AuthorizeSecurityGroupIngress":
+		buffer = append(buffer, `{"groupId":"sg-`...)
+		buffer = appendLowerHex(buffer, g.rng, 17)
+		return append(buffer, `","ipPermissions":{"items":[{"ipProtocol":"tcp","fromPort":22,"toPort":22,"groups":{},"ipRanges":{"items":[{"cidrIp":"0.0.0.0/0"}]}}]}}`...)
+	case "CreateAccessKey":
+		buffer = append(buffer, '{')
+		buffer = appendJSONField(buffer, "userName", event.user.name)
+		return append(buffer, '}')
+	case "AttachUserPolicy":
+		buffer = append(buffer, '{')
+		buffer = appendJSONField(buffer, "userName", cloudTrailUserNames[g.rng.Intn(len(cloudTrailUserNames))])
+		return append(buffer, `,"policyArn":"arn:aws:iam::aws:policy/AdministratorAccess"}`...)
+	case "StopLogging":
+		return append(buffer, `{"name":"arn:aws:cloudtrail:`+cloudTrailHomeRegion+`:`+account.id+`:trail/`+cloudTrailTrailName+`"}`...)
+	}
+	return append(buffer, "null"...)
+}
+
+// appendResponseElements - 이벤트별 responseElements (실패 또는 없으면 null)
+func (g *CloudTrailGenerator) appendResponseElements(buffer []byte, event *cloudTrailEvent) []byte {
+	switch event.action.name {
+	case "ConsoleLogin":
+		if event.failed {
+			return append(buffer, `{"ConsoleLogin":"Failure"}`...)
+		}
+		return append(buffer, `{"ConsoleLogin":"Success"}`...)
+	}
+	if event.failed {
+		return append(buffer, "null"...)
+	}
+
+	switch event.action.name {
+	case "AssumeRole":
+		role := event.role
+		buffer = append(buffer, `{"credentials":{"accessKeyId":"`...)
+		buffer = append(buffer, role.accessKeyID...)
+		buffer = append(buffer, `","expiration":"`...)
+		buffer = event.now.Add(time.Hour).AppendFormat(buffer, "Jan 2, 2006, 3:04:05 PM")
+		buffer = append(buffer, `"},"assumedRoleUser":{`...)
+		buffer = appendJSONField(buffer, "assumedRoleId", role.principalID+":"+role.session)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "arn", "arn:aws:sts::"+event.account.id+":assumed-role/"+role.name+"/"+role.session)
+		return append(buffer, "}}"...)
+	case "PutObject":
+		return append(buffer, `{"x-amz-server-side-encryption":"aws:kms"}`...)
+	case "CreateAccessKey":
+		buffer = append(buffer, `{"accessKey":{"accessKeyId":"`...)
+		buffer = appendAWSID(buffer, g.rng, "AKIA")
+		return append(buffer, `","status":"Active"}}`...)
+	}
+	return append(buffer, "null"...)
+}
+
+// appendAWSID - AWS 고유 ID (접두사 + 대문자/숫자 16자, S3 요청 ID는 접두사 없음)
+func appendAWSID(buffer []byte, rng *rand.Rand, prefix string) []byte {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	buffer = append(buffer, prefix...)
+	for i := 0; i < 16; i++ {
+		buffer = append(buffer, alphabet[rng.Intn(len(alphabet))])
+	}
+	return buffer
+}
+
+// appendLowerHex - 임의 소문자 16진수 n자리
+func appendLowerHex(buffer []byte, rng *rand.Rand, n int) []byte {
+	for i := 0; i < n; i++ {
+		buffer = append(buffer, "0123456789abcdef"[rng.Intn(16)])
+	}
+	return buffer
+}
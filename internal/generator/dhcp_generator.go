@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DHCP 클라이언트 풀 크기
+const dhcpClientCount = 512
+
+// dhcpSubnet - 서브넷 (로컬 인터페이스 또는 릴레이 에이전트 경유)
+type dhcpSubnet struct {
+	prefix string // "192.168.1."
+	cidr   string
+	via    string // 인터페이스명 또는 릴레이(giaddr) 주소
+	router string // DHCPREQUEST 서버 식별자
+}
+
+var dhcpSubnets = []dhcpSubnet{
+	{"192.168.1.", "192.168.1.0/24", "eth0", "192.168.1.1"},
+	{"10.20.10.", "10.20.10.0/24", "10.20.10.1", "192.168.1.1"},
+	{"10.20.20.", "10.20.20.0/24", "10.20.20.1", "192.168.1.1"},
+	{"10.20.30.", "10.20.30.0/24", "10.20.30.1", "192.168.1.1"},
+}
+
+var dhcpHostnamePrefixes = []string{"laptop-", "desktop-", "iphone-", "galaxy-", "printer-", "mbp-", ""}
+
+// 클라이언트 MAC 제조사 OUI
+var dhcpVendorOUIs = []string{"3c:22:fb", "a8:5e:45", "f0:18:98", "dc:a6:32", "8c:85:90", "00:1b:21"}
+
+// dhcpClient - 클라이언트 (MAC/호스트명/임대 주소가 고정)
+type dhcpClient struct {
+	mac      string
+	hostname string // "(호스트명)" 형태, 없으면 빈 문자열
+	ip       string
+	subnet   *dhcpSubnet
+	bound    bool
+}
+
+// DHCPGenerator - ISC dhcpd 로그 생성기
+//
+// 클라이언트는 MAC별로 고정된 임대 주소를 가지며, 미임대 상태에서는
+// DISCOVER/OFFER/REQUEST/ACK 흐름을, 임대 중에는 갱신(REQUEST/ACK) 또는
+// RELEASE를 생성한다. 흐름의 각 줄은 순서대로 한 줄씩 출력된다.
+type DHCPGenerator struct {
+	header  *syslogWrapper
+	clients []dhcpClient
+	servers []int // 클라이언트 서브넷별 DHCP 서버 호스트 인덱스
+	pids    []string
+
+	pending recordQueue
+	hostIdx int
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newDHCPGenerator - dhcpd 로그 생성기 초기화
+func newDHCPGenerator(options GeneratorOptions) (*DHCPGenerator, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &DHCPGenerator{
+		header:  header,
+		clients: make([]dhcpClient, dhcpClientCount),
+		servers: make([]int, len(dhcpSubnets)),
+		pids:    make([]string, len(header.hostnames)),
+		rng:     rng,
+	}
+	for i := range gen.pids {
+		gen.pids[i] = strconv.Itoa(500 + rng.Intn(3000))
+	}
+	for i := range gen.servers {
+		gen.servers[i] = rng.Intn(len(header.hostnames))
+	}
+	for i := range gen.clients {
+		subnet := &dhcpSubnets[i%len(dhcpSubnets)]
+		client := &gen.clients[i]
+		client.subnet = subnet
+		client.ip = subnet.prefix + strconv.Itoa(20+i/len(dhcpSubnets))
+		client.mac = fmt.Sprintf("%s:%02x:%02x:%02x", dhcpVendorOUIs[rng.Intn(len(dhcpVendorOUIs))],
+			rng.Intn(256), rng.Intn(256), rng.Intn(256))
+		if prefix := dhcpHostnamePrefixes[rng.Intn(len(dhcpHostnamePrefixes))]; prefix != "" {
+			client.hostname = fmt.Sprintf("(%s%03d)", prefix, i)
+		}
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("dhcpd", func(options GeneratorOptions) (LogFormatter, error) {
+		return newDHCPGenerator(options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *DHCPGenerator) Name() string {
+	return "dhcpd"
+}
+
+// Generate - LogFormatter 구현
+func (g *DHCPGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	if g.pending.empty() {
+		g.buildFlow()
+	}
+	buffer = g.header.appendHeader(buffer, 3, 6, g.hostIdx, "dhcpd", g.header.procID(g.rng, g.hostIdx, "dhcpd", g.pids[g.hostIdx]), "")
+	buffer = append(buffer, g.pending.pop()...)
+	return finishBuffer(buffer)
+}
+
+// buildFlow - 클라이언트 하나의 다음 메시지 흐름 렌더링 (호출자가 락 보유)
+func (g *DHCPGenerator) buildFlow() {
+	index := skewedIndex(g.rng, len(g.clients))
+	client := &g.clients[index]
+	g.hostIdx = g.servers[index%len(dhcpSubnets)]
+	q := &g.pending
+	q.reset()
+
+	switch {
+	case !client.bound && g.rng.Intn(100) == 0:
+		// 주소 풀 고갈 (단일 줄, 상태 변화 없음)
+		g.appendMessage("DHCPDISCOVER from ", "", client)
+		q.data = append(q.data, ": network "...)
+		q.data = append(q.data, client.subnet.cidr...)
+		q.data = append(q.data, ": no free leases"...)
+
+	case !client.bound:
+		g.appendMessage("DHCPDISCOVER from ", "", client)
+		g.appendMessage("DHCPOFFER on ", client.ip, client)
+		q.begin()
+		q.data = append(q.data, "DHCPREQUEST for "...)
+		q.data = append(q.data, client.ip...)
+		q.data = append(q.data, " ("...)
+		q.data = append(q.data, client.subnet.router...)
+		q.data = append(q.data, ") from "...)
+		g.appendClient(client)
+		g.appendMessage("DHCPACK on ", client.ip, client)
+		client.bound = true
+
+	case g.rng.Intn(100) < 15:
+		g.appendMessage("DHCPRELEASE of ", client.ip, client)
+		q.data = append(q.data, " (found)"...)
+		client.bound = false
+
+	default:
+		// 임대 갱신 (T1, 유니캐스트)
+		g.appendMessage("DHCPREQUEST for ", client.ip, client)
+		g.appendMessage("DHCPACK on ", client.ip, client)
+	}
+}
+
+// appendMessage - 새 줄: "<prefix>[<ip> to|from ]<mac> (<hostname>) via <iface>"
+func (g *DHCPGenerator) appendMessage(prefix, ip string, client *dhcpClient) {
+	q := &g.pending
+	q.begin()
+	q.data = append(q.data, prefix...)
+	if ip != "" {
+		q.data = append(q.data, ip...)
+		if prefix == "DHCPOFFER on " || prefix == "DHCPACK on " {
+			q.data = append(q.data, " to "...)
+		} else {
+			q.data = append(q.data, " from "...)
+		}
+	}
+	g.appendClient(client)
+}
+
+// appendClient - "<mac> (<hostname>) via <iface>"
+func (g *DHCPGenerator) appendClient(client *dhcpClient) {
+	q := &g.pending
+	q.data = append(q.data, client.mac...)
+	if client.hostname != "" {
+		q.data = append(q.data, ' ')
+		q.data = append(q.data, client.hostname...)
+	}
+	q.data = append(q.data, " via "...)
+	q.data = append(q.data, client.subnet.via...)
+}
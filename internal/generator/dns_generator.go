@@ -0,0 +1,360 @@
+package generator
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 기본 의심 도메인 비율 (DGA + DNS 터널링)
+const defaultDNSSuspiciousRatio = 0.02
+
+// DNSOptions - DNS 서버 로그 출력 옵션
+type DNSOptions struct {
+	// DGA/고엔트로피 도메인과 터널링 질의 비율 (0~1, 절반씩)
+	SuspiciousRatio float64 `json:"suspicious_ratio"`
+}
+
+// Validate - DNS 옵션 검증
+func (o DNSOptions) Validate() error {
+	if o.SuspiciousRatio < 0 || o.SuspiciousRatio > 1 {
+		return fmt.Errorf("DNS 의심 도메인 비율은 0~1 사이여야 합니다: %g", o.SuspiciousRatio)
+	}
+	return nil
+}
+
+// dnsPopularDomains - 일반 도메인 (앞쪽일수록 자주 조회)
+var dnsPopularDomains = []string{
+	"www.google.com", "graph.microsoft.com", "login.microsoftonline.com", "outlook.office365.com",
+	"www.youtube.com", "api.github.com", "s3.amazonaws.com", "slack.com", "www.naver.com",
+	"update.googleapis.com", "time.windows.com", "ocsp.digicert.com", "cdn.jsdelivr.net",
+	"registry.npmjs.org", "pypi.org", "zoom.us", "teams.microsoft.com", "www.kakao.com",
+	"fonts.googleapis.com", "dc01.corp.local", "ldap.corp.local", "wpad.corp.local",
+	"api.slack.com", "d1.awsstatic.com", "connectivitycheck.gstatic.com", "www.apple.com",
+	"mirrors.ubuntu.com", "security.ubuntu.com", "docker.io", "registry-1.docker.io",
+}
+
+// 일반 질의 타입 분포
+var dnsQueryTypes = map[int]float64{
+	dnsTypeA: 60, dnsTypeAAAA: 25, dnsTypeHTTPS: 6, dnsTypeMX: 2, dnsTypeTXT: 2, dnsTypeSRV: 3, dnsTypePTR: 2,
+}
+
+const (
+	dnsTypeA = iota
+	dnsTypeAAAA
+	dnsTypeHTTPS
+	dnsTypeMX
+	dnsTypeTXT
+	dnsTypeSRV
+	dnsTypePTR
+)
+
+var dnsTypeNames = []string{"A", "AAAA", "HTTPS", "MX", "TXT", "SRV", "PTR"}
+
+// DGA 도메인 TLD
+var dnsDGATLDs = []string{"com", "net", "info", "biz", "xyz", "top", "ru", "cc"}
+
+// 터널링 수신 도메인 (공격자 권한 서버)
+var dnsTunnelDomains = []string{"t.cdn-sync.net", "ns.update-check.info", "d.telemetry-api.xyz"}
+
+// dnsQuery - 선택된 질의
+type dnsQuery struct {
+	name     string
+	qtype    int
+	clientIP string
+	nxdomain bool
+}
+
+// dnsQuerySource - BIND/dnsmasq 공용 질의 선택기
+type dnsQuerySource struct {
+	suspiciousRatio float64
+	types           cumulativeTable
+	clients         []string
+	infected        []string // 터널링 질의를 보내는 감염 호스트
+}
+
+// newDNSQuerySource - 질의 선택기 초기화
+func newDNSQuerySource(rng *rand.Rand, options DNSOptions) (*dnsQuerySource, error) {
+	types, err := newCumulativeTable(dnsQueryTypes, "DNS 질의 타입")
+	if err != nil {
+		return nil, err
+	}
+	clients := newIPPool(rng, []string{"10.1", "10.2", "192.168"}, 512)
+	return &dnsQuerySource{
+		suspiciousRatio: options.SuspiciousRatio,
+		types:           types,
+		clients:         clients,
+		infected:        clients[:3],
+	}, nil
+}
+
+// pick - 다음 질의 (일반 / DGA / 터널링)
+func (s *dnsQuerySource) pick(rng *rand.Rand) dnsQuery {
+	if rng.Float64() < s.suspiciousRatio {
+		if rng.Intn(2) == 0 {
+			// DGA: 무작위 레이블, 대부분 NXDOMAIN
+			return dnsQuery{
+				name:     dnsDGAName(rng),
+				qtype:    dnsTypeA,
+				clientIP: s.clients[rng.Intn(len(s.clients))],
+				nxdomain: rng.Intn(10) != 0,
+			}
+		}
+		// 터널링: 긴 16진수 레이블을 감염 호스트가 반복 질의
+		qtype := dnsTypeTXT
+		if rng.Intn(4) == 0 {
+			qtype = dnsTypeA
+		}
+		return dnsQuery{
+			name:     dnsTunnelName(rng),
+			qtype:    qtype,
+			clientIP: s.infected[rng.Intn(len(s.infected))],
+		}
+	}
+
+	query := dnsQuery{
+		name:     dnsPopularDomains[skewedIndex(rng, len(dnsPopularDomains))],
+		qtype:    s.types.pick(rng),
+		clientIP: s.clients[skewedIndex(rng, len(s.clients))],
+	}
+	if query.qtype == dnsTypePTR {
+		ip := dnsAnswerIPv4(query.name)
+		query.name = fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", ip[3], ip[2], ip[1], ip[0])
+	}
+	return query
+}
+
+// dnsDGAName - 무작위 영숫자 레이블 + TLD
+func dnsDGAName(rng *rand.Rand) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	length := 10 + rng.Intn(15)
+	name := make([]byte, 0, length+5)
+	for i := 0; i < length; i++ {
+		letters := len(alphabet)
+		if i == 0 {
+			letters = 26 // 첫 글자는 영문
+		}
+		name = append(name, alphabet[rng.Intn(letters)])
+	}
+	name = append(name, '.')
+	name = append(name, dnsDGATLDs[rng.Intn(len(dnsDGATLDs))]...)
+	return string(name)
+}
+
+// dnsTunnelName - 인코딩된 데이터 레이블 (최대 63자) + 순번 + 터널 도메인
+func dnsTunnelName(rng *rand.Rand) string {
+	const hex = "0123456789abcdef"
+	name := make([]byte, 0, 160)
+	for labels := 1 + rng.Intn(2); labels > 0; labels-- {
+		for i := 32 + rng.Intn(32); i > 0; i-- {
+			name = append(name, hex[rng.Intn(16)])
+		}
+		name = append(name, '.')
+	}
+	name = strconv.AppendInt(name, int64(rng.Intn(65536)), 16)
+	name = append(name, '.')
+	name = append(name, dnsTunnelDomains[rng.Intn(len(dnsTunnelDomains))]...)
+	return string(name)
+}
+
+// dnsAnswerIPv4 - 도메인별 고정 응답 주소 (같은 이름은 항상 같은 주소)
+func dnsAnswerIPv4(name string) [4]byte {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	sum := h.Sum32()
+	if len(name) > 6 && name[len(name)-6:] == ".local" {
+		return [4]byte{10, 0, byte(sum >> 8), byte(sum)%250 + 2}
+	}
+	return [4]byte{byte(sum>>24)%180 + 20, byte(sum >> 16), byte(sum >> 8), byte(sum)%250 + 2}
+}
+
+// appendDNSAnswer - 질의 타입별 응답 값 (dnsmasq reply 형식)
+func appendDNSAnswer(buffer []byte, query *dnsQuery) []byte {
+	ip := dnsAnswerIPv4(query.name)
+	switch query.qtype {
+	case dnsTypeAAAA:
+		buffer = append(buffer, "2606:4700:"...)
+		buffer = strconv.AppendInt(buffer, int64(ip[0]), 16)
+		buffer = append(buffer, "::"...)
+		return strconv.AppendInt(buffer, int64(ip[2])<<8|int64(ip[3]), 16)
+	case dnsTypeA:
+		return appendIPv4(buffer, ip)
+	case dnsTypePTR:
+		return append(buffer, "host.example.net"...)
+	default:
+		// dnsmasq는 A/AAAA/CNAME 외 레코드를 <타입>으로 기록
+		buffer = append(buffer, '<')
+		buffer = append(buffer, dnsTypeNames[query.qtype]...)
+		return append(buffer, '>')
+	}
+}
+
+// appendIPv4 - 점 표기 IPv4
+func appendIPv4(buffer []byte, ip [4]byte) []byte {
+	for i, octet := range ip {
+		if i > 0 {
+			buffer = append(buffer, '.')
+		}
+		buffer = strconv.AppendInt(buffer, int64(octet), 10)
+	}
+	return buffer
+}
+
+// DNSGenerator - DNS 서버 질의 로그 생성기 (BIND queries / dnsmasq)
+type DNSGenerator struct {
+	name    string
+	dnsmasq bool
+	header  *syslogWrapper
+	queries *dnsQuerySource
+
+	pids      []string // 호스트별 데몬 PID
+	upstreams []string // dnsmasq 상위 리졸버
+	pending   recordQueue
+	hostIdx   int
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newDNSGenerator - DNS 로그 생성기 초기화
+func newDNSGenerator(name string, dnsmasq bool, options GeneratorOptions) (*DNSGenerator, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	queries, err := newDNSQuerySource(rng, options.DNS)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &DNSGenerator{
+		name:      name,
+		dnsmasq:   dnsmasq,
+		header:    header,
+		queries:   queries,
+		pids:      make([]string, len(header.hostnames)),
+		upstreams: []string{"8.8.8.8", "1.1.1.1", "9.9.9.9"},
+		rng:       rng,
+	}
+	for i := range gen.pids {
+		gen.pids[i] = strconv.Itoa(300 + rng.Intn(3000))
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("bind", func(options GeneratorOptions) (LogFormatter, error) {
+		return newDNSGenerator("bind", false, options)
+	})
+	RegisterFormatter("dnsmasq", func(options GeneratorOptions) (LogFormatter, error) {
+		return newDNSGenerator("dnsmasq", true, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *DNSGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+func (g *DNSGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	if !g.dnsmasq {
+		hostIdx := g.header.pickHost(g.rng)
+		buffer = g.header.appendHeader(buffer, 3, 6, hostIdx, "named", g.header.procID(g.rng, hostIdx, "named", g.pids[hostIdx]), "")
+		buffer = g.appendBindQuery(buffer, hostIdx)
+		return finishBuffer(buffer)
+	}
+
+	// dnsmasq는 질의 하나가 query → forwarded/cached → reply 여러 줄로 기록됨
+	if g.pending.empty() {
+		g.hostIdx = g.header.pickHost(g.rng)
+		g.buildDnsmasq()
+	}
+	buffer = g.header.appendHeader(buffer, 3, 6, g.hostIdx, "dnsmasq", g.header.procID(g.rng, g.hostIdx, "dnsmasq", g.pids[g.hostIdx]), "")
+	buffer = append(buffer, g.pending.pop()...)
+	return finishBuffer(buffer)
+}
+
+// appendBindQuery - BIND 9 queries 카테고리
+// client @0x7f... 10.1.2.3#53211 (name): query: name IN A +E(0)K (10.0.0.53)
+func (g *DNSGenerator) appendBindQuery(buffer []byte, hostIdx int) []byte {
+	query := g.queries.pick(g.rng)
+
+	buffer = append(buffer, "client @0x"...)
+	buffer = strconv.AppendUint(buffer, 0x7f0000000000+uint64(g.rng.Int63n(1<<40)), 16)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, query.clientIP...)
+	buffer = append(buffer, '#')
+	buffer = strconv.AppendInt(buffer, int64(1024+g.rng.Intn(64511)), 10)
+	buffer = append(buffer, " ("...)
+	buffer = append(buffer, query.name...)
+	buffer = append(buffer, "): query: "...)
+	buffer = append(buffer, query.name...)
+	buffer = append(buffer, " IN "...)
+	buffer = append(buffer, dnsTypeNames[query.qtype]...)
+
+	// +: 재귀 요청, E(0): EDNS0, K: 쿠키, T: TCP, D: DNSSEC OK
+	switch r := g.rng.Intn(20); {
+	case r < 14:
+		buffer = append(buffer, " +E(0)K ("...)
+	case r < 17:
+		buffer = append(buffer, " + ("...)
+	case r < 19:
+		buffer = append(buffer, " +ED ("...)
+	default:
+		buffer = append(buffer, " +ET ("...)
+	}
+	buffer = append(buffer, "10.0.0."...)
+	buffer = strconv.AppendInt(buffer, int64(53+hostIdx), 10)
+	return append(buffer, ')')
+}
+
+// buildDnsmasq - dnsmasq 질의 한 건의 로그 줄 묶음 렌더링 (호출자가 락 보유)
+func (g *DNSGenerator) buildDnsmasq() {
+	query := g.queries.pick(g.rng)
+	q := &g.pending
+	q.reset()
+
+	q.begin()
+	q.data = append(q.data, "query["...)
+	q.data = append(q.data, dnsTypeNames[query.qtype]...)
+	q.data = append(q.data, "] "...)
+	q.data = append(q.data, query.name...)
+	q.data = append(q.data, " from "...)
+	q.data = append(q.data, query.clientIP...)
+
+	// 자주 조회되는 일반 도메인은 캐시 응답
+	if !query.nxdomain && query.qtype <= dnsTypeAAAA && g.rng.Intn(100) < 45 && len(query.name) < 40 {
+		q.begin()
+		q.data = append(q.data, "cached "...)
+		q.data = append(q.data, query.name...)
+		q.data = append(q.data, " is "...)
+		q.data = appendDNSAnswer(q.data, &query)
+		return
+	}
+
+	q.begin()
+	q.data = append(q.data, "forwarded "...)
+	q.data = append(q.data, query.name...)
+	q.data = append(q.data, " to "...)
+	q.data = append(q.data, g.upstreams[g.rng.Intn(len(g.upstreams))]...)
+
+	q.begin()
+	q.data = append(q.data, "reply "...)
+	q.data = append(q.data, query.name...)
+	q.data = append(q.data, " is "...)
+	if query.nxdomain {
+		q.data = append(q.data, "NXDOMAIN"...)
+	} else {
+		q.data = appendDNSAnswer(q.data, &query)
+	}
+}
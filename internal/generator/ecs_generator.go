@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"strconv"
+)
+
+// ECS 스키마 버전
+const ecsVersion = "8.11.0"
+
+// ecsMessageMeta - 메시지 템플릿별 ECS 분류 (event.category/type/outcome, source.ip, user.name)
+type ecsMessageMeta struct {
+	category string
+	kind     string // event.type
+	outcome  string
+	sourceIP string
+	userName string
+}
+
+// 시스템 로그 메시지 → ECS 분류 (SystemLogGenerator 메시지 템플릿과 1:1)
+var ecsMessageMetas = map[string]ecsMessageMeta{
+	"Starting nginx.service":                                {category: "process", kind: "start"},
+	"Started nginx.service":                                 {category: "process", kind: "start", outcome: "success"},
+	"Stopping nginx.service":                                {category: "process", kind: "end"},
+	"Starting docker.service":                               {category: "process", kind: "start"},
+	"Started docker.service":                                {category: "process", kind: "start", outcome: "success"},
+	"Unit entered failed state":                             {category: "process", kind: "error", outcome: "failure"},
+	"CPU0: temperature above threshold":                     {category: "host", kind: "info"},
+	"Out of memory: Kill process":                           {category: "process", kind: "end"},
+	"device eth0: link up":                                  {category: "network", kind: "info"},
+	"TCP: Possible SYN flooding on port 80":                 {category: "intrusion_detection", kind: "info"},
+	"oom-killer: Killed process":                            {category: "process", kind: "end"},
+	"Accepted password for admin from 192.168.1.100":        {category: "authentication", kind: "start", outcome: "success", sourceIP: "192.168.1.100", userName: "admin"},
+	"Failed password for admin from 192.168.1.200":          {category: "authentication", kind: "start", outcome: "failure", sourceIP: "192.168.1.200", userName: "admin"},
+	"Connection closed by 192.168.1.100":                    {category: "session", kind: "end", sourceIP: "192.168.1.100"},
+	"pam_unix(sshd:session): session opened for user admin": {category: "session", kind: "start", outcome: "success", userName: "admin"},
+	"(root) CMD (/usr/bin/updatedb)":                        {category: "process", kind: "start", userName: "root"},
+	"action 'action 17' suspended":                          {category: "host", kind: "info"},
+	"device (eth0): state change":                           {category: "network", kind: "info"},
+	"Certificate will expire":                               {category: "configuration", kind: "info"},
+	"Disk space warning: /var partition at 85%":             {category: "host", kind: "info"},
+
+	// 인벤토리 역할 전용 이벤트 (system_events.go inventoryEventCategories)
+	"Starting mysqld.service":       {category: "process", kind: "start"},
+	"Started mysqld.service":        {category: "process", kind: "start", outcome: "success"},
+	"Starting redis-server.service": {category: "process", kind: "start"},
+	"Started redis-server.service":  {category: "process", kind: "start", outcome: "success"},
+	"upstream timed out (110: Connection timed out) while reading response header from upstream": {category: "web", kind: "error", outcome: "failure"},
+	"an upstream response is buffered to a temporary file":                                       {category: "web", kind: "info"},
+	"Aborted connection to db: 'app' user: 'app' (Got timeout reading communication packets)":    {category: "database", kind: "connection", outcome: "failure", userName: "app"},
+	"InnoDB: Buffer pool(s) load completed":                                                      {category: "database", kind: "info"},
+	"Slow query: Query_time 12.4 Lock_time 0.0 Rows_examined 1842211":                            {category: "database", kind: "info"},
+	"Background saving started":                                                                  {category: "database", kind: "info"},
+	"DB saved on disk":                                                                           {category: "database", kind: "change", outcome: "success"},
+	"Asynchronous AOF fsync is taking too long (disk is busy?)":                                  {category: "database", kind: "info"},
+}
+
+// ecsDefaultMeta - 분류가 없는 메시지
+var ecsDefaultMeta = ecsMessageMeta{category: "host", kind: "info"}
+
+// ECSGenerator - Elastic Common Schema NDJSON 로그 생성기
+//
+// SystemLogGenerator가 선택한 이벤트를 그대로 ECS 문서로 렌더링하며,
+// header가 설정되면 같은 문서를 syslog MSG로 감싸 전송한다.
+type ECSGenerator struct {
+	name   string
+	events *SystemLogGenerator
+	header *syslogWrapper // nil이면 JSON 원문
+
+	// 사전 조립된 JSON 조각 (인덱스는 events의 풀과 1:1)
+	hostFields    []string // "host":{"name":...}
+	processNames  []string // "process":{"name":...
+	messageFields []string // ,"event":{...} ... "message":"..."}
+	templateField string   // 템플릿 메시지 뒤의 ,"event":{...},"sequence":
+	logFields     [192]string
+}
+
+// newECSGenerator - ECS 생성기 초기화 (embedded = syslog 헤더 포함)
+func newECSGenerator(name string, embedded bool, options GeneratorOptions) (*ECSGenerator, error) {
+	events, err := newSyslogFormatter(options)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &ECSGenerator{
+		name:   name,
+		events: events.(*SystemLogGenerator),
+	}
+	if embedded {
+		if gen.header, err = newSyslogWrapper(options); err != nil {
+			return nil, err
+		}
+	}
+	gen.precompute()
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("ecs", func(options GeneratorOptions) (LogFormatter, error) {
+		return newECSGenerator("ecs", false, options)
+	})
+	RegisterFormatter("ecs_syslog", func(options GeneratorOptions) (LogFormatter, error) {
+		return newECSGenerator("ecs_syslog", true, options)
+	})
+}
+
+// precompute - 변하지 않는 JSON 조각 사전 생성 (생성 시 이스케이프 비용 제거)
+func (g *ECSGenerator) precompute() {
+	events := g.events
+
+	g.hostFields = make([]string, len(events.hostnames))
+	for i, hostname := range events.hostnames {
+		g.hostFields[i] = `,"host":{"name":` + jsonString(hostname) + `,"hostname":` + jsonString(hostname)
+		if events.hosts != nil {
+			// 인벤토리 호스트는 자산 DB와 맞출 수 있도록 주소 포함
+			g.hostFields[i] += `,"ip":[` + jsonString(events.hosts[i].IP) + `]`
+		}
+		g.hostFields[i] += `}`
+	}
+
+	g.processNames = make([]string, len(events.services))
+	for i, service := range events.services {
+		g.processNames[i] = `,"process":{"name":` + jsonString(service) + `,"pid":`
+	}
+
+	g.messageFields = make([]string, len(events.messages))
+	for i, message := range events.messages {
+		meta, ok := ecsMessageMetas[message]
+		if !ok {
+			meta = ecsDefaultMeta
+		}
+
+		var field []byte
+		if meta.sourceIP != "" {
+			field = append(field, `,"source":{"ip":`...)
+			field = appendJSONString(field, meta.sourceIP)
+			field = append(field, '}')
+		}
+		if meta.userName != "" {
+			field = append(field, `,"user":{"name":`...)
+			field = appendJSONString(field, meta.userName)
+			field = append(field, '}')
+		}
+		field = append(field, `,"message":`...)
+		field = appendJSONString(field, message)
+		field = appendECSEvent(field, meta)
+		g.messageFields[i] = string(field)
+	}
+	// 사용자 정의 템플릿은 분류 정보가 없으므로 기본 분류 사용
+	g.templateField = string(appendECSEvent(nil, ecsDefaultMeta))
+
+	// log.level + log.syslog (PRI별)
+	for pri := range g.logFields {
+		facility, severity := pri/8, pri%8
+		var field []byte
+		field = append(field, `,"log":{`...)
+		field = appendJSONField(field, "level", severityNames[severity])
+		field = append(field, `,"syslog":{"facility":{`...)
+		field = appendJSONInt(field, "code", int64(facility))
+		field = append(field, ',')
+		field = appendJSONField(field, "name", facilityNames[facility])
+		field = append(field, `},"severity":{`...)
+		field = appendJSONInt(field, "code", int64(severity))
+		field = append(field, ',')
+		field = appendJSONField(field, "name", severityNames[severity])
+		field = append(field, `},`...)
+		field = appendJSONInt(field, "priority", int64(pri))
+		field = append(field, `}}`...)
+		g.logFields[pri] = string(field)
+	}
+}
+
+// appendECSEvent - ,"event":{...},"sequence": 조각
+func appendECSEvent(field []byte, meta ecsMessageMeta) []byte {
+	field = append(field, `,"event":{"kind":"event","category":[`...)
+	field = appendJSONString(field, meta.category)
+	field = append(field, `],"type":[`...)
+	field = appendJSONString(field, meta.kind)
+	field = append(field, ']')
+	if meta.outcome != "" {
+		field = append(field, ',')
+		field = appendJSONField(field, "outcome", meta.outcome)
+	}
+	return append(field, `,"sequence":`...)
+}
+
+// Name - LogFormatter 구현
+func (g *ECSGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+// {"@timestamp":...,"ecs":{...},"host":{...},"process":{...},"log":{...},"message":...,"event":{...}}
+func (g *ECSGenerator) Generate() []byte {
+	buffer := getBuffer()
+	event, _ := g.events.pickEvent()
+	pid := event.pid
+
+	if g.header != nil {
+		service := g.events.services[event.serviceIdx]
+		buffer = g.header.appendHeader(buffer, event.facility, event.severity, event.hostnameIdx,
+			service, pid, g.events.msgIDs[event.serviceIdx])
+	}
+
+	buffer = append(buffer, `{"@timestamp":"`...)
+	buffer = append(buffer, g.events.clock.RFC3164()...)
+	buffer = append(buffer, `","ecs":{"version":"`+ecsVersion+`"}`...)
+	buffer = append(buffer, g.hostFields[event.hostnameIdx]...)
+	buffer = append(buffer, g.processNames[event.serviceIdx]...)
+	buffer = append(buffer, pid...)
+	buffer = append(buffer, '}')
+	buffer = append(buffer, g.logFields[event.facility*8+event.severity]...)
+	if g.events.templates != nil {
+		buffer = append(buffer, `,"message":`...)
+		buffer = g.events.appendMessageJSON(buffer, event)
+		buffer = append(buffer, g.templateField...)
+	} else {
+		buffer = append(buffer, g.messageFields[event.messageIdx]...)
+	}
+	buffer = strconv.AppendUint(buffer, event.sequenceID, 10)
+	buffer = append(buffer, `}}`...)
+
+	return finishBuffer(buffer)
+}
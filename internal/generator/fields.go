@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// 여러 포맷터가 공유하는 필드 값 풀과 난수 헬퍼
+
+// 사용자명 풀 (인증/웹/보안 이벤트 공용)
+var commonUsernames = []string{
+	"admin", "root", "jsmith", "mkim", "lee.j", "park.s", "choi", "deploy",
+	"jenkins", "svc_backup", "oracle", "postgres", "ubuntu", "ec2-user", "alice", "bob",
+}
+
+// 사용자 에이전트 풀 (브라우저 + 봇 + CLI)
+var commonUserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14; SM-S918N) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+	"Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)",
+	"curl/8.4.0",
+	"python-requests/2.31.0",
+	"Go-http-client/1.1",
+	"kube-probe/1.28",
+}
+
+// newIPPool - 사전 생성된 IPv4 문자열 풀 (prefix.x.y 형태, 문자열 변환 오버헤드 제거)
+func newIPPool(rng *rand.Rand, prefixes []string, size int) []string {
+	pool := make([]string, size)
+	for i := range pool {
+		prefix := prefixes[i%len(prefixes)]
+		pool[i] = prefix + "." + strconv.Itoa(rng.Intn(256)) + "." + strconv.Itoa(1+rng.Intn(254))
+	}
+	return pool
+}
+
+// skewedIndex - 앞쪽 인덱스에 편중된 선택 (소수의 헤비 히터를 흉내)
+func skewedIndex(rng *rand.Rand, n int) int {
+	r := rng.Float64()
+	return int(r * r * float64(n))
+}
+
+// logUniform - [min, max] 구간의 로그 균등 분포 정수 (크기/지연 시간처럼 긴 꼬리를 갖는 값)
+func logUniform(rng *rand.Rand, min, max int) int {
+	if min <= 0 {
+		min = 1
+	}
+	if max <= min {
+		return min
+	}
+	return int(float64(min) * math.Pow(float64(max)/float64(min), rng.Float64()))
+}
+
+// appendRandomIPv4 - 사설 대역의 임의 IPv4 주소 추가
+func appendRandomIPv4(buffer []byte, rng *rand.Rand, firstOctet int) []byte {
+	buffer = strconv.AppendInt(buffer, int64(firstOctet), 10)
+	buffer = append(buffer, '.')
+	buffer = strconv.AppendInt(buffer, int64(rng.Intn(256)), 10)
+	buffer = append(buffer, '.')
+	buffer = strconv.AppendInt(buffer, int64(rng.Intn(256)), 10)
+	buffer = append(buffer, '.')
+	buffer = strconv.AppendInt(buffer, int64(1+rng.Intn(254)), 10)
+	return buffer
+}
+
+// appendUUID - 임의 UUID v4 추가 (소문자 8-4-4-4-12)
+func appendUUID(buffer []byte, rng *rand.Rand) []byte {
+	const hex = "0123456789abcdef"
+	high, low := rng.Uint64(), rng.Uint64()
+	high = high&^0xf000 | 0x4000   // 버전 4
+	low = low&^(0xc<<60) | 0x8<<60 // RFC 4122 변형
+	for i := 60; i >= 0; i -= 4 {
+		if i == 28 || i == 12 {
+			buffer = append(buffer, '-')
+		}
+		buffer = append(buffer, hex[high>>uint(i)&0xf])
+	}
+	buffer = append(buffer, '-')
+	for i := 60; i >= 0; i -= 4 {
+		if i == 44 {
+			buffer = append(buffer, '-')
+		}
+		buffer = append(buffer, hex[low>>uint(i)&0xf])
+	}
+	return buffer
+}
+
+// finishBuffer - 풀 버퍼를 호출자 소유 슬라이스로 복사하고 반환
+func finishBuffer(buffer []byte) []byte {
+	result := make([]byte, len(buffer))
+	copy(result, buffer)
+	logBufferPool.Put(buffer[:0])
+	return result
+}
+
+// getBuffer - 풀에서 빈 버퍼 획득
+func getBuffer() []byte {
+	return logBufferPool.Get().([]byte)[:0]
+}
@@ -0,0 +1,738 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// firewallVendor - 방화벽 로그 형식
+type firewallVendor int
+
+const (
+	vendorCiscoASA  firewallVendor = iota // %ASA-6-302013 빌드/티어다운
+	vendorPANOS                           // PAN-OS TRAFFIC/THREAT CSV
+	vendorFortiGate                       // FortiGate key=value
+	vendorIptables                        // netfilter LOG 타깃 (커널 메시지)
+)
+
+// 연결 추적 파라미터
+const (
+	fwMaxOpenConns = 4096 // 티어다운 대기 중인 최대 연결 수
+	fwDenyRatio    = 0.12 // 정책 차단 이벤트 비율
+	fwThreatRatio  = 0.04 // PAN-OS THREAT 로그 비율 (열린 세션에 귀속)
+)
+
+// fwPhase - 연결 수명 주기 단계
+type fwPhase int
+
+const (
+	fwBuild    fwPhase = iota // 세션 시작 (ASA Built, PAN start, Forti start)
+	fwTeardown                // 세션 종료 (같은 연결 ID)
+	fwDeny                    // 정책 차단 (단발성)
+	fwThreat                  // 위협 탐지 (열린 세션에 귀속)
+)
+
+// fwService - 목적지 서비스 (포트/프로토콜/애플리케이션 이름)
+type fwService struct {
+	port     int
+	proto    string // tcp, udp
+	panApp   string
+	category string // PAN-OS URL 카테고리
+	forti    string // FortiGate service 이름
+	weight   float64
+}
+
+var fwServices = []fwService{
+	{443, "tcp", "ssl", "computer-and-internet-info", "HTTPS", 45},
+	{80, "tcp", "web-browsing", "business-and-economy", "HTTP", 15},
+	{53, "udp", "dns", "any", "DNS", 15},
+	{123, "udp", "ntp", "any", "NTP", 3},
+	{22, "tcp", "ssh", "any", "SSH", 4},
+	{25, "tcp", "smtp", "any", "SMTP", 3},
+	{3389, "tcp", "ms-rdp", "any", "RDP", 2},
+	{445, "tcp", "ms-ds-smb", "any", "SMB", 3},
+	{3306, "tcp", "mysql", "any", "MYSQL", 2},
+	{8080, "tcp", "web-browsing", "computer-and-internet-info", "HTTP-8080", 8},
+}
+
+// 외부에서 들어오는 차단 트래픽의 목적지 포트 (스캔/무차별 대입)
+var fwDeniedPorts = []int{22, 23, 445, 1433, 3306, 3389, 5900, 8443}
+
+var fwTeardownReasons = []string{"TCP FINs", "TCP FINs", "TCP FINs", "TCP Reset-I", "TCP Reset-O", "Conn-timeout", "SYN Timeout"}
+var fwPANEndReasons = []string{"tcp-fin", "tcp-fin", "tcp-fin", "tcp-rst-from-client", "tcp-rst-from-server", "aged-out", "aged-out"}
+var fwFortiCloseActions = []string{"close", "close", "close", "client-rst", "server-rst", "timeout", "timeout"}
+
+var fwPANThreats = []struct {
+	subtype, name, category, severity, target string
+}{
+	{"vulnerability", "Microsoft Windows SMB Remote Code Execution Vulnerability(41327)", "code-execution", "critical", ""},
+	{"vulnerability", "Apache Log4j Remote Code Execution Vulnerability(91991)", "code-execution", "critical", "api.example.com/v1/login"},
+	{"spyware", "Cobalt Strike Beacon Command and Control Traffic Detection(86445)", "command-and-control", "high", ""},
+	{"virus", "Virus/Win32.WGeneric.abcdef(326412345)", "any", "medium", "invoice.exe"},
+	{"url", "(9999)", "malware", "informational", "malicious.example.net/payload"},
+}
+
+// fwConn - 추적 중인 연결 (빌드와 티어다운이 같은 값을 사용)
+type fwConn struct {
+	id         uint64
+	hostIdx    int
+	service    *fwService
+	srcIP      string
+	srcPort    int
+	dstIP      string
+	dstPort    int
+	natIP      string
+	natPort    int
+	user       string // 도메인 없는 계정명 (PAN-OS는 corp\ 접두사)
+	rule       string
+	started    string // PAN-OS Start Time (빌드 시점)
+	startedSec int64  // 지속 시간 계산용
+}
+
+// FirewallGenerator - 방화벽 벤더 로그 생성기 (Cisco ASA / PAN-OS / FortiGate / iptables)
+type FirewallGenerator struct {
+	name   string
+	vendor firewallVendor
+	header *syslogWrapper
+
+	services   cumulativeTable
+	insideIPs  []string
+	outsideIPs []string
+	natIPs     []string // 장비(호스트)별 외부 NAT 주소
+	serials    []string // 장비별 시리얼 (PAN-OS serial, FortiGate devid)
+
+	open     []fwConn
+	nextID   uint64
+	sequence uint64
+	bootSec  int64 // iptables 커널 타임스탬프 기준 (가상 부팅 시각)
+	clock    *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newFirewallGenerator - 벤더별 방화벽 로그 생성기 초기화
+func newFirewallGenerator(name string, vendor firewallVendor, options GeneratorOptions) (*FirewallGenerator, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[int]float64, len(fwServices))
+	for i, service := range fwServices {
+		weights[i] = service.weight
+	}
+	services, err := newCumulativeTable(weights, "방화벽 서비스")
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &FirewallGenerator{
+		name:       name,
+		vendor:     vendor,
+		header:     header,
+		services:   services,
+		insideIPs:  newIPPool(rng, []string{"10.1", "10.2", "10.10", "192.168"}, 1024),
+		outsideIPs: newIPPool(rng, []string{"203.0", "198.51", "142.250", "52.95", "151.101"}, 2048),
+		natIPs:     make([]string, len(header.hostnames)),
+		serials:    make([]string, len(header.hostnames)),
+		open:       make([]fwConn, 0, fwMaxOpenConns),
+		nextID:     uint64(100000 + rng.Intn(900000)),
+		bootSec:    time.Now().Unix() - int64(86400+rng.Intn(30*86400)),
+		clock:      getClock(),
+		rng:        rng,
+	}
+	for i := range header.hostnames {
+		gen.natIPs[i] = fmt.Sprintf("198.18.%d.%d", i/250, i%250+1)
+		switch vendor {
+		case vendorFortiGate:
+			gen.serials[i] = fmt.Sprintf("FG100F%010d", 3901+i)
+		default:
+			gen.serials[i] = fmt.Sprintf("0079010%05d", 1000+i)
+		}
+	}
+	return gen, nil
+}
+
+func init() {
+	register := func(name string, vendor firewallVendor) {
+		RegisterFormatter(name, func(options GeneratorOptions) (LogFormatter, error) {
+			return newFirewallGenerator(name, vendor, options)
+		})
+	}
+	register("cisco_asa", vendorCiscoASA)
+	register("panos", vendorPANOS)
+	register("fortigate", vendorFortiGate)
+	register("iptables", vendorIptables)
+}
+
+// Name - LogFormatter 구현
+func (g *FirewallGenerator) Name() string {
+	return g.name
+}
+
+// next - 다음 이벤트 단계와 연결 선택 (호출자가 rng 락 보유)
+//
+// 빌드된 연결은 open에 보관했다가 이후 티어다운에서 같은 ID로 종료한다.
+func (g *FirewallGenerator) next() (fwPhase, fwConn) {
+	r := g.rng.Float64()
+	switch {
+	case r < fwDenyRatio:
+		return fwDeny, g.deniedConn()
+	case g.vendor == vendorPANOS && r < fwDenyRatio+fwThreatRatio && len(g.open) > 0:
+		return fwThreat, g.open[g.rng.Intn(len(g.open))]
+	}
+
+	// iptables는 세션 종료를 기록하지 않으므로 빌드만 생성
+	if g.vendor != vendorIptables && len(g.open) > 0 && (len(g.open) >= fwMaxOpenConns || g.rng.Intn(2) == 0) {
+		i := g.rng.Intn(len(g.open))
+		conn := g.open[i]
+		g.open[i] = g.open[len(g.open)-1]
+		g.open = g.open[:len(g.open)-1]
+		return fwTeardown, conn
+	}
+
+	conn := g.newConn()
+	if g.vendor != vendorIptables {
+		g.open = append(g.open, conn)
+	}
+	return fwBuild, conn
+}
+
+// newConn - 내부 → 외부 신규 연결
+func (g *FirewallGenerator) newConn() fwConn {
+	service := &fwServices[g.services.pick(g.rng)]
+	hostIdx := g.rng.Intn(len(g.header.hostnames))
+	g.nextID++
+	conn := fwConn{
+		id:         g.nextID,
+		hostIdx:    hostIdx,
+		service:    service,
+		srcIP:      g.insideIPs[skewedIndex(g.rng, len(g.insideIPs))],
+		srcPort:    32768 + g.rng.Intn(28232),
+		dstIP:      g.outsideIPs[skewedIndex(g.rng, len(g.outsideIPs))],
+		dstPort:    service.port,
+		natIP:      g.natIPs[hostIdx],
+		natPort:    1024 + g.rng.Intn(64511),
+		rule:       "allow-outbound",
+		startedSec: g.clock.Now().Unix(),
+	}
+	if g.rng.Intn(3) == 0 {
+		conn.user = commonUsernames[g.rng.Intn(len(commonUsernames))]
+	}
+	if g.vendor == vendorPANOS {
+		conn.started = string(appendPANTime(nil, g.clock.W3C()))
+	}
+	return conn
+}
+
+// deniedConn - 외부 → 내부 차단 연결 (스캔/무차별 대입)
+func (g *FirewallGenerator) deniedConn() fwConn {
+	port := fwDeniedPorts[g.rng.Intn(len(fwDeniedPorts))]
+	g.nextID++
+	return fwConn{
+		id:      g.nextID,
+		hostIdx: g.rng.Intn(len(g.header.hostnames)),
+		service: &fwService{port: port, proto: "tcp", panApp: "incomplete", category: "any", forti: "tcp/" + strconv.Itoa(port)},
+		srcIP:   g.outsideIPs[g.rng.Intn(len(g.outsideIPs))],
+		srcPort: 1024 + g.rng.Intn(64511),
+		dstIP:   g.natIPs[g.rng.Intn(len(g.natIPs))],
+		dstPort: port,
+		rule:    "deny-inbound",
+	}
+}
+
+// Generate - LogFormatter 구현
+func (g *FirewallGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	phase, conn := g.next()
+	g.sequence++
+
+	switch g.vendor {
+	case vendorCiscoASA:
+		buffer = g.appendASA(buffer, phase, &conn)
+	case vendorPANOS:
+		buffer = g.appendPANOS(buffer, phase, &conn)
+	case vendorFortiGate:
+		buffer = g.appendFortiGate(buffer, phase, &conn)
+	default:
+		buffer = g.appendIptables(buffer, phase, &conn)
+	}
+	return finishBuffer(buffer)
+}
+
+// teardownStats - 종료 시점 지속 시간/바이트/패킷
+func (g *FirewallGenerator) teardownStats(conn *fwConn) (duration int64, sent, received, packetsSent, packetsReceived int) {
+	duration = g.clock.Now().Unix() - conn.startedSec
+	if conn.service.proto == "udp" {
+		sent = logUniform(g.rng, 40, 600)
+		received = logUniform(g.rng, 60, 1500)
+	} else {
+		sent = logUniform(g.rng, 300, 200000)
+		received = logUniform(g.rng, 500, 5000000)
+	}
+	packetsSent = 1 + sent/900
+	packetsReceived = 1 + received/1300
+	return
+}
+
+// --- Cisco ASA ---
+
+// appendASA - %ASA-6-302013/302014/302015/302016, %ASA-4-106023
+func (g *FirewallGenerator) appendASA(buffer []byte, phase fwPhase, conn *fwConn) []byte {
+	const facility = 20 // local4 (ASA 기본값)
+	severity := 6
+	if phase == fwDeny {
+		severity = 4
+	}
+	buffer = g.header.appendHeader(buffer, facility, severity, conn.hostIdx, "", "", "")
+
+	protoUpper := "TCP"
+	if conn.service.proto == "udp" {
+		protoUpper = "UDP"
+	}
+
+	switch phase {
+	case fwDeny:
+		buffer = append(buffer, `%ASA-4-106023: Deny tcp src outside:`...)
+		buffer = appendHostPort(buffer, conn.srcIP, conn.srcPort)
+		buffer = append(buffer, " dst inside:"...)
+		buffer = appendHostPort(buffer, conn.dstIP, conn.dstPort)
+		buffer = append(buffer, ` by access-group "outside_access_in" [0x0, 0x0]`...)
+
+	case fwBuild:
+		if protoUpper == "TCP" {
+			buffer = append(buffer, "%ASA-6-302013: Built outbound TCP connection "...)
+		} else {
+			buffer = append(buffer, "%ASA-6-302015: Built outbound UDP connection "...)
+		}
+		buffer = strconv.AppendUint(buffer, conn.id, 10)
+		buffer = append(buffer, " for outside:"...)
+		buffer = appendHostPort(buffer, conn.dstIP, conn.dstPort)
+		buffer = append(buffer, " ("...)
+		buffer = appendHostPort(buffer, conn.dstIP, conn.dstPort)
+		buffer = append(buffer, ") to inside:"...)
+		buffer = appendHostPort(buffer, conn.srcIP, conn.srcPort)
+		buffer = append(buffer, " ("...)
+		buffer = appendHostPort(buffer, conn.natIP, conn.natPort)
+		buffer = append(buffer, ')')
+
+	default:
+		duration, sent, received, _, _ := g.teardownStats(conn)
+		if protoUpper == "TCP" {
+			buffer = append(buffer, "%ASA-6-302014: Teardown TCP connection "...)
+		} else {
+			buffer = append(buffer, "%ASA-6-302016: Teardown UDP connection "...)
+		}
+		buffer = strconv.AppendUint(buffer, conn.id, 10)
+		buffer = append(buffer, " for outside:"...)
+		buffer = appendHostPort(buffer, conn.dstIP, conn.dstPort)
+		buffer = append(buffer, " to inside:"...)
+		buffer = appendHostPort(buffer, conn.srcIP, conn.srcPort)
+		buffer = append(buffer, " duration "...)
+		buffer = appendHMS(buffer, duration)
+		buffer = append(buffer, " bytes "...)
+		buffer = strconv.AppendInt(buffer, int64(sent+received), 10)
+		if protoUpper == "TCP" {
+			buffer = append(buffer, ' ')
+			buffer = append(buffer, fwTeardownReasons[g.rng.Intn(len(fwTeardownReasons))]...)
+		}
+	}
+	return buffer
+}
+
+// appendHostPort - "ip/port"
+func appendHostPort(buffer []byte, ip string, port int) []byte {
+	buffer = append(buffer, ip...)
+	buffer = append(buffer, '/')
+	return strconv.AppendInt(buffer, int64(port), 10)
+}
+
+// appendHMS - "h:mm:ss"
+func appendHMS(buffer []byte, seconds int64) []byte {
+	buffer = strconv.AppendInt(buffer, seconds/3600, 10)
+	buffer = append(buffer, ':')
+	buffer = appendTwoDigits(buffer, int((seconds/60)%60))
+	buffer = append(buffer, ':')
+	return appendTwoDigits(buffer, int(seconds%60))
+}
+
+func appendTwoDigits(buffer []byte, value int) []byte {
+	return append(buffer, byte('0'+value/10), byte('0'+value%10))
+}
+
+// --- Palo Alto PAN-OS ---
+
+// appendPANTime - W3C 캐시 ("2006-01-02 15:04:05")를 PAN-OS 형식 ("2006/01/02 15:04:05")으로 변환
+func appendPANTime(buffer []byte, w3c string) []byte {
+	for i := 0; i < len(w3c); i++ {
+		if w3c[i] == '-' {
+			buffer = append(buffer, '/')
+		} else {
+			buffer = append(buffer, w3c[i])
+		}
+	}
+	return buffer
+}
+
+// panLocation - PAN-OS Source/Destination Location
+func panLocation(ip string) string {
+	if len(ip) > 3 && (ip[:3] == "10." || ip[:3] == "192" || ip[:3] == "172") {
+		return "10.0.0.0-10.255.255.255"
+	}
+	return "US"
+}
+
+// appendPANOS - TRAFFIC(start/end/deny) 및 THREAT CSV (PAN-OS 10.x 컬럼 순서)
+func (g *FirewallGenerator) appendPANOS(buffer []byte, phase fwPhase, conn *fwConn) []byte {
+	const facility = 16 // local0
+	severity := 6
+	logType, subtype := "TRAFFIC", "start"
+	switch phase {
+	case fwTeardown:
+		subtype = "end"
+	case fwDeny:
+		subtype, severity = "deny", 4
+	case fwThreat:
+		logType, severity = "THREAT", 4
+	}
+	buffer = g.header.appendHeader(buffer, facility, severity, conn.hostIdx, "", "", "")
+
+	w3c := g.clock.W3C()
+	inbound := phase == fwDeny
+	srcZone, dstZone, inIf, outIf := "trust", "untrust", "ethernet1/2", "ethernet1/1"
+	natSrc, natDst, natSrcPort := conn.natIP, "0.0.0.0", conn.natPort
+	if inbound {
+		srcZone, dstZone, inIf, outIf = "untrust", "trust", "ethernet1/1", "ethernet1/2"
+		natSrc, natDst, natSrcPort = "0.0.0.0", "0.0.0.0", 0
+	}
+
+	// 1~6: FUTURE_USE, Receive Time, Serial, Type, Subtype, FUTURE_USE
+	buffer = append(buffer, "1,"...)
+	buffer = appendPANTime(buffer, w3c)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, g.serials[conn.hostIdx]...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, logType...)
+	buffer = append(buffer, ',')
+	if phase == fwThreat {
+		threat := fwPANThreats[g.rng.Intn(len(fwPANThreats))]
+		return g.appendPANThreat(buffer, conn, threat.subtype, threat.name, threat.category, threat.severity, threat.target,
+			srcZone, dstZone, inIf, outIf, natSrc, natDst, natSrcPort)
+	}
+	buffer = append(buffer, subtype...)
+	buffer = append(buffer, ",2562,"...)
+	// 7~21: Generated Time, Src, Dst, NAT Src, NAT Dst, Rule, Src User, Dst User, App, Vsys, Zones, Interfaces, Log Action
+	buffer = g.appendPANCommon(buffer, w3c, conn, srcZone, dstZone, inIf, outIf, natSrc, natDst)
+	// 22~30: FUTURE_USE, Session ID, Repeat Count, Ports, NAT Ports, Flags, Protocol
+	buffer = g.appendPANSession(buffer, conn, natSrcPort)
+
+	// 31~: Action, Bytes, Bytes Sent, Bytes Received, Packets, Start Time, Elapsed, Category
+	action, endReason := "allow", "n/a"
+	var duration int64
+	var sent, received, packetsSent, packetsReceived int
+	switch phase {
+	case fwTeardown:
+		duration, sent, received, packetsSent, packetsReceived = g.teardownStats(conn)
+		endReason = fwPANEndReasons[g.rng.Intn(len(fwPANEndReasons))]
+		if conn.service.proto == "udp" {
+			endReason = "aged-out"
+		}
+	case fwDeny:
+		action, endReason = "deny", "policy-deny"
+		sent, packetsSent = 60+g.rng.Intn(20), 1
+	default:
+		sent, packetsSent = 60+g.rng.Intn(600), 1
+	}
+	buffer = append(buffer, action...)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(sent+received), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(sent), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(received), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(packetsSent+packetsReceived), 10)
+	buffer = append(buffer, ',')
+	if conn.started != "" {
+		buffer = append(buffer, conn.started...)
+	} else {
+		buffer = appendPANTime(buffer, w3c)
+	}
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, duration, 10)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, conn.service.category...)
+	// FUTURE_USE, Sequence Number, Action Flags, Source Location, Destination Location, FUTURE_USE
+	buffer = append(buffer, ",0,"...)
+	buffer = strconv.AppendUint(buffer, g.sequence, 10)
+	buffer = append(buffer, ",0x0,"...)
+	buffer = append(buffer, panLocation(conn.srcIP)...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, panLocation(conn.dstIP)...)
+	buffer = append(buffer, ",0,"...)
+	// Packets Sent, Packets Received, Session End Reason
+	buffer = strconv.AppendInt(buffer, int64(packetsSent), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(packetsReceived), 10)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, endReason...)
+	// Device Group Hierarchy 1~4, Virtual System Name, Device Name, Action Source
+	buffer = append(buffer, ",0,0,0,0,,"...)
+	buffer = append(buffer, g.header.hostnames[conn.hostIdx]...)
+	if phase == fwDeny {
+		return append(buffer, ",from-policy"...)
+	}
+	return append(buffer, ",from-application"...)
+}
+
+// appendPANCommon - Generated Time ~ Log Action (TRAFFIC/THREAT 공통)
+func (g *FirewallGenerator) appendPANCommon(buffer []byte, w3c string, conn *fwConn,
+	srcZone, dstZone, inIf, outIf, natSrc, natDst string) []byte {
+	buffer = appendPANTime(buffer, w3c)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, conn.srcIP...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, conn.dstIP...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, natSrc...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, natDst...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, conn.rule...)
+	buffer = append(buffer, ',')
+	if conn.user != "" {
+		buffer = append(buffer, `corp\`...)
+		buffer = append(buffer, conn.user...)
+	}
+	buffer = append(buffer, ",,"...)
+	buffer = append(buffer, conn.service.panApp...)
+	buffer = append(buffer, ",vsys1,"...)
+	buffer = append(buffer, srcZone...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, dstZone...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, inIf...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, outIf...)
+	return append(buffer, ",Log-Forwarding-SIEM,"...)
+}
+
+// appendPANSession - FUTURE_USE ~ Protocol (Session ID가 세션 연결 키)
+func (g *FirewallGenerator) appendPANSession(buffer []byte, conn *fwConn, natSrcPort int) []byte {
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendUint(buffer, conn.id, 10)
+	buffer = append(buffer, ",1,"...)
+	buffer = strconv.AppendInt(buffer, int64(conn.srcPort), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(conn.dstPort), 10)
+	buffer = append(buffer, ',')
+	buffer = strconv.AppendInt(buffer, int64(natSrcPort), 10)
+	buffer = append(buffer, ",0,0x400000,"...)
+	buffer = append(buffer, conn.service.proto...)
+	return append(buffer, ',')
+}
+
+// appendPANThreat - THREAT CSV (Subtype 이후)
+func (g *FirewallGenerator) appendPANThreat(buffer []byte, conn *fwConn, subtype, name, category, severity, target,
+	srcZone, dstZone, inIf, outIf, natSrc, natDst string, natSrcPort int) []byte {
+	buffer = append(buffer, subtype...)
+	buffer = append(buffer, ",2562,"...)
+	buffer = g.appendPANCommon(buffer, g.clock.W3C(), conn, srcZone, dstZone, inIf, outIf, natSrc, natDst)
+	buffer = g.appendPANSession(buffer, conn, natSrcPort)
+	// Action, URL/Filename, Threat ID, Category, Severity, Direction
+	if subtype == "url" {
+		buffer = append(buffer, "block-url,"...)
+	} else {
+		buffer = append(buffer, "reset-both,"...)
+	}
+	buffer = append(buffer, '"')
+	buffer = append(buffer, target...)
+	buffer = append(buffer, "\","...)
+	buffer = append(buffer, name...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, category...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, severity...)
+	buffer = append(buffer, ",client-to-server,"...)
+	// Sequence Number, Action Flags, Source/Destination Location, FUTURE_USE
+	buffer = strconv.AppendUint(buffer, g.sequence, 10)
+	buffer = append(buffer, ",0x0,"...)
+	buffer = append(buffer, panLocation(conn.srcIP)...)
+	buffer = append(buffer, ',')
+	buffer = append(buffer, panLocation(conn.dstIP)...)
+	// FUTURE_USE, Content Type, PCAP_ID, File Digest, Cloud, URL Index, User Agent, File Type,
+	// X-Forwarded-For, Referer, Sender, Subject, Recipient, Report ID, DG 1~4, Vsys Name, Device Name
+	buffer = append(buffer, ",0,,0,,,0,,,,,,,,0,0,0,0,0,,"...)
+	return append(buffer, g.header.hostnames[conn.hostIdx]...)
+}
+
+// --- FortiGate ---
+
+// appendFortiGate - FortiOS traffic 로그 (key=value, 문자열 값은 따옴표)
+func (g *FirewallGenerator) appendFortiGate(buffer []byte, phase fwPhase, conn *fwConn) []byte {
+	const facility = 23 // local7 (FortiGate 기본값)
+	severity, level := 5, "notice"
+	if phase == fwDeny {
+		severity, level = 4, "warning"
+	}
+	buffer = g.header.appendHeader(buffer, facility, severity, conn.hostIdx, "", "", "")
+
+	w3c := g.clock.W3C()
+	buffer = append(buffer, "date="...)
+	buffer = append(buffer, w3c[:10]...)
+	buffer = append(buffer, " time="...)
+	buffer = append(buffer, w3c[11:]...)
+	buffer = append(buffer, ` devname="`...)
+	buffer = append(buffer, g.header.hostnames[conn.hostIdx]...)
+	buffer = append(buffer, `" devid="`...)
+	buffer = append(buffer, g.serials[conn.hostIdx]...)
+	buffer = append(buffer, `" eventtime=`...)
+	buffer = strconv.AppendInt(buffer, g.clock.Now().UnixNano(), 10)
+	buffer = append(buffer, ` tz="+0000" logid="0000000013" type="traffic" subtype="forward" level="`...)
+	buffer = append(buffer, level...)
+	buffer = append(buffer, `" vd="root" srcip=`...)
+	buffer = append(buffer, conn.srcIP...)
+	buffer = append(buffer, " srcport="...)
+	buffer = strconv.AppendInt(buffer, int64(conn.srcPort), 10)
+	if phase == fwDeny {
+		buffer = append(buffer, ` srcintf="wan1" srcintfrole="wan" dstip=`...)
+	} else {
+		buffer = append(buffer, ` srcintf="port1" srcintfrole="lan" dstip=`...)
+	}
+	buffer = append(buffer, conn.dstIP...)
+	buffer = append(buffer, " dstport="...)
+	buffer = strconv.AppendInt(buffer, int64(conn.dstPort), 10)
+	if phase == fwDeny {
+		buffer = append(buffer, ` dstintf="port1" dstintfrole="lan"`...)
+	} else {
+		buffer = append(buffer, ` dstintf="wan1" dstintfrole="wan"`...)
+	}
+	buffer = append(buffer, " sessionid="...)
+	buffer = strconv.AppendUint(buffer, conn.id, 10)
+	buffer = append(buffer, " proto="...)
+	if conn.service.proto == "udp" {
+		buffer = append(buffer, "17"...)
+	} else {
+		buffer = append(buffer, '6')
+	}
+
+	action := "start"
+	switch phase {
+	case fwTeardown:
+		action = fwFortiCloseActions[g.rng.Intn(len(fwFortiCloseActions))]
+		if conn.service.proto == "udp" {
+			action = "accept"
+		}
+	case fwDeny:
+		action = "deny"
+	}
+	buffer = append(buffer, ` action="`...)
+	buffer = append(buffer, action...)
+	if phase == fwDeny {
+		buffer = append(buffer, `" policyid=0 policytype="policy" service="`...)
+	} else {
+		buffer = append(buffer, `" policyid=1 policytype="policy" service="`...)
+	}
+	buffer = append(buffer, conn.service.forti...)
+	buffer = append(buffer, '"')
+
+	if phase == fwDeny {
+		return append(buffer, ` trandisp="noop" duration=0 sentbyte=0 rcvdbyte=0 sentpkt=0 appcat="unscanned" crscore=30 craction=131072 crlevel="high"`...)
+	}
+
+	buffer = append(buffer, ` trandisp="snat" transip=`...)
+	buffer = append(buffer, conn.natIP...)
+	buffer = append(buffer, " transport="...)
+	buffer = strconv.AppendInt(buffer, int64(conn.natPort), 10)
+	if conn.user != "" {
+		buffer = append(buffer, ` user="`...)
+		buffer = append(buffer, conn.user...)
+		buffer = append(buffer, '"')
+	}
+
+	var duration int64
+	var sent, received, packetsSent, packetsReceived int
+	if phase == fwTeardown {
+		duration, sent, received, packetsSent, packetsReceived = g.teardownStats(conn)
+	}
+	buffer = append(buffer, " duration="...)
+	buffer = strconv.AppendInt(buffer, duration, 10)
+	buffer = append(buffer, " sentbyte="...)
+	buffer = strconv.AppendInt(buffer, int64(sent), 10)
+	buffer = append(buffer, " rcvdbyte="...)
+	buffer = strconv.AppendInt(buffer, int64(received), 10)
+	buffer = append(buffer, " sentpkt="...)
+	buffer = strconv.AppendInt(buffer, int64(packetsSent), 10)
+	buffer = append(buffer, " rcvdpkt="...)
+	buffer = strconv.AppendInt(buffer, int64(packetsReceived), 10)
+	return append(buffer, ` appcat="unscanned"`...)
+}
+
+// --- iptables ---
+
+// appendIptables - netfilter LOG 타깃 커널 메시지
+// kernel: [uptime] PREFIX IN=eth0 OUT=eth1 SRC=... DST=... LEN= ... PROTO=TCP SPT= DPT= ...
+func (g *FirewallGenerator) appendIptables(buffer []byte, phase fwPhase, conn *fwConn) []byte {
+	severity := 4 // LOG 타깃 기본 level warning
+	buffer = g.header.appendHeader(buffer, 0, severity, conn.hostIdx, "kernel", "", "")
+
+	// 커널 타임스탬프 [초.마이크로초] (가상 부팅 이후 경과 시간)
+	now := g.clock.Now()
+	buffer = append(buffer, '[')
+	buffer = strconv.AppendInt(buffer, now.Unix()-g.bootSec, 10)
+	buffer = append(buffer, '.')
+	micros := now.Nanosecond() / 1000
+	for div := 100000; div > 0; div /= 10 {
+		buffer = append(buffer, byte('0'+micros/div%10))
+	}
+	buffer = append(buffer, "] "...)
+
+	if phase == fwDeny {
+		buffer = append(buffer, "IPTABLES-DROP: IN=eth0 OUT= MAC=52:54:00:12:34:56:00:1c:7f:aa:bb:cc:08:00 SRC="...)
+	} else {
+		buffer = append(buffer, "IPTABLES-ACCEPT: IN=eth1 OUT=eth0 SRC="...)
+	}
+	buffer = append(buffer, conn.srcIP...)
+	buffer = append(buffer, " DST="...)
+	buffer = append(buffer, conn.dstIP...)
+
+	udp := conn.service.proto == "udp"
+	length := 60
+	if udp {
+		length = 40 + g.rng.Intn(480)
+	}
+	buffer = append(buffer, " LEN="...)
+	buffer = strconv.AppendInt(buffer, int64(length), 10)
+	buffer = append(buffer, " TOS=0x00 PREC=0x00 TTL="...)
+	if phase == fwDeny {
+		buffer = strconv.AppendInt(buffer, int64(40+g.rng.Intn(20)), 10)
+	} else {
+		buffer = strconv.AppendInt(buffer, 63, 10)
+	}
+	buffer = append(buffer, " ID="...)
+	buffer = strconv.AppendInt(buffer, int64(g.rng.Intn(65536)), 10)
+	if udp {
+		buffer = append(buffer, " DF PROTO=UDP SPT="...)
+	} else {
+		buffer = append(buffer, " DF PROTO=TCP SPT="...)
+	}
+	buffer = strconv.AppendInt(buffer, int64(conn.srcPort), 10)
+	buffer = append(buffer, " DPT="...)
+	buffer = strconv.AppendInt(buffer, int64(conn.dstPort), 10)
+	if udp {
+		buffer = append(buffer, " LEN="...)
+		return strconv.AppendInt(buffer, int64(length-20), 10)
+	}
+	buffer = append(buffer, " WINDOW="...)
+	buffer = strconv.AppendInt(buffer, int64(1024+g.rng.Intn(64000)), 10)
+	return append(buffer, " RES=0x00 SYN URGP=0"...)
+}
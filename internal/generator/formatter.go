@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LogFormatter - 로그 한 건을 생성하는 포맷터 인터페이스
+//
+// 워커는 포맷터 하나를 단독으로 사용하지만 realtime 모드처럼 배치를 미리 생성하는
+// 고루틴이 함께 호출할 수 있으므로 구현체는 내부 상태를 락으로 보호한다.
+// 반환된 슬라이스는 호출자가 소유한다.
+type LogFormatter interface {
+	// Name - 레지스트리에 등록된 형식 이름
+	Name() string
+	// Generate - 로그 한 건 생성
+	Generate() []byte
+}
+
+// PacketFormatter - UDP 페이로드 하나를 통째로 생성하는 바이너리 포맷터 (NetFlow/IPFIX)
+//
+// 워커는 Generate 결과를 줄바꿈으로 묶지 않고 패킷 하나씩 전송하며,
+// 전송량과 목표 속도를 로그 건수 대신 패킷에 담긴 레코드(플로) 수로 계산한다.
+type PacketFormatter interface {
+	LogFormatter
+	// RecordsPerPacket - Generate 한 번이 담는 데이터 레코드 수 (항상 일정)
+	RecordsPerPacket() int
+	// DestinationPort - 수집기 UDP 포트
+	DestinationPort() int
+}
+
+// ChunkedFormatter - 큰 메시지를 여러 UDP 데이터그램으로 나눠 보내는 포맷터 (GELF)
+//
+// 워커는 Generate 결과를 줄바꿈으로 묶지 않고 AppendChunks로 나눈 데이터그램을 차례로
+// 전송하며, 메시지 한 건을 로그 한 건으로, 데이터그램은 따로 계산한다.
+type ChunkedFormatter interface {
+	LogFormatter
+	// AppendChunks - 메시지를 전송할 데이터그램으로 분할 (나눌 수 없으면 nil)
+	AppendChunks(chunks [][]byte, message []byte) [][]byte
+	// GenerateWatermarked - 워터마크를 넣어 메시지 생성 (압축 전에 넣으므로 워커가 덧붙일 수 없음)
+	GenerateWatermarked(mark Watermark) []byte
+	// DestinationPort - 수집기 UDP 포트
+	DestinationPort() int
+}
+
+// FormatterFactory - 출력 옵션으로 포맷터를 생성하는 팩토리
+type FormatterFactory func(options GeneratorOptions) (LogFormatter, error)
+
+var (
+	formatterRegistry = make(map[string]FormatterFactory)
+	registryMutex     sync.RWMutex
+)
+
+// RegisterFormatter - 이름으로 포맷터 팩토리 등록 (중복 등록은 프로그래밍 오류)
+func RegisterFormatter(name string, factory FormatterFactory) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if factory == nil {
+		panic("generator: nil formatter factory for " + name)
+	}
+	if _, exists := formatterRegistry[name]; exists {
+		panic("generator: formatter registered twice: " + name)
+	}
+	formatterRegistry[name] = factory
+}
+
+// NewFormatter - 등록된 이름으로 포맷터 생성
+func NewFormatter(name string, options GeneratorOptions) (LogFormatter, error) {
+	registryMutex.RLock()
+	factory, exists := formatterRegistry[name]
+	registryMutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("알 수 없는 로그 형식: %s (사용 가능: %s)", name, strings.Join(ListFormats(), ", "))
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	options.format = name
+	formatter, err := factory(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// 바이너리/청크 형식은 자체 프로토콜이므로 패딩/퍼징/시나리오 주입 대상에서 제외
+	switch formatter.(type) {
+	case PacketFormatter, ChunkedFormatter:
+		return formatter, nil
+	}
+	// 패딩을 먼저 적용해 퍼징 변형(oversized, 잘림 등)이 최종 메시지에 걸리게 함
+	if options.Size.Distribution != "" {
+		formatter = newPaddingFormatter(formatter, options.Size)
+	}
+	if options.Fuzz.Ratio > 0 {
+		formatter = newFuzzFormatter(formatter, options.Fuzz, options.MessageLimit, options.TrackInjections)
+	}
+	// 시나리오 줄은 탐지 검증용이므로 패딩/퍼징을 거치지 않게 가장 바깥에서 끼워 넣음
+	if options.Scenarios != nil {
+		if formatter, err = newScenarioFormatter(formatter, options); err != nil {
+			return nil, err
+		}
+	}
+	return formatter, nil
+}
+
+// scenarioTextFormats - 시나리오 줄(syslog 헤더 + 자유 텍스트)과 같은 모양의 줄을 내보내는 형식
+var scenarioTextFormats = []string{"syslog", "session", "stacktrace", "iptables", "bind", "dnsmasq", "dhcpd"}
+
+// InjectionWarnings - 선택한 형식에 패딩/퍼징/시나리오가 기대대로 적용되지 않는 경우의 경고 목록
+//
+// 바이너리/청크 형식(NetFlow/IPFIX, GELF)은 NewFormatter가 세 기능을 모두 건너뛴다.
+// 시나리오 줄은 형식과 관계없이 syslog 헤더 + 텍스트이므로 CEF, JSON, 방화벽 key=value 같은
+// 스트림에 섞이면 수신 측의 해당 형식 파서가 인식하지 못한다. 오류가 아니므로 호출자가 출력만 한다.
+func InjectionWarnings(names []string, options GeneratorOptions) []string {
+	var features []string
+	if options.Size.Distribution != "" {
+		features = append(features, "크기 패딩")
+	}
+	if options.Fuzz.Ratio > 0 {
+		features = append(features, "퍼징")
+	}
+	if options.Scenarios != nil {
+		features = append(features, "시나리오 주입")
+	}
+	if len(features) == 0 {
+		return nil
+	}
+
+	var native, mismatched []string
+	for _, name := range names {
+		if isNativeProtocolFormat(name) {
+			native = append(native, name)
+		} else if options.Scenarios != nil && !slices.Contains(scenarioTextFormats, name) {
+			mismatched = append(mismatched, name)
+		}
+	}
+
+	var warnings []string
+	if len(native) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s 형식은 자체 UDP 프로토콜이므로 다음 기능이 적용되지 않습니다: %s",
+			strings.Join(native, ", "), strings.Join(features, ", ")))
+	}
+	if len(mismatched) > 0 {
+		warnings = append(warnings, fmt.Sprintf("시나리오 줄은 syslog 헤더 + 텍스트로 주입되므로 %s 형식 스트림에서는 수신 측 파서가 인식하지 못할 수 있습니다 (같은 모양의 형식: %s)",
+			strings.Join(mismatched, ", "), strings.Join(scenarioTextFormats, ", ")))
+	}
+	return warnings
+}
+
+// isNativeProtocolFormat - 바이너리/청크 형식 여부 (패딩/퍼징/시나리오 주입 대상 아님)
+func isNativeProtocolFormat(name string) bool {
+	registryMutex.RLock()
+	factory, exists := formatterRegistry[name]
+	registryMutex.RUnlock()
+	if !exists {
+		return false
+	}
+
+	options := DefaultGeneratorOptions()
+	options.format = name
+	formatter, err := factory(options)
+	if err != nil {
+		return false
+	}
+	switch formatter.(type) {
+	case PacketFormatter, ChunkedFormatter:
+		return true
+	}
+	return false
+}
+
+// IsFormatRegistered - 형식 이름 등록 여부
+func IsFormatRegistered(name string) bool {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	_, exists := formatterRegistry[name]
+	return exists
+}
+
+// ListFormats - 등록된 형식 이름 목록 (정렬)
+func ListFormats() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	names := make([]string, 0, len(formatterRegistry))
+	for name := range formatterRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateFormats - 형식 목록 검증 (비어 있거나 미등록 이름이 있으면 오류)
+func ValidateFormats(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("로그 형식이 지정되지 않았습니다")
+	}
+	for _, name := range names {
+		if !IsFormatRegistered(name) {
+			return fmt.Errorf("알 수 없는 로그 형식: %s (사용 가능: %s)", name, strings.Join(ListFormats(), ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 퍼징 변형 이름 (메트릭 레이블)
+const (
+	MutationMissingPRI      = "missing_pri"
+	MutationInvalidPRI      = "invalid_pri"
+	MutationPRIOverflow     = "pri_overflow"
+	MutationTruncatedHeader = "truncated_header"
+	MutationInvalidUTF8     = "invalid_utf8"
+	MutationNULBytes        = "nul_bytes"
+	MutationControlChars    = "control_chars"
+	MutationOversized       = "oversized"
+	MutationEmpty           = "empty"
+	MutationOddTimestamp    = "odd_timestamp"
+)
+
+// MutationOversizedSkipped - 전송 한도(UDP) 때문에 oversized 변형을 건너뛴 건수 레이블
+const MutationOversizedSkipped = MutationOversized + "_skipped"
+
+// oversized 변형의 최소 크기 (64KiB 초과, UDP 데이터그램 한계보다 큼)
+const fuzzOversizedMin = 64*1024 + 1
+
+// FuzzOptions - 비정상 메시지 퍼징 옵션
+type FuzzOptions struct {
+	// 변형할 로그 비율 (0~1, 0 = 퍼징 끔)
+	Ratio float64 `json:"ratio,omitempty"`
+	// 사용할 변형 목록 (비어 있으면 전체)
+	Mutations []string `json:"mutations,omitempty"`
+}
+
+// Validate - 퍼징 옵션 검증
+func (o FuzzOptions) Validate() error {
+	if o.Ratio < 0 || o.Ratio > 1 {
+		return fmt.Errorf("퍼징 비율은 0~1 사이여야 합니다: %g", o.Ratio)
+	}
+	for _, name := range o.Mutations {
+		if fuzzMutationIndex(name) < 0 {
+			return fmt.Errorf("알 수 없는 퍼징 변형: %s (사용 가능: %s)", name, strings.Join(ListMutations(), ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateLimit - 메시지 한도(UDP 최대 데이터그램, 0 = 무제한)에서 보낼 수 없는 변형을 지정했는지 검증
+//
+// 변형 목록이 비어 있으면(전체) oversized는 건너뛰고 oversized_skipped로 세므로 허용한다.
+func (o FuzzOptions) ValidateLimit(limit int) error {
+	if o.Ratio == 0 || limit <= 0 || limit >= fuzzOversizedMin {
+		return nil
+	}
+	for _, name := range o.Mutations {
+		if name == MutationOversized {
+			return fmt.Errorf("oversized 변형은 %d바이트를 넘는 메시지라 UDP 전송(최대 %d바이트)으로 보낼 수 없습니다 (-transport tcp 사용)", fuzzOversizedMin-1, limit)
+		}
+	}
+	return nil
+}
+
+// MutationCounter - 적용한 변형을 레이블별로 세는 포맷터 (퍼징 모드)
+type MutationCounter interface {
+	// MutationCounts - 레이블별 누적 변형 수 (0인 레이블 제외)
+	MutationCounts() map[string]int64
+}
+
+// fuzzMutation - 변형 함수 (적용할 수 없는 메시지면 false)
+type fuzzMutation struct {
+	name  string
+	apply func(message []byte, rng *rand.Rand) ([]byte, bool)
+}
+
+// fuzzMutations - 변형 목록 (순서 = 카운터 인덱스)
+var fuzzMutations = []fuzzMutation{
+	{MutationMissingPRI, mutateMissingPRI},
+	{MutationInvalidPRI, mutateInvalidPRI},
+	{MutationPRIOverflow, mutatePRIOverflow},
+	{MutationTruncatedHeader, mutateTruncatedHeader},
+	{MutationInvalidUTF8, mutateInvalidUTF8},
+	{MutationNULBytes, mutateNULBytes},
+	{MutationControlChars, mutateControlChars},
+	{MutationOversized, mutateOversized},
+	{MutationEmpty, mutateEmpty},
+	{MutationOddTimestamp, mutateOddTimestamp},
+}
+
+// 변형을 적용할 수 없는 메시지(PRI/타임스탬프 없음 등)에 대신 쓰는 변형
+var fuzzFallback = fuzzMutationIndex(MutationInvalidUTF8)
+
+// oversized 변형 인덱스 (메시지 한도 확인용)
+var fuzzOversized = fuzzMutationIndex(MutationOversized)
+
+// ListMutations - 퍼징 변형 이름 목록
+func ListMutations() []string {
+	names := make([]string, len(fuzzMutations))
+	for i, mutation := range fuzzMutations {
+		names[i] = mutation.name
+	}
+	return names
+}
+
+// fuzzMutationIndex - 이름으로 변형 인덱스 조회 (없으면 -1)
+func fuzzMutationIndex(name string) int {
+	for i, mutation := range fuzzMutations {
+		if mutation.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// fuzzFormatter - 다른 포맷터의 출력 일부를 비정상 메시지로 바꾸는 래퍼
+//
+// 변형은 생성 시점에 세므로 전송에 실패한 배치도 포함된다. 수신 측 거부 건수와 비교할 때는
+// 워커 오류 수를 함께 본다. 메시지 한도(UDP 최대 데이터그램)가 있으면 보낼 수 없는 oversized
+// 변형은 적용하지 않고 원본을 그대로 내보내며 oversized_skipped로 센다.
+type fuzzFormatter struct {
+	inner     LogFormatter
+	ratio     float64
+	mutations []int // fuzzMutations 인덱스
+	counts    []atomic.Int64
+	limit     int // 메시지 최대 바이트 수 (0 = 무제한)
+	skipped   atomic.Int64
+
+	// 정답 기록용 레이블 (track이 false면 보관하지 않음)
+	track  bool
+	labels injectionLabels
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// fuzzInjections - 변형별 정답 레이블 (읽기 전용이므로 공유)
+var fuzzInjections = func() []Injection {
+	injections := make([]Injection, len(fuzzMutations))
+	for i, mutation := range fuzzMutations {
+		injections[i] = Injection{Kind: InjectionFuzz, Name: mutation.name}
+	}
+	return injections
+}()
+
+// newFuzzFormatter - 퍼징 래퍼 생성 (limit는 메시지 최대 바이트 수, track이면 정답 기록용 레이블 보관)
+func newFuzzFormatter(inner LogFormatter, options FuzzOptions, limit int, track bool) *fuzzFormatter {
+	fuzz := &fuzzFormatter{
+		inner:  inner,
+		ratio:  options.Ratio,
+		counts: make([]atomic.Int64, len(fuzzMutations)),
+		limit:  limit,
+		track:  track,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, name := range options.Mutations {
+		fuzz.mutations = append(fuzz.mutations, fuzzMutationIndex(name))
+	}
+	if len(fuzz.mutations) == 0 {
+		for i := range fuzzMutations {
+			fuzz.mutations = append(fuzz.mutations, i)
+		}
+	}
+	return fuzz
+}
+
+// Name - LogFormatter 구현 (감싼 형식 이름)
+func (f *fuzzFormatter) Name() string {
+	return f.inner.Name()
+}
+
+// Generate - LogFormatter 구현 (ratio 비율만큼 변형)
+func (f *fuzzFormatter) Generate() []byte {
+	message := f.inner.Generate()
+
+	f.rngMutex.Lock()
+	defer f.rngMutex.Unlock()
+
+	if f.rng.Float64() >= f.ratio || IsDirective(message) {
+		return message
+	}
+	idx := f.mutations[f.rng.Intn(len(f.mutations))]
+	if idx == fuzzOversized && f.limit > 0 && f.limit < fuzzOversizedMin {
+		// 한도를 넘는 메시지는 워커가 버리므로 전송/정답 집계가 어긋나지 않게 건너뜀
+		f.skipped.Add(1)
+		return message
+	}
+	mutated, ok := fuzzMutations[idx].apply(message, f.rng)
+	if !ok {
+		idx = fuzzFallback
+		mutated, _ = fuzzMutations[idx].apply(message, f.rng)
+	}
+	f.counts[idx].Add(1)
+	if f.track {
+		f.labels.add(mutated, &fuzzInjections[idx])
+	}
+	return mutated
+}
+
+// TakeInjection - InjectionSource 구현
+func (f *fuzzFormatter) TakeInjection(message []byte) *Injection {
+	return f.labels.take(message)
+}
+
+// MutationCounts - MutationCounter 구현
+func (f *fuzzFormatter) MutationCounts() map[string]int64 {
+	counts := make(map[string]int64)
+	for i := range f.counts {
+		if n := f.counts[i].Load(); n > 0 {
+			counts[fuzzMutations[i].name] = n
+		}
+	}
+	if n := f.skipped.Load(); n > 0 {
+		counts[MutationOversizedSkipped] = n
+	}
+	return counts
+}
+
+// priEnd - "<숫자>" PRI 바로 다음 위치 (PRI가 없으면 0)
+func priEnd(message []byte) int {
+	if len(message) < 3 || message[0] != '<' {
+		return 0
+	}
+	for i := 1; i < len(message) && i <= 4; i++ {
+		switch c := message[i]; {
+		case c == '>' && i > 1:
+			return i + 1
+		case c < '0' || c > '9':
+			return 0
+		}
+	}
+	return 0
+}
+
+// replacePRI - PRI를 바꾸거나 (없으면) 앞에 붙임
+func replacePRI(message []byte, pri string) []byte {
+	rest := message[priEnd(message):]
+	result := make([]byte, 0, len(pri)+len(rest))
+	result = append(result, pri...)
+	return append(result, rest...)
+}
+
+// insertAt - 본문 쪽 임의 위치에 바이트열 삽입 (PRI 안쪽은 피함)
+func insertAt(message []byte, rng *rand.Rand, insert string) []byte {
+	start := priEnd(message)
+	pos := start + rng.Intn(len(message)-start+1)
+	result := make([]byte, 0, len(message)+len(insert))
+	result = append(result, message[:pos]...)
+	result = append(result, insert...)
+	return append(result, message[pos:]...)
+}
+
+func mutateMissingPRI(message []byte, _ *rand.Rand) ([]byte, bool) {
+	end := priEnd(message)
+	if end == 0 {
+		return nil, false
+	}
+	return message[end:], true
+}
+
+// 형식이 잘못된 PRI (빈 값, 숫자 아님, 음수, 선행 0, 닫는 괄호 누락 등)
+var fuzzInvalidPRIs = []string{"<>", "<abc>", "<-1>", "<1a>", "<013>", "< 13>", "<13.5>", "<13", "13>", "<<13>>", "<0x0d>"}
+
+func mutateInvalidPRI(message []byte, rng *rand.Rand) ([]byte, bool) {
+	return replacePRI(message, fuzzInvalidPRIs[rng.Intn(len(fuzzInvalidPRIs))]), true
+}
+
+// 범위를 넘는 PRI (최대 191 = local7.debug)
+var fuzzOverflowPRIs = []string{"<192>", "<200>", "<255>", "<999>", "<1000>", "<65536>", "<4294967296>"}
+
+func mutatePRIOverflow(message []byte, rng *rand.Rand) ([]byte, bool) {
+	return replacePRI(message, fuzzOverflowPRIs[rng.Intn(len(fuzzOverflowPRIs))]), true
+}
+
+// mutateTruncatedHeader - 헤더 중간(TAG 구분자 ": " 이전, 없으면 앞 48바이트 안)에서 자름
+func mutateTruncatedHeader(message []byte, rng *rand.Rand) ([]byte, bool) {
+	limit := bytes.Index(message, []byte(": "))
+	if limit < 0 {
+		limit = len(message)
+		if limit > 48 {
+			limit = 48
+		}
+	}
+	if limit < 2 {
+		return nil, false
+	}
+	return message[:1+rng.Intn(limit-1)], true
+}
+
+// 잘못된 UTF-8 (단독 0xff, 잘린 2바이트, 단독 연속 바이트, 과잉 인코딩, 서로게이트, 범위 초과)
+var fuzzInvalidUTF8 = []string{"\xff", "\xc3\x28", "\x80", "\xc0\xaf", "\xed\xa0\x80", "\xf4\x90\x80\x80", "\xe2\x82", "\xfe\xfe\xff\xff"}
+
+func mutateInvalidUTF8(message []byte, rng *rand.Rand) ([]byte, bool) {
+	return insertAt(message, rng, fuzzInvalidUTF8[rng.Intn(len(fuzzInvalidUTF8))]), true
+}
+
+func mutateNULBytes(message []byte, rng *rand.Rand) ([]byte, bool) {
+	for n := 1 + rng.Intn(4); n > 0; n-- {
+		message = insertAt(message, rng, "\x00")
+	}
+	return message, true
+}
+
+// 제어 문자 (벨, 백스페이스, CR, ANSI 색상/제목 이스케이프, DEL, NEL, 줄 구분자)
+var fuzzControlChars = []string{"\x01", "\x07", "\x08", "\x0b", "\x0c", "\r", "\x1b[31m", "\x1b]0;pwned\x07", "\x7f", "\u0085", "\u2028"}
+
+func mutateControlChars(message []byte, rng *rand.Rand) ([]byte, bool) {
+	for n := 1 + rng.Intn(4); n > 0; n-- {
+		message = insertAt(message, rng, fuzzControlChars[rng.Intn(len(fuzzControlChars))])
+	}
+	return message, true
+}
+
+// mutateOversized - 원본 본문을 반복해 64KiB를 넘기는 메시지
+func mutateOversized(message []byte, rng *rand.Rand) ([]byte, bool) {
+	size := fuzzOversizedMin + rng.Intn(8192)
+	result := make([]byte, 0, size)
+	result = append(result, message...)
+	filler := message[priEnd(message):]
+	if len(filler) == 0 {
+		filler = []byte("A")
+	}
+	for len(result) < size {
+		result = append(result, ' ')
+		result = append(result, filler...)
+	}
+	return result[:size], true
+}
+
+// mutateEmpty - 빈 메시지 (원래 버퍼를 유지해 정답 기록에서 메시지를 구분)
+func mutateEmpty(message []byte, _ *rand.Rand) ([]byte, bool) {
+	return message[:0], true
+}
+
+// fuzzTimestamps - 흔하지 않거나 잘못된 타임스탬프 (now 기준, 고정 값 포함)
+var fuzzTimestamps = []func(now time.Time) string{
+	func(now time.Time) string { return now.Format("2006-01-02 15:04:05") },
+	func(now time.Time) string { return now.Format("02/Jan/2006:15:04:05 -0700") },
+	func(now time.Time) string { return strconv.FormatInt(now.Unix(), 10) },
+	func(now time.Time) string { return strconv.FormatInt(now.UnixMilli(), 10) },
+	func(now time.Time) string { return now.Format("Jan _2 2006 15:04:05") },
+	func(now time.Time) string { return now.Format("Jan _2 15:04:05.000") },
+	func(now time.Time) string { return now.Format(time.UnixDate) },
+	func(now time.Time) string { return now.Format("06-01-02T15:04:05") },
+	func(now time.Time) string {
+		return now.In(time.FixedZone("", 9*3600)).Format("2006-01-02T15:04:05.000000000-07:00")
+	},
+	func(time.Time) string { return "2026-13-45T25:61:61Z" },
+	func(time.Time) string { return "1969-12-31T23:59:59Z" },
+	func(time.Time) string { return "9999-12-31T23:59:60Z" },
+	func(time.Time) string { return "0000-00-00T00:00:00Z" },
+}
+
+// mutateOddTimestamp - syslog 헤더의 타임스탬프 교체 (RFC 5424 필드, RFC 3164 15자 또는 PRI 뒤 ISO 8601)
+func mutateOddTimestamp(message []byte, rng *rand.Rand) ([]byte, bool) {
+	start := priEnd(message)
+	if start == 0 {
+		return nil, false
+	}
+
+	var end int
+	switch rest := message[start:]; {
+	case bytes.HasPrefix(rest, []byte("1 ")):
+		start += 2
+		end = bytes.IndexByte(message[start:], ' ')
+		if end < 0 {
+			return nil, false
+		}
+		end += start
+	case len(rest) >= 15 && rest[3] == ' ' && rest[6] == ' ' && rest[9] == ':' && rest[12] == ':':
+		end = start + 15
+	case len(rest) >= 20 && rest[4] == '-' && rest[10] == 'T':
+		end = bytes.IndexByte(rest, ' ')
+		if end < 0 {
+			return nil, false
+		}
+		end += start
+	default:
+		return nil, false
+	}
+
+	timestamp := fuzzTimestamps[rng.Intn(len(fuzzTimestamps))](time.Now())
+	result := make([]byte, 0, len(message)-(end-start)+len(timestamp))
+	result = append(result, message[:start]...)
+	result = append(result, timestamp...)
+	return append(result, message[end:]...), true
+}
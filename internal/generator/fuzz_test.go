@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuzzOptionsValidateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		options FuzzOptions
+		limit   int
+		ok      bool
+	}{
+		{"UDP에서 전체 변형", FuzzOptions{Ratio: 0.1}, 65507, true},
+		{"UDP에서 oversized 지정", FuzzOptions{Ratio: 0.1, Mutations: []string{MutationEmpty, MutationOversized}}, 65507, false},
+		{"UDP에서 다른 변형만", FuzzOptions{Ratio: 0.1, Mutations: []string{MutationEmpty}}, 65507, true},
+		{"TCP에서 oversized 지정", FuzzOptions{Ratio: 0.1, Mutations: []string{MutationOversized}}, 0, true},
+		{"퍼징 끔", FuzzOptions{Mutations: []string{MutationOversized}}, 65507, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.ValidateLimit(tt.limit); (err == nil) != tt.ok {
+				t.Fatalf("ValidateLimit() = %v, 성공 기대 %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+// newTestFuzzFormatter - 모든 로그를 변형하는 퍼징 포맷터 생성 (정답 레이블 보관)
+func newTestFuzzFormatter(t *testing.T, format string, mutations []string, limit int) *fuzzFormatter {
+	t.Helper()
+	options := DefaultGeneratorOptions()
+	options.Fuzz = FuzzOptions{Ratio: 1, Mutations: mutations}
+	options.MessageLimit = limit
+	options.TrackInjections = true
+	formatter, err := NewFormatter(format, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return formatter.(*fuzzFormatter)
+}
+
+// 변형마다 메시지 버퍼로 레이블을 찾을 수 있고 건수와 일치해야 한다.
+func TestFuzzLabels(t *testing.T) {
+	for _, name := range ListMutations() {
+		t.Run(name, func(t *testing.T) {
+			f := newTestFuzzFormatter(t, "syslog", []string{name}, 0)
+			const n = 200
+			for i := 0; i < n; i++ {
+				message := f.Generate()
+				label := f.TakeInjection(message)
+				if label == nil {
+					t.Fatalf("%d번째 메시지에 레이블 없음: %q", i, message)
+				}
+				if label.Kind != InjectionFuzz || label.Name != name {
+					t.Fatalf("레이블 %+v, 기대값 %s", label, name)
+				}
+				if f.TakeInjection(message) != nil {
+					t.Fatal("같은 레이블을 두 번 꺼냄")
+				}
+			}
+			if got := f.MutationCounts(); len(got) != 1 || got[name] != n {
+				t.Fatalf("변형 건수 %v, 기대값 %s=%d", got, name, n)
+			}
+		})
+	}
+}
+
+// PRI가 없는 형식에 적용할 수 없는 변형은 invalid_utf8로 대신하고 그 이름으로 기록해야 한다.
+func TestFuzzLabelsFallback(t *testing.T) {
+	f := newTestFuzzFormatter(t, "ecs", []string{MutationMissingPRI}, 0)
+	for i := 0; i < 100; i++ {
+		message := f.Generate()
+		if label := f.TakeInjection(message); label == nil || label.Name != MutationInvalidUTF8 {
+			t.Fatalf("레이블 %+v, 기대값 %s", label, MutationInvalidUTF8)
+		}
+	}
+	if got := f.MutationCounts(); len(got) != 1 || got[MutationInvalidUTF8] != 100 {
+		t.Fatalf("변형 건수 %v", got)
+	}
+}
+
+// 메시지 한도가 있으면 oversized를 적용하지 않고 레이블 없이 원본을 보내며 건너뛴 건수로 센다.
+func TestFuzzOversizedSkipped(t *testing.T) {
+	const limit = 1400
+	f := newTestFuzzFormatter(t, "syslog", nil, limit)
+	const n = 5000
+	labelled := int64(0)
+	for i := 0; i < n; i++ {
+		message := f.Generate()
+		if len(message) > limit {
+			t.Fatalf("한도 %d를 넘는 메시지 %d바이트", limit, len(message))
+		}
+		if label := f.TakeInjection(message); label != nil {
+			if label.Name == MutationOversized {
+				t.Fatal("건너뛴 oversized에 레이블이 붙음")
+			}
+			labelled++
+		}
+	}
+	counts := f.MutationCounts()
+	if counts[MutationOversized] != 0 || counts[MutationOversizedSkipped] == 0 {
+		t.Fatalf("변형 건수 %v", counts)
+	}
+	applied := int64(0)
+	for name, count := range counts {
+		if !strings.HasSuffix(name, "_skipped") {
+			applied += count
+		}
+	}
+	if applied+counts[MutationOversizedSkipped] != n || applied != labelled {
+		t.Fatalf("적용 %d + 건너뜀 %d != %d, 레이블 %d", applied, counts[MutationOversizedSkipped], n, labelled)
+	}
+}
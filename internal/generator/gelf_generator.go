@@ -0,0 +1,297 @@
+package generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GELF UDP 상수
+const (
+	gelfVersion          = "1.1"
+	gelfPort             = 12201
+	gelfChunkHeaderSize  = 12   // 매직(2) + 메시지 ID(8) + 순번(1) + 청크 수(1)
+	gelfMaxChunks        = 128  // 초과하면 Graylog가 메시지를 버림
+	defaultGELFChunkSize = 1420 // Graylog 클라이언트 WAN 기본값 (LAN은 8154)
+	minGELFChunkSize     = 128
+	maxGELFChunkSize     = 8192
+)
+
+// GELF 압축 방식
+const (
+	GELFCompressionNone = "none"
+	GELFCompressionGzip = "gzip"
+	GELFCompressionZlib = "zlib"
+)
+
+// GELFOptions - GELF 출력 옵션
+type GELFOptions struct {
+	// 압축 방식 (none, gzip, zlib / 빈 값 = none)
+	Compression string `json:"compression,omitempty"`
+	// UDP 청크 크기 (헤더 포함 데이터그램 최대 크기, 0 = 1420)
+	ChunkSize int `json:"chunk_size,omitempty"`
+	// GELF 입력 포트 (0 = 12201)
+	Port int `json:"port,omitempty"`
+}
+
+// Validate - GELF 옵션 검증
+func (o GELFOptions) Validate() error {
+	switch o.Compression {
+	case "", GELFCompressionNone, GELFCompressionGzip, GELFCompressionZlib:
+	default:
+		return fmt.Errorf("지원하지 않는 GELF 압축 방식: %s (none, gzip, zlib)", o.Compression)
+	}
+	if o.ChunkSize != 0 && (o.ChunkSize < minGELFChunkSize || o.ChunkSize > maxGELFChunkSize) {
+		return fmt.Errorf("GELF 청크 크기는 %d~%d 사이여야 합니다: %d", minGELFChunkSize, maxGELFChunkSize, o.ChunkSize)
+	}
+	if o.Port < 0 || o.Port > 65535 {
+		return fmt.Errorf("잘못된 GELF 포트: %d", o.Port)
+	}
+	return nil
+}
+
+// GELFGenerator - Graylog GELF 1.1 메시지 생성기
+//
+// SystemLogGenerator가 선택한 이벤트를 GELF JSON으로 렌더링하고 옵션에 따라
+// gzip/zlib으로 압축한다. 청크 크기를 넘는 페이로드는 AppendChunks가
+// GELF 청크(매직 0x1e 0x0f, 메시지 ID, 순번/청크 수)로 나눈다.
+type GELFGenerator struct {
+	events      *SystemLogGenerator
+	compression string
+	chunkSize   int
+	port        int
+
+	// 사전 조립된 JSON 조각 (인덱스는 events의 풀과 1:1)
+	hostFields    []string // {"version":"1.1","host":...
+	messageFields []string // ,"short_message":... ,"_event_category":...
+	templateField string   // 템플릿 메시지 뒤의 ,"_event_category":...
+	serviceFields []string // ,"_application_name":...,"_process_id":
+	levelFields   [192]string
+
+	// 압축 상태 (Writer와 출력 버퍼 재사용)
+	compressed bytes.Buffer
+	gzipWriter *gzip.Writer
+	zlibWriter *zlib.Writer
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newGELFGenerator - GELF 생성기 초기화
+func newGELFGenerator(options GeneratorOptions) (LogFormatter, error) {
+	events, err := newSyslogFormatter(options)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &GELFGenerator{
+		events:      events.(*SystemLogGenerator),
+		compression: options.GELF.Compression,
+		chunkSize:   options.GELF.ChunkSize,
+		port:        options.GELF.Port,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if gen.chunkSize == 0 {
+		gen.chunkSize = defaultGELFChunkSize
+	}
+	if gen.port == 0 {
+		gen.port = gelfPort
+	}
+	switch gen.compression {
+	case GELFCompressionGzip:
+		gen.gzipWriter, _ = gzip.NewWriterLevel(&gen.compressed, gzip.BestSpeed)
+	case GELFCompressionZlib:
+		gen.zlibWriter, _ = zlib.NewWriterLevel(&gen.compressed, zlib.BestSpeed)
+	}
+	gen.precompute()
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("gelf", newGELFGenerator)
+}
+
+// precompute - 변하지 않는 JSON 조각 사전 생성
+func (g *GELFGenerator) precompute() {
+	events := g.events
+
+	g.hostFields = make([]string, len(events.hostnames))
+	for i, hostname := range events.hostnames {
+		g.hostFields[i] = `{"version":"` + gelfVersion + `","host":` + jsonString(hostname)
+	}
+
+	g.serviceFields = make([]string, len(events.services))
+	for i, service := range events.services {
+		g.serviceFields[i] = `,"_application_name":` + jsonString(service) + `,"_process_id":`
+	}
+
+	// 메시지별 추가 필드는 ECS 분류를 그대로 사용
+	g.messageFields = make([]string, len(events.messages))
+	for i, message := range events.messages {
+		meta, ok := ecsMessageMetas[message]
+		if !ok {
+			meta = ecsDefaultMeta
+		}
+
+		var field []byte
+		field = append(field, `,"short_message":`...)
+		field = appendJSONString(field, message)
+		field = appendGELFMeta(field, meta)
+		g.messageFields[i] = string(field)
+	}
+	// 사용자 정의 템플릿은 분류 정보가 없으므로 기본 분류 사용
+	g.templateField = string(appendGELFMeta(nil, ecsDefaultMeta))
+
+	// level은 syslog 심각도, _facility는 퍼실리티 이름
+	for pri := range g.levelFields {
+		facility, severity := pri/8, pri%8
+		var field []byte
+		field = append(field, ',')
+		field = appendJSONInt(field, "level", int64(severity))
+		field = append(field, ',')
+		field = appendJSONField(field, "_facility", facilityNames[facility])
+		g.levelFields[pri] = string(field)
+	}
+}
+
+// appendGELFMeta - 메시지 분류 추가 필드 (_event_category, _source_ip 등)
+func appendGELFMeta(field []byte, meta ecsMessageMeta) []byte {
+	field = append(field, ',')
+	field = appendJSONField(field, "_event_category", meta.category)
+	if meta.outcome != "" {
+		field = append(field, ',')
+		field = appendJSONField(field, "_event_outcome", meta.outcome)
+	}
+	if meta.sourceIP != "" {
+		field = append(field, ',')
+		field = appendJSONField(field, "_source_ip", meta.sourceIP)
+	}
+	if meta.userName != "" {
+		field = append(field, ',')
+		field = appendJSONField(field, "_user_name", meta.userName)
+	}
+	return field
+}
+
+// Name - LogFormatter 구현
+func (g *GELFGenerator) Name() string {
+	return "gelf"
+}
+
+// DestinationPort - ChunkedFormatter 구현
+func (g *GELFGenerator) DestinationPort() int {
+	return g.port
+}
+
+// Generate - LogFormatter 구현 (압축 설정 시 압축된 페이로드)
+// {"version":"1.1","host":...,"short_message":...,"timestamp":1700000000.123,"level":6,"_facility":...}
+func (g *GELFGenerator) Generate() []byte {
+	return g.generate(nil)
+}
+
+// GenerateWatermarked - ChunkedFormatter 구현 (워터마크는 _lg_run, _lg_worker, _lg_seq 추가 필드)
+func (g *GELFGenerator) GenerateWatermarked(mark Watermark) []byte {
+	return g.generate(&mark)
+}
+
+// generate - GELF 메시지 생성 (mark가 있으면 압축 전에 워터마크 추가 필드를 넣음)
+func (g *GELFGenerator) generate(mark *Watermark) []byte {
+	buffer := getBuffer()
+	event, _ := g.events.pickEvent()
+
+	buffer = append(buffer, g.hostFields[event.hostnameIdx]...)
+	if g.events.templates != nil {
+		buffer = append(buffer, `,"short_message":`...)
+		buffer = g.events.appendMessageJSON(buffer, event)
+		buffer = append(buffer, g.templateField...)
+	} else {
+		buffer = append(buffer, g.messageFields[event.messageIdx]...)
+	}
+	buffer = append(buffer, `,"timestamp":`...)
+	millis := time.Now().UnixMilli()
+	buffer = strconv.AppendInt(buffer, millis/1000, 10)
+	buffer = append(buffer, '.')
+	buffer = append(buffer, byte('0'+millis%1000/100), byte('0'+millis%100/10), byte('0'+millis%10))
+	buffer = append(buffer, g.levelFields[event.facility*8+event.severity]...)
+	buffer = append(buffer, g.serviceFields[event.serviceIdx]...)
+	buffer = append(buffer, event.pid...)
+	buffer = append(buffer, `,"_sequence_id":`...)
+	buffer = strconv.AppendUint(buffer, event.sequenceID, 10)
+	if mark != nil {
+		// GELF 추가 필드는 '_'로 시작해야 하므로 다른 형식의 lg_ 키 앞에 '_'를 붙임
+		buffer = append(buffer, `,"_`+watermarkKeyPrefix+watermarkKeyRun+`":`...)
+		buffer = appendJSONString(buffer, mark.Run)
+		buffer = append(buffer, `,"_`+watermarkKeyPrefix+watermarkKeyWorker+`":`...)
+		buffer = strconv.AppendInt(buffer, int64(mark.Worker), 10)
+		buffer = append(buffer, `,"_`+watermarkKeyPrefix+watermarkKeySeq+`":`...)
+		buffer = strconv.AppendUint(buffer, mark.Seq, 10)
+	}
+	buffer = append(buffer, '}')
+
+	if g.compression == "" || g.compression == GELFCompressionNone {
+		return finishBuffer(buffer)
+	}
+	return g.compress(buffer)
+}
+
+// compress - JSON 페이로드 압축 (버퍼는 풀로 반환)
+func (g *GELFGenerator) compress(buffer []byte) []byte {
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	g.compressed.Reset()
+	if g.gzipWriter != nil {
+		g.gzipWriter.Reset(&g.compressed)
+		g.gzipWriter.Write(buffer)
+		g.gzipWriter.Close()
+	} else {
+		g.zlibWriter.Reset(&g.compressed)
+		g.zlibWriter.Write(buffer)
+		g.zlibWriter.Close()
+	}
+	logBufferPool.Put(buffer[:0])
+
+	result := make([]byte, g.compressed.Len())
+	copy(result, g.compressed.Bytes())
+	return result
+}
+
+// AppendChunks - ChunkedFormatter 구현
+//
+// 청크 크기 이하의 페이로드는 그대로 하나의 데이터그램으로 보내고, 큰 페이로드는
+// 메시지 ID를 공유하는 청크로 나눈다. 128개를 넘으면 Graylog가 버리므로 nil을 반환한다.
+func (g *GELFGenerator) AppendChunks(chunks [][]byte, message []byte) [][]byte {
+	if len(message) <= g.chunkSize {
+		return append(chunks, message)
+	}
+
+	payloadSize := g.chunkSize - gelfChunkHeaderSize
+	count := (len(message) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		return nil
+	}
+
+	g.rngMutex.Lock()
+	messageID := g.rng.Uint64()
+	g.rngMutex.Unlock()
+
+	for seq := 0; seq < count; seq++ {
+		end := (seq + 1) * payloadSize
+		if end > len(message) {
+			end = len(message)
+		}
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-seq*payloadSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = binary.BigEndian.AppendUint64(chunk, messageID)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunk = append(chunk, message[seq*payloadSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestGELFAppendChunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunkSize int
+		size      func(payload int) int // 메시지 길이 (payload = 청크당 페이로드)
+		chunks    int                   // 기대 데이터그램 수 (0 = 버림)
+		chunked   bool                  // GELF 청크 헤더가 붙는지
+	}{
+		{"청크 크기 이하", 1420, func(int) int { return 1000 }, 1, false},
+		{"청크 크기와 같음", 1420, func(int) int { return 1420 }, 1, false},
+		{"청크 크기 + 1", 1420, func(int) int { return 1421 }, 2, true},
+		{"페이로드 경계", 1420, func(p int) int { return 3 * p }, 3, true},
+		{"페이로드 경계 + 1", 1420, func(p int) int { return 3*p + 1 }, 4, true},
+		{"최대 청크 수", 1420, func(p int) int { return gelfMaxChunks * p }, gelfMaxChunks, true},
+		{"최대 청크 수 초과", 1420, func(p int) int { return gelfMaxChunks*p + 1 }, 0, false},
+		{"최소 청크 크기 최대 청크 수", minGELFChunkSize, func(p int) int { return gelfMaxChunks * p }, gelfMaxChunks, true},
+		{"최소 청크 크기 초과", minGELFChunkSize, func(p int) int { return gelfMaxChunks*p + 1 }, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.GELF.ChunkSize = tt.chunkSize
+			formatter, err := NewFormatter("gelf", options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gen := formatter.(*GELFGenerator)
+
+			payload := tt.chunkSize - gelfChunkHeaderSize
+			message := make([]byte, tt.size(payload))
+			for i := range message {
+				message[i] = byte(i)
+			}
+
+			chunks := gen.AppendChunks(nil, message)
+			if len(chunks) != tt.chunks {
+				t.Fatalf("데이터그램 %d개, 기대값 %d개", len(chunks), tt.chunks)
+			}
+			if tt.chunks == 0 {
+				return
+			}
+			if !tt.chunked {
+				if !bytes.Equal(chunks[0], message) {
+					t.Fatal("청크 크기 이하 메시지가 그대로 전송되지 않음")
+				}
+				return
+			}
+
+			// 헤더 확인 후 순번대로 이어 붙이면 원래 메시지가 되어야 함
+			messageID := binary.BigEndian.Uint64(chunks[0][2:])
+			var joined []byte
+			for seq, chunk := range chunks {
+				if len(chunk) > tt.chunkSize {
+					t.Fatalf("청크 %d: %d바이트가 청크 크기 %d를 넘음", seq, len(chunk), tt.chunkSize)
+				}
+				if len(chunk) <= gelfChunkHeaderSize {
+					t.Fatalf("청크 %d: 페이로드가 비어 있음", seq)
+				}
+				if chunk[0] != 0x1e || chunk[1] != 0x0f {
+					t.Fatalf("청크 %d: 매직 = % x", seq, chunk[:2])
+				}
+				if id := binary.BigEndian.Uint64(chunk[2:]); id != messageID {
+					t.Fatalf("청크 %d: 메시지 ID %x, 첫 청크 %x", seq, id, messageID)
+				}
+				if int(chunk[10]) != seq || int(chunk[11]) != len(chunks) {
+					t.Fatalf("청크 %d: 순번/청크 수 = %d/%d", seq, chunk[10], chunk[11])
+				}
+				joined = append(joined, chunk[gelfChunkHeaderSize:]...)
+			}
+			if !bytes.Equal(joined, message) {
+				t.Fatal("청크를 이어 붙인 결과가 원래 메시지와 다름")
+			}
+		})
+	}
+}
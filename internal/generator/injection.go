@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// 주입 메시지 종류 (정답 파일의 kind)
+const (
+	InjectionScenario = "scenario"
+	InjectionFuzz     = "fuzz"
+)
+
+// Injection - 주입한 메시지의 정답 레이블 (공격 시나리오 줄, 퍼징 변형)
+type Injection struct {
+	Kind       string   // InjectionScenario 또는 InjectionFuzz
+	Name       string   // 시나리오 이름 또는 퍼징 변형 이름
+	Instance   string   // 시나리오 인스턴스 ID (같은 실행의 줄끼리 같음, 퍼징은 빈 값)
+	Step       int      // 시나리오 단계 번호 (1부터, 퍼징은 0)
+	Techniques []string // ATT&CK 기법 ID (시나리오 정의 값)
+}
+
+// InjectionSource - 주입 메시지의 레이블을 내주는 포맷터 (GeneratorOptions.TrackInjections 설정 시)
+//
+// 워커는 전송을 시도한 메시지마다 TakeInjection을 호출해 레이블을 꺼낸다. 꺼내지 않은
+// 레이블은 계속 보관되므로 정답 파일을 쓰지 않으면 TrackInjections를 켜지 않는다.
+type InjectionSource interface {
+	// TakeInjection - message가 주입한 메시지면 레이블을 꺼내 반환 (아니면 nil)
+	TakeInjection(message []byte) *Injection
+}
+
+// injectionLabels - 생성했지만 아직 꺼내지 않은 주입 메시지 레이블 (메시지 버퍼 주소 기준)
+//
+// 메시지 바이트를 바꾸지 않고 식별하기 위해 Generate가 반환한 슬라이스의 첫 바이트 주소를
+// 키로 쓴다. 맵이 버퍼를 참조하므로 꺼내기 전에 주소가 재사용되지 않는다.
+type injectionLabels struct {
+	count  atomic.Int64 // 보관 중인 레이블 수 (0이면 락 없이 건너뜀)
+	mutex  sync.Mutex
+	labels map[*byte]*Injection
+}
+
+// messageKey - 메시지 버퍼 주소 (용량이 0이면 nil)
+func messageKey(message []byte) *byte {
+	if cap(message) == 0 {
+		return nil
+	}
+	return &message[:1][0]
+}
+
+// add - 메시지 레이블 보관
+func (l *injectionLabels) add(message []byte, label *Injection) {
+	key := messageKey(message)
+	if key == nil {
+		return
+	}
+	l.mutex.Lock()
+	if l.labels == nil {
+		l.labels = make(map[*byte]*Injection)
+	}
+	l.labels[key] = label
+	l.count.Store(int64(len(l.labels)))
+	l.mutex.Unlock()
+}
+
+// take - 메시지 레이블 꺼내기 (없으면 nil)
+func (l *injectionLabels) take(message []byte) *Injection {
+	if l.count.Load() == 0 {
+		return nil
+	}
+	key := messageKey(message)
+	if key == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	label, ok := l.labels[key]
+	if ok {
+		delete(l.labels, key)
+		l.count.Store(int64(len(l.labels)))
+	}
+	return label
+}
+
+// SortedCountKeys - 이름별 건수의 이름 정렬 (퍼징 변형, 시나리오 통계 출력용)
+func SortedCountKeys(counts map[string]int64) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,449 @@
+package generator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 인벤토리 호스트 역할
+const (
+	RoleWeb      = "web"
+	RoleDB       = "db"
+	RoleCache    = "cache"
+	RoleDC       = "dc"
+	RoleFirewall = "firewall"
+)
+
+// 기본 호스트 이름 패턴 ({role} = 역할 접두사, {n} = 역할 내 번호)
+const defaultInventoryPattern = "{role}{n}"
+
+// 인벤토리 기본 시드 (같은 옵션이면 실행마다 같은 호스트/IP)
+const defaultInventorySeed = 1
+
+// PID 범위 (Linux 기본 pid_max 32768, 300 미만은 커널 스레드 영역)
+const (
+	inventoryMinPID = 300
+	inventoryMaxPID = 32768
+)
+
+// 리눅스 역할 공통 syslog 서비스
+var inventoryBaseServices = []string{"systemd", "kernel", "sshd", "cron", "rsyslog", "NetworkManager"}
+
+// inventoryRole - 역할별 기본 비율, 대역, 실행 서비스, 내보내는 로그 형식
+type inventoryRole struct {
+	name     string
+	prefix   string // 이름 패턴의 {role} 값
+	os       string
+	weight   float64
+	cidr     string
+	services []string // syslog/ecs/gelf 형식에서 고르는 서비스 (TAG)
+	formats  []string
+}
+
+// inventoryRoles - 역할 정의 (순서 = 호스트 번호 순서)
+var inventoryRoles = []inventoryRole{
+	{
+		name: RoleWeb, prefix: "web", os: "Ubuntu 22.04", weight: 40, cidr: "10.10.0.0/16",
+		services: append(inventoryBaseServices[:len(inventoryBaseServices):len(inventoryBaseServices)],
+			"nginx", "docker", "containerd", "kubelet"),
+		formats: []string{"syslog", "ecs", "ecs_syslog", "gelf", "stacktrace", "auditd", "auditd_syslog", "session",
+			"apache", "apache_common", "nginx", "nginx_timing", "iis", "k8s_cri", "k8s_container"},
+	},
+	{
+		name: RoleDB, prefix: "db", os: "Rocky Linux 9", weight: 20, cidr: "10.20.0.0/16",
+		services: append(inventoryBaseServices[:len(inventoryBaseServices):len(inventoryBaseServices)],
+			"mysqld"),
+		formats: []string{"syslog", "ecs", "ecs_syslog", "gelf", "auditd", "auditd_syslog", "session"},
+	},
+	{
+		name: RoleCache, prefix: "cache", os: "Ubuntu 22.04", weight: 15, cidr: "10.30.0.0/16",
+		services: append(inventoryBaseServices[:len(inventoryBaseServices):len(inventoryBaseServices)],
+			"redis-server"),
+		formats: []string{"syslog", "ecs", "ecs_syslog", "gelf", "auditd", "auditd_syslog", "session"},
+	},
+	{
+		name: RoleDC, prefix: "dc", os: "Windows Server 2022", weight: 10, cidr: "10.40.0.0/24",
+		formats: []string{"win_snare", "win_xml", "win_json", "bind", "dnsmasq", "dhcpd"},
+	},
+	{
+		name: RoleFirewall, prefix: "fw", os: "Network Appliance", weight: 15, cidr: "10.0.0.0/24",
+		formats: []string{"cisco_asa", "panos", "fortigate", "iptables", "cef", "leef1", "leef2"},
+	},
+}
+
+// inventoryRoleIndex - 역할 이름으로 인덱스 조회 (없으면 -1)
+func inventoryRoleIndex(name string) int {
+	for i := range inventoryRoles {
+		if inventoryRoles[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ListRoles - 인벤토리 역할 이름 목록
+func ListRoles() []string {
+	names := make([]string, len(inventoryRoles))
+	for i := range inventoryRoles {
+		names[i] = inventoryRoles[i].name
+	}
+	return names
+}
+
+// InventoryOptions - 호스트 인벤토리 옵션
+type InventoryOptions struct {
+	// 호스트 수 (0 = 인벤토리 끔, 기존 호스트명 풀 사용)
+	Hosts int `json:"hosts,omitempty"`
+	// 이름 패턴 ({role}, {n} / 빈 값 = "{role}{n}")
+	Pattern string `json:"pattern,omitempty"`
+	// 역할 비율 (빈 값 = web 40, db 20, cache 15, dc 10, firewall 15)
+	Roles map[string]float64 `json:"roles,omitempty"`
+	// 역할별 IPv4 대역 (지정하지 않은 역할은 기본 대역, 여러 역할이 같은 대역을 나눠 쓸 수 있음)
+	CIDRs map[string]string `json:"cidrs,omitempty"`
+	// 서비스 프로세스의 평균 재시작 간격 (분, 0 = 재시작 없음)
+	RestartMinutes int `json:"restart_minutes,omitempty"`
+	// 호스트 배치 시드 (0 = 기본값, 같은 옵션과 시드면 같은 인벤토리)
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// Validate - 인벤토리 옵션 검증
+func (o InventoryOptions) Validate() error {
+	if o.Hosts < 0 || o.Hosts > 100000 {
+		return fmt.Errorf("인벤토리 호스트 수는 0~100000 사이여야 합니다: %d", o.Hosts)
+	}
+	if o.Pattern != "" {
+		if !strings.Contains(o.Pattern, "{n}") {
+			return fmt.Errorf("호스트 이름 패턴에 {n}이 필요합니다: %s", o.Pattern)
+		}
+		if strings.ContainsAny(o.Pattern, " \t\r\n") {
+			return fmt.Errorf("호스트 이름 패턴에 공백을 사용할 수 없습니다: %q", o.Pattern)
+		}
+	}
+	total := 0.0
+	for role, weight := range o.Roles {
+		if inventoryRoleIndex(role) < 0 {
+			return fmt.Errorf("알 수 없는 역할: %s (사용 가능: %s)", role, strings.Join(ListRoles(), ", "))
+		}
+		total += weight
+	}
+	if len(o.Roles) > 0 && total <= 0 {
+		return fmt.Errorf("역할 비율의 합이 0입니다")
+	}
+	for role, cidr := range o.CIDRs {
+		if inventoryRoleIndex(role) < 0 {
+			return fmt.Errorf("알 수 없는 역할: %s (사용 가능: %s)", role, strings.Join(ListRoles(), ", "))
+		}
+		if _, network, err := net.ParseCIDR(cidr); err != nil || network.IP.To4() == nil {
+			return fmt.Errorf("잘못된 IPv4 CIDR: %s=%s", role, cidr)
+		}
+	}
+	if o.RestartMinutes < 0 {
+		return fmt.Errorf("재시작 간격은 0 이상이어야 합니다: %d", o.RestartMinutes)
+	}
+	return nil
+}
+
+// ParseRoleCIDRs - "web=10.10.0.0/16,db=10.20.0.0/24" 형식 파싱
+func ParseRoleCIDRs(spec string) (map[string]string, error) {
+	cidrs := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return cidrs, nil
+	}
+	for _, pair := range strings.Split(spec, ",") {
+		role, cidr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("역할 대역 형식 오류: %q (role=CIDR)", pair)
+		}
+		cidrs[strings.TrimSpace(role)] = strings.TrimSpace(cidr)
+	}
+	return cidrs, nil
+}
+
+// InventoryHost - 인벤토리 호스트 한 대
+type InventoryHost struct {
+	Name string
+	IP   string
+	Role string
+	OS   string
+
+	roleIdx   int
+	restart   time.Duration
+	nextPID   atomic.Int64
+	processes sync.Map // 서비스명 → *inventoryProcess
+}
+
+// inventoryProcess - 호스트에서 실행 중인 서비스 프로세스 (재시작 전까지 PID 고정)
+type inventoryProcess struct {
+	pid       atomic.Pointer[string]
+	restartAt atomic.Int64 // UnixNano (재시작 없음이면 0)
+}
+
+// Services - 호스트 역할의 syslog 서비스 목록
+func (h *InventoryHost) Services() []string {
+	return inventoryRoles[h.roleIdx].services
+}
+
+// Formats - 호스트 역할이 내보내는 로그 형식 목록
+func (h *InventoryHost) Formats() []string {
+	return inventoryRoles[h.roleIdx].formats
+}
+
+// pid - 서비스 프로세스의 현재 PID (재시작 시점이 지났으면 새 PID 배정, 호출자가 rng 락 보유)
+func (h *InventoryHost) pid(service string, now time.Time, rng *rand.Rand) string {
+	value, ok := h.processes.Load(service)
+	if !ok {
+		process := &inventoryProcess{}
+		process.pid.Store(h.allocatePID(rng))
+		if h.restart > 0 {
+			process.restartAt.Store(now.Add(h.restartDelay(rng)).UnixNano())
+		}
+		value, _ = h.processes.LoadOrStore(service, process)
+	}
+	process := value.(*inventoryProcess)
+
+	if restartAt := process.restartAt.Load(); restartAt != 0 && now.UnixNano() >= restartAt {
+		// 여러 워커가 동시에 만나도 한 번만 재시작
+		if process.restartAt.CompareAndSwap(restartAt, now.Add(h.restartDelay(rng)).UnixNano()) {
+			process.pid.Store(h.allocatePID(rng))
+		}
+	}
+	return *process.pid.Load()
+}
+
+// allocatePID - 호스트의 다음 PID (리눅스처럼 증가하다 pid_max에서 되돌아감)
+func (h *InventoryHost) allocatePID(rng *rand.Rand) *string {
+	next := h.nextPID.Add(int64(1 + rng.Intn(64)))
+	pid := strconv.FormatInt(inventoryMinPID+(next-inventoryMinPID)%(inventoryMaxPID-inventoryMinPID), 10)
+	return &pid
+}
+
+// restartDelay - 다음 재시작까지의 시간 (지수 분포, 평균 = 재시작 간격)
+func (h *InventoryHost) restartDelay(rng *rand.Rand) time.Duration {
+	return time.Duration(rng.ExpFloat64() * float64(h.restart))
+}
+
+// Inventory - 역할/IP/서비스가 정해진 호스트 모음
+//
+// 모든 워커의 포맷터가 같은 인벤토리를 공유하므로 호스트별 PID가 형식과 워커에
+// 관계없이 일관된다. 호스트 배치는 옵션과 시드로 결정되어 실행마다 같다.
+type Inventory struct {
+	hosts    []*InventoryHost
+	byFormat map[string][]*InventoryHost
+	names    map[string][]string
+}
+
+// NewInventory - 옵션으로 인벤토리 생성
+func NewInventory(options InventoryOptions) (*Inventory, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	if options.Hosts == 0 {
+		return nil, fmt.Errorf("인벤토리 호스트 수가 0입니다")
+	}
+	pattern := options.Pattern
+	if pattern == "" {
+		pattern = defaultInventoryPattern
+	}
+	seed := options.Seed
+	if seed == 0 {
+		seed = defaultInventorySeed
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	counts := inventoryRoleCounts(options.Hosts, options.Roles)
+	allocators := make(map[string]*ipv4Allocator)
+	inventory := &Inventory{
+		byFormat: make(map[string][]*InventoryHost),
+		names:    make(map[string][]string),
+	}
+	seen := make(map[string]bool, options.Hosts)
+
+	for roleIdx := range inventoryRoles {
+		role := &inventoryRoles[roleIdx]
+		cidr := role.cidr
+		if custom, ok := options.CIDRs[role.name]; ok {
+			cidr = custom
+		}
+		allocator, ok := allocators[cidr]
+		if !ok {
+			allocator = newIPv4Allocator(cidr)
+			allocators[cidr] = allocator
+		}
+
+		width := max(2, len(strconv.Itoa(counts[roleIdx])))
+		for n := 1; n <= counts[roleIdx]; n++ {
+			ip, err := allocator.next()
+			if err != nil {
+				return nil, fmt.Errorf("%s 역할 대역 %s: %v", role.name, cidr, err)
+			}
+			name := strings.ReplaceAll(pattern, "{role}", role.prefix)
+			name = strings.ReplaceAll(name, "{n}", fmt.Sprintf("%0*d", width, n))
+			if seen[name] {
+				return nil, fmt.Errorf("호스트 이름이 중복됩니다: %s (패턴에 {role}이 필요합니다)", name)
+			}
+			seen[name] = true
+
+			host := &InventoryHost{
+				Name:    name,
+				IP:      ip,
+				Role:    role.name,
+				OS:      role.os,
+				roleIdx: roleIdx,
+				restart: time.Duration(options.RestartMinutes) * time.Minute,
+			}
+			host.nextPID.Store(int64(400 + rng.Intn(2000)))
+			inventory.hosts = append(inventory.hosts, host)
+			for _, format := range role.formats {
+				inventory.byFormat[format] = append(inventory.byFormat[format], host)
+			}
+		}
+	}
+
+	for format, hosts := range inventory.byFormat {
+		names := make([]string, len(hosts))
+		for i, host := range hosts {
+			names[i] = host.Name
+		}
+		inventory.names[format] = names
+	}
+	all := make([]string, len(inventory.hosts))
+	for i, host := range inventory.hosts {
+		all[i] = host.Name
+	}
+	inventory.names[""] = all
+	return inventory, nil
+}
+
+// inventoryRoleCounts - 비율에 따른 역할별 호스트 수 (최대 잔여 방식, 비율이 있는 역할은 최소 1대)
+func inventoryRoleCounts(hosts int, weights map[string]float64) []int {
+	roleWeights := make([]float64, len(inventoryRoles))
+	total := 0.0
+	for i := range inventoryRoles {
+		roleWeights[i] = inventoryRoles[i].weight
+		if len(weights) > 0 {
+			roleWeights[i] = weights[inventoryRoles[i].name]
+		}
+		total += roleWeights[i]
+	}
+
+	counts := make([]int, len(inventoryRoles))
+	remainders := make([]float64, len(inventoryRoles))
+	assigned := 0
+	for i, weight := range roleWeights {
+		share := float64(hosts) * weight / total
+		counts[i] = int(math.Floor(share))
+		remainders[i] = share - float64(counts[i])
+		assigned += counts[i]
+	}
+	order := make([]int, len(inventoryRoles))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order {
+		if assigned == hosts {
+			break
+		}
+		if roleWeights[i] > 0 {
+			counts[i]++
+			assigned++
+		}
+	}
+
+	// 비율이 있는데 0대인 역할은 가장 많은 역할에서 1대를 옮김
+	for i := range counts {
+		if roleWeights[i] == 0 || counts[i] > 0 {
+			continue
+		}
+		largest := 0
+		for j := range counts {
+			if counts[j] > counts[largest] {
+				largest = j
+			}
+		}
+		if counts[largest] > 1 {
+			counts[largest]--
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// ipv4Allocator - 대역 안에서 호스트 주소를 순서대로 배정 (네트워크/브로드캐스트 제외)
+type ipv4Allocator struct {
+	base   uint32
+	size   uint32
+	offset uint32
+}
+
+// newIPv4Allocator - 검증된 CIDR로 배정기 생성 (.1은 게이트웨이로 남기고 .10부터)
+func newIPv4Allocator(cidr string) *ipv4Allocator {
+	_, network, _ := net.ParseCIDR(cidr)
+	ip := network.IP.To4()
+	ones, _ := network.Mask.Size()
+	allocator := &ipv4Allocator{
+		base:   uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3]),
+		size:   uint32(1) << (32 - ones),
+		offset: 1,
+	}
+	if allocator.size > 32 {
+		allocator.offset = 10
+	}
+	return allocator
+}
+
+// next - 다음 주소
+func (a *ipv4Allocator) next() (string, error) {
+	if a.size <= 2 || a.offset >= a.size-1 {
+		return "", fmt.Errorf("주소가 부족합니다")
+	}
+	addr := a.base + a.offset
+	a.offset++
+	return net.IPv4(byte(addr>>24), byte(addr>>16), byte(addr>>8), byte(addr)).String(), nil
+}
+
+// Hosts - 전체 호스트 (역할 순서)
+func (inv *Inventory) Hosts() []*InventoryHost {
+	return inv.hosts
+}
+
+// hostsFor - 형식을 내보내는 역할의 호스트 (역할이 정해지지 않은 형식이나 해당 호스트가 없으면 전체)
+func (inv *Inventory) hostsFor(format string) ([]*InventoryHost, []string) {
+	if format == "" {
+		format = "syslog" // NewSystemLogGenerator 직접 생성
+	}
+	if hosts, ok := inv.byFormat[format]; ok {
+		return hosts, inv.names[format]
+	}
+	return inv.hosts, inv.names[""]
+}
+
+// WriteCSV - 자산 DB 적재용 CSV (hostname, ip, role, os, services, log_formats)
+func (inv *Inventory) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"hostname", "ip", "role", "os", "services", "log_formats"}); err != nil {
+		return err
+	}
+	for _, host := range inv.hosts {
+		record := []string{
+			host.Name, host.IP, host.Role, host.OS,
+			strings.Join(host.Services(), ";"),
+			strings.Join(host.Formats(), ";"),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
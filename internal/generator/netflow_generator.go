@@ -0,0 +1,516 @@
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// 플로 내보내기 상수
+const (
+	netflowPort            = 2055
+	ipfixPort              = 4739
+	flowTemplateID         = 256
+	defaultTemplateRefresh = 20 // Cisco 기본 template refresh-rate (패킷)
+	flowActiveTimeout      = 120000
+	flowInactiveTimeout    = 15000
+
+	// 패킷당 데이터 레코드 수 (헤더 + 템플릿 + 데이터가 1400바이트 이내)
+	netflowV5Records = 30 // v5 최대값
+	netflowV9Records = 28 // 28 × 45바이트
+	ipfixRecords     = 18 // 18 × 70바이트
+)
+
+// FlowOptions - NetFlow/IPFIX 내보내기 옵션
+type FlowOptions struct {
+	// 수집기 포트 (0 = 형식 기본값, NetFlow 2055 / IPFIX 4739)
+	Port int `json:"port,omitempty"`
+	// v9/IPFIX 템플릿 재전송 간격 (패킷 수, 0 = 20)
+	TemplateRefresh int `json:"template_refresh,omitempty"`
+}
+
+// Validate - 플로 옵션 검증
+func (o FlowOptions) Validate() error {
+	if o.Port < 0 || o.Port > 65535 {
+		return fmt.Errorf("잘못된 플로 수집기 포트: %d", o.Port)
+	}
+	if o.TemplateRefresh < 0 {
+		return fmt.Errorf("템플릿 재전송 간격은 0 이상이어야 합니다: %d", o.TemplateRefresh)
+	}
+	return nil
+}
+
+// flowField - 템플릿 필드 (정보 요소 ID, 길이)
+type flowField struct {
+	id     uint16
+	length uint16
+}
+
+// NetFlow v9 템플릿 (appendV9Record와 같은 순서, 45바이트)
+var netflowV9Fields = []flowField{
+	{1, 4},  // IN_BYTES
+	{2, 4},  // IN_PKTS
+	{4, 1},  // PROTOCOL
+	{5, 1},  // SRC_TOS
+	{6, 1},  // TCP_FLAGS
+	{7, 2},  // L4_SRC_PORT
+	{8, 4},  // IPV4_SRC_ADDR
+	{9, 1},  // SRC_MASK
+	{10, 2}, // INPUT_SNMP
+	{11, 2}, // L4_DST_PORT
+	{12, 4}, // IPV4_DST_ADDR
+	{13, 1}, // DST_MASK
+	{14, 2}, // OUTPUT_SNMP
+	{15, 4}, // IPV4_NEXT_HOP
+	{16, 2}, // SRC_AS
+	{17, 2}, // DST_AS
+	{21, 4}, // LAST_SWITCHED
+	{22, 4}, // FIRST_SWITCHED
+}
+
+// IPFIX 템플릿 (appendIPFIXRecord와 같은 순서, 70바이트)
+var ipfixFields = []flowField{
+	{1, 8},   // octetDeltaCount
+	{2, 8},   // packetDeltaCount
+	{4, 1},   // protocolIdentifier
+	{5, 1},   // ipClassOfService
+	{6, 2},   // tcpControlBits
+	{7, 2},   // sourceTransportPort
+	{8, 4},   // sourceIPv4Address
+	{9, 1},   // sourceIPv4PrefixLength
+	{10, 4},  // ingressInterface
+	{11, 2},  // destinationTransportPort
+	{12, 4},  // destinationIPv4Address
+	{13, 1},  // destinationIPv4PrefixLength
+	{14, 4},  // egressInterface
+	{15, 4},  // ipNextHopIPv4Address
+	{16, 4},  // bgpSourceAsNumber
+	{17, 4},  // bgpDestinationAsNumber
+	{152, 8}, // flowStartMilliseconds
+	{153, 8}, // flowEndMilliseconds
+}
+
+// flowService - 서비스별 트래픽 특성
+type flowService struct {
+	protocol   uint8
+	port       uint16
+	internal   bool // 사내 데이터센터 서버 (그 외는 인터넷)
+	minPackets int
+	maxPackets int
+	minSize    int // 패킷당 바이트
+	maxSize    int
+	weight     float64
+}
+
+var flowServices = []flowService{
+	{protocol: 6, port: 443, minPackets: 4, maxPackets: 20000, minSize: 80, maxSize: 1400, weight: 40},
+	{protocol: 17, port: 443, minPackets: 4, maxPackets: 8000, minSize: 80, maxSize: 1350, weight: 8}, // QUIC
+	{protocol: 6, port: 80, minPackets: 4, maxPackets: 2000, minSize: 60, maxSize: 1400, weight: 10},
+	{protocol: 17, port: 53, minPackets: 1, maxPackets: 2, minSize: 60, maxSize: 300, weight: 15},
+	{protocol: 17, port: 123, minPackets: 1, maxPackets: 1, minSize: 76, maxSize: 76, weight: 3},
+	{protocol: 6, port: 22, internal: true, minPackets: 10, maxPackets: 5000, minSize: 52, maxSize: 400, weight: 4},
+	{protocol: 6, port: 445, internal: true, minPackets: 6, maxPackets: 3000, minSize: 60, maxSize: 1400, weight: 4},
+	{protocol: 6, port: 3389, internal: true, minPackets: 20, maxPackets: 8000, minSize: 60, maxSize: 1200, weight: 2},
+	{protocol: 1, minPackets: 1, maxPackets: 4, minSize: 84, maxSize: 84, weight: 4}, // ICMP echo
+}
+
+// 누적 TCP 플래그 분포 (0x1b = FIN|SYN|PSH|ACK, 0x18 = 활성 타임아웃으로 내보낸 진행 중 세션,
+// 0x02 = 응답 없는 SYN, 0x14 = RST|ACK, 0x13 = FIN|SYN|ACK)
+var flowTCPFlags = map[int]float64{0x1b: 70, 0x18: 10, 0x02: 8, 0x14: 7, 0x13: 5}
+
+// 외부 서버 AS (Google, Amazon, Microsoft, Cloudflare, Meta, KT, LG U+, SK브로드밴드)
+var flowExternalAS = []uint16{15169, 16509, 8075, 13335, 32934, 4766, 3786, 9318}
+
+// 인터페이스 (ifIndex)
+const (
+	flowIfLAN = 1 // 사용자 대역 10.1.0.0/16
+	flowIfWAN = 2 // 인터넷 (ISP 게이트웨이)
+	flowIfDC  = 3 // 데이터센터 10.20.0.0/16
+)
+
+// flowPeer - 플로 상대 주소
+type flowPeer struct {
+	addr uint32
+	as   uint16
+	mask uint8
+}
+
+// flowRecord - 합성 단방향 플로
+type flowRecord struct {
+	src, dst         flowPeer
+	nextHop          uint32
+	srcPort, dstPort uint16
+	protocol         uint8
+	tcpFlags         uint8
+	input, output    uint16
+	packets, bytes   uint32
+	first, last      uint32 // sysUptime 기준 ms
+}
+
+// flowSource - 5-튜플 플로 합성기
+type flowSource struct {
+	services cumulativeTable
+	tcpFlags cumulativeTable
+	clients  []flowPeer
+	servers  []flowPeer // 인터넷 서버
+	internal []flowPeer // 데이터센터 서버
+}
+
+// newFlowSource - 클라이언트/서버 풀 초기화
+func newFlowSource(rng *rand.Rand) (*flowSource, error) {
+	weights := make(map[int]float64, len(flowServices))
+	for i, service := range flowServices {
+		weights[i] = service.weight
+	}
+	services, err := newCumulativeTable(weights, "플로 서비스")
+	if err != nil {
+		return nil, err
+	}
+	tcpFlags, err := newCumulativeTable(flowTCPFlags, "TCP 플래그")
+	if err != nil {
+		return nil, err
+	}
+
+	source := &flowSource{
+		services: services,
+		tcpFlags: tcpFlags,
+		clients:  make([]flowPeer, 1024),
+		servers:  make([]flowPeer, 512),
+		internal: make([]flowPeer, 32),
+	}
+	for i := range source.clients {
+		source.clients[i] = flowPeer{addr: 10<<24 | 1<<16 | uint32(rng.Intn(64))<<8 | uint32(2+rng.Intn(250)), mask: 24}
+	}
+	for i := range source.servers {
+		// 사설/예약 대역을 피한 공인 주소
+		first := uint32(1 + rng.Intn(222))
+		if first == 10 || first == 127 || first == 100 || first == 172 || first == 192 || first == 169 {
+			first = 211
+		}
+		source.servers[i] = flowPeer{
+			addr: first<<24 | uint32(rng.Intn(256))<<16 | uint32(rng.Intn(256))<<8 | uint32(1+rng.Intn(254)),
+			as:   flowExternalAS[rng.Intn(len(flowExternalAS))],
+			mask: uint8(16 + rng.Intn(9)),
+		}
+	}
+	for i := range source.internal {
+		source.internal[i] = flowPeer{addr: 10<<24 | 20<<16 | uint32(i/8)<<8 | uint32(10+i), mask: 24}
+	}
+	return source, nil
+}
+
+// next - 플로 하나 합성 (uptime = 내보내는 시점의 sysUptime)
+func (s *flowSource) next(rng *rand.Rand, uptime uint32) flowRecord {
+	service := &flowServices[s.services.pick(rng)]
+	client := s.clients[skewedIndex(rng, len(s.clients))]
+
+	record := flowRecord{
+		src:      client,
+		srcPort:  uint16(49152 + rng.Intn(16384)),
+		dstPort:  service.port,
+		protocol: service.protocol,
+		input:    flowIfLAN,
+	}
+	if service.internal {
+		record.dst = s.internal[skewedIndex(rng, len(s.internal))]
+		record.output, record.nextHop = flowIfDC, 10<<24|20<<16|1
+	} else {
+		record.dst = s.servers[skewedIndex(rng, len(s.servers))]
+		record.output, record.nextHop = flowIfWAN, 203<<24|113<<8|1
+	}
+	if service.protocol == 1 {
+		record.srcPort, record.dstPort = 0, 8<<8 // echo request (type 8, code 0)
+	}
+
+	packets := logUniform(rng, service.minPackets, service.maxPackets)
+	size := service.minSize + rng.Intn(service.maxSize-service.minSize+1)
+	if service.protocol == 6 {
+		record.tcpFlags = uint8(s.tcpFlags.pick(rng))
+		if record.tcpFlags == 0x02 {
+			packets, size = 1+rng.Intn(3), 60
+		}
+	}
+	record.packets, record.bytes = uint32(packets), uint32(packets*size)
+
+	// 응답 방향 (서버 → 클라이언트)
+	if rng.Intn(100) < 45 && record.tcpFlags != 0x02 {
+		record.src, record.dst = record.dst, record.src
+		record.srcPort, record.dstPort = record.dstPort, record.srcPort
+		record.input, record.output = record.output, flowIfLAN
+		record.nextHop = 10<<24 | 1<<16 | 1
+		if service.protocol == 1 {
+			record.srcPort, record.dstPort = 0, 0 // echo reply
+		}
+	}
+
+	// 활성/비활성 타임아웃 안에서 지속 시간과 내보내기 지연 결정
+	duration := packets * logUniform(rng, 1, 40)
+	if duration > flowActiveTimeout {
+		duration = flowActiveTimeout
+	}
+	record.last = uptime - uint32(rng.Intn(flowInactiveTimeout))
+	record.first = record.last - uint32(duration)
+	return record
+}
+
+// FlowExporter - NetFlow v5/v9, IPFIX 내보내기 패킷 생성기
+//
+// Generate 한 번이 수집기로 보낼 UDP 페이로드 하나이며, 항상 같은 수의 데이터 레코드를
+// 담는다. 시퀀스 번호는 v5/IPFIX는 누적 레코드 수, v9는 누적 패킷 수이고,
+// v9/IPFIX 템플릿은 첫 패킷과 이후 templateRefresh 패킷마다 다시 포함된다.
+type FlowExporter struct {
+	name            string
+	version         int
+	port            int
+	records         int
+	templateRefresh int
+
+	source   *flowSource
+	flows    []flowRecord
+	boot     time.Time // sysUptime 기준 시각
+	sourceID uint32    // v9 Source ID / IPFIX Observation Domain ID / v5 engine_id
+	sequence uint32
+	exported uint32 // 보낸 패킷 수
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newFlowExporter - 플로 내보내기 생성기 초기화
+func newFlowExporter(name string, version int, options GeneratorOptions) (*FlowExporter, error) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	source, err := newFlowSource(rng)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &FlowExporter{
+		name:            name,
+		version:         version,
+		port:            options.Flow.Port,
+		templateRefresh: options.Flow.TemplateRefresh,
+		source:          source,
+		// 라우터가 며칠 전 부팅된 것으로 간주
+		boot:     time.Now().Add(-time.Duration(24+rng.Intn(24*30)) * time.Hour),
+		sourceID: uint32(rng.Intn(256)),
+		rng:      rng,
+	}
+	if gen.templateRefresh == 0 {
+		gen.templateRefresh = defaultTemplateRefresh
+	}
+	switch version {
+	case 5:
+		gen.records = netflowV5Records
+	case 9:
+		gen.records = netflowV9Records
+	default:
+		gen.records = ipfixRecords
+	}
+	if gen.port == 0 {
+		gen.port = netflowPort
+		if version == 10 {
+			gen.port = ipfixPort
+		}
+	}
+	gen.flows = make([]flowRecord, gen.records)
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("netflow_v5", func(options GeneratorOptions) (LogFormatter, error) {
+		return newFlowExporter("netflow_v5", 5, options)
+	})
+	RegisterFormatter("netflow_v9", func(options GeneratorOptions) (LogFormatter, error) {
+		return newFlowExporter("netflow_v9", 9, options)
+	})
+	RegisterFormatter("ipfix", func(options GeneratorOptions) (LogFormatter, error) {
+		return newFlowExporter("ipfix", 10, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *FlowExporter) Name() string {
+	return g.name
+}
+
+// RecordsPerPacket - PacketFormatter 구현
+func (g *FlowExporter) RecordsPerPacket() int {
+	return g.records
+}
+
+// DestinationPort - PacketFormatter 구현
+func (g *FlowExporter) DestinationPort() int {
+	return g.port
+}
+
+// Generate - LogFormatter 구현 (내보내기 패킷 하나)
+func (g *FlowExporter) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	now := time.Now()
+	uptime := uint32(now.Sub(g.boot) / time.Millisecond)
+	for i := range g.flows {
+		g.flows[i] = g.source.next(g.rng, uptime)
+	}
+
+	switch g.version {
+	case 5:
+		buffer = g.appendV5(buffer, now, uptime)
+	case 9:
+		buffer = g.appendV9(buffer, now, uptime)
+	default:
+		buffer = g.appendIPFIX(buffer, now)
+	}
+	g.exported++
+	return finishBuffer(buffer)
+}
+
+// includeTemplate - 이번 패킷에 템플릿을 포함할지 여부
+func (g *FlowExporter) includeTemplate() bool {
+	return g.exported%uint32(g.templateRefresh) == 0
+}
+
+// appendV5 - NetFlow v5 패킷 (24바이트 헤더 + 48바이트 레코드)
+func (g *FlowExporter) appendV5(buffer []byte, now time.Time, uptime uint32) []byte {
+	be := binary.BigEndian
+	buffer = be.AppendUint16(buffer, 5)
+	buffer = be.AppendUint16(buffer, uint16(len(g.flows)))
+	buffer = be.AppendUint32(buffer, uptime)
+	buffer = be.AppendUint32(buffer, uint32(now.Unix()))
+	buffer = be.AppendUint32(buffer, uint32(now.Nanosecond()))
+	buffer = be.AppendUint32(buffer, g.sequence) // 이 패킷 첫 플로의 일련번호
+	buffer = append(buffer, 0, byte(g.sourceID)) // engine_type, engine_id
+	buffer = be.AppendUint16(buffer, 0)          // sampling_interval
+
+	for i := range g.flows {
+		flow := &g.flows[i]
+		buffer = be.AppendUint32(buffer, flow.src.addr)
+		buffer = be.AppendUint32(buffer, flow.dst.addr)
+		buffer = be.AppendUint32(buffer, flow.nextHop)
+		buffer = be.AppendUint16(buffer, flow.input)
+		buffer = be.AppendUint16(buffer, flow.output)
+		buffer = be.AppendUint32(buffer, flow.packets)
+		buffer = be.AppendUint32(buffer, flow.bytes)
+		buffer = be.AppendUint32(buffer, flow.first)
+		buffer = be.AppendUint32(buffer, flow.last)
+		buffer = be.AppendUint16(buffer, flow.srcPort)
+		buffer = be.AppendUint16(buffer, flow.dstPort)
+		buffer = append(buffer, 0, flow.tcpFlags, flow.protocol, 0) // pad1, tcp_flags, prot, tos
+		buffer = be.AppendUint16(buffer, flow.src.as)
+		buffer = be.AppendUint16(buffer, flow.dst.as)
+		buffer = append(buffer, flow.src.mask, flow.dst.mask, 0, 0) // src_mask, dst_mask, pad2
+	}
+	g.sequence += uint32(len(g.flows))
+	return buffer
+}
+
+// appendV9 - NetFlow v9 패킷 (RFC 3954, 템플릿 FlowSet은 주기적으로 포함)
+func (g *FlowExporter) appendV9(buffer []byte, now time.Time, uptime uint32) []byte {
+	be := binary.BigEndian
+	template := g.includeTemplate()
+	count := len(g.flows)
+	if template {
+		count++
+	}
+	buffer = be.AppendUint16(buffer, 9)
+	buffer = be.AppendUint16(buffer, uint16(count)) // 템플릿 + 데이터 레코드 수
+	buffer = be.AppendUint32(buffer, uptime)
+	buffer = be.AppendUint32(buffer, uint32(now.Unix()))
+	buffer = be.AppendUint32(buffer, g.exported) // 패킷 일련번호
+	buffer = be.AppendUint32(buffer, g.sourceID)
+
+	if template {
+		buffer = appendFlowTemplate(buffer, 0, netflowV9Fields)
+	}
+
+	start := len(buffer)
+	buffer = be.AppendUint16(buffer, flowTemplateID)
+	buffer = be.AppendUint16(buffer, 0) // 길이 (아래에서 채움)
+	for i := range g.flows {
+		flow := &g.flows[i]
+		buffer = be.AppendUint32(buffer, flow.bytes)
+		buffer = be.AppendUint32(buffer, flow.packets)
+		buffer = append(buffer, flow.protocol, 0, flow.tcpFlags)
+		buffer = be.AppendUint16(buffer, flow.srcPort)
+		buffer = be.AppendUint32(buffer, flow.src.addr)
+		buffer = append(buffer, flow.src.mask)
+		buffer = be.AppendUint16(buffer, flow.input)
+		buffer = be.AppendUint16(buffer, flow.dstPort)
+		buffer = be.AppendUint32(buffer, flow.dst.addr)
+		buffer = append(buffer, flow.dst.mask)
+		buffer = be.AppendUint16(buffer, flow.output)
+		buffer = be.AppendUint32(buffer, flow.nextHop)
+		buffer = be.AppendUint16(buffer, flow.src.as)
+		buffer = be.AppendUint16(buffer, flow.dst.as)
+		buffer = be.AppendUint32(buffer, flow.last)
+		buffer = be.AppendUint32(buffer, flow.first)
+	}
+	// FlowSet은 4바이트 경계로 패딩
+	for (len(buffer)-start)%4 != 0 {
+		buffer = append(buffer, 0)
+	}
+	be.PutUint16(buffer[start+2:], uint16(len(buffer)-start))
+	return buffer
+}
+
+// appendIPFIX - IPFIX 메시지 (RFC 7011, 템플릿 Set은 주기적으로 포함)
+func (g *FlowExporter) appendIPFIX(buffer []byte, now time.Time) []byte {
+	be := binary.BigEndian
+	buffer = be.AppendUint16(buffer, 10)
+	buffer = be.AppendUint16(buffer, 0) // 메시지 길이 (아래에서 채움)
+	buffer = be.AppendUint32(buffer, uint32(now.Unix()))
+	buffer = be.AppendUint32(buffer, g.sequence) // 이전까지 보낸 데이터 레코드 수
+	buffer = be.AppendUint32(buffer, g.sourceID)
+
+	if g.includeTemplate() {
+		buffer = appendFlowTemplate(buffer, 2, ipfixFields)
+	}
+
+	bootMillis := uint64(g.boot.UnixMilli())
+	start := len(buffer)
+	buffer = be.AppendUint16(buffer, flowTemplateID)
+	buffer = be.AppendUint16(buffer, 0) // Set 길이 (아래에서 채움)
+	for i := range g.flows {
+		flow := &g.flows[i]
+		buffer = be.AppendUint64(buffer, uint64(flow.bytes))
+		buffer = be.AppendUint64(buffer, uint64(flow.packets))
+		buffer = append(buffer, flow.protocol, 0)
+		buffer = be.AppendUint16(buffer, uint16(flow.tcpFlags))
+		buffer = be.AppendUint16(buffer, flow.srcPort)
+		buffer = be.AppendUint32(buffer, flow.src.addr)
+		buffer = append(buffer, flow.src.mask)
+		buffer = be.AppendUint32(buffer, uint32(flow.input))
+		buffer = be.AppendUint16(buffer, flow.dstPort)
+		buffer = be.AppendUint32(buffer, flow.dst.addr)
+		buffer = append(buffer, flow.dst.mask)
+		buffer = be.AppendUint32(buffer, uint32(flow.output))
+		buffer = be.AppendUint32(buffer, flow.nextHop)
+		buffer = be.AppendUint32(buffer, uint32(flow.src.as))
+		buffer = be.AppendUint32(buffer, uint32(flow.dst.as))
+		buffer = be.AppendUint64(buffer, bootMillis+uint64(flow.first))
+		buffer = be.AppendUint64(buffer, bootMillis+uint64(flow.last))
+	}
+	be.PutUint16(buffer[start+2:], uint16(len(buffer)-start))
+	be.PutUint16(buffer[2:], uint16(len(buffer)))
+	g.sequence += uint32(len(g.flows))
+	return buffer
+}
+
+// appendFlowTemplate - 템플릿 FlowSet/Set (setID: v9 = 0, IPFIX = 2)
+func appendFlowTemplate(buffer []byte, setID uint16, fields []flowField) []byte {
+	be := binary.BigEndian
+	buffer = be.AppendUint16(buffer, setID)
+	buffer = be.AppendUint16(buffer, uint16(8+4*len(fields)))
+	buffer = be.AppendUint16(buffer, flowTemplateID)
+	buffer = be.AppendUint16(buffer, uint16(len(fields)))
+	for _, field := range fields {
+		buffer = be.AppendUint16(buffer, field.id)
+		buffer = be.AppendUint16(buffer, field.length)
+	}
+	return buffer
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// flowPacket - 테스트용으로 해석한 v9/IPFIX 패킷
+type flowPacket struct {
+	count    int         // v9 헤더의 레코드 수 (IPFIX는 0)
+	length   int         // IPFIX 헤더의 메시지 길이 (v9는 0)
+	template []flowField // 템플릿 Set이 있으면 그 필드 목록
+	records  [][]byte    // 데이터 레코드
+}
+
+// parseFlowPacket - 헤더와 Set을 순서대로 읽어 템플릿과 데이터 레코드로 나눔
+//
+// 데이터 Set은 fields(직전 템플릿)의 길이 합으로 자르고, Set 경계가 패킷 끝과
+// 정확히 맞아야 한다.
+func parseFlowPacket(t *testing.T, packet []byte, version int, fields []flowField) flowPacket {
+	t.Helper()
+	be := binary.BigEndian
+
+	var parsed flowPacket
+	headerLen, templateSetID := 20, uint16(0)
+	if version == 10 {
+		headerLen, templateSetID = 16, 2
+	}
+	if len(packet) < headerLen {
+		t.Fatalf("패킷이 헤더보다 짧음: %d바이트", len(packet))
+	}
+	if got := int(be.Uint16(packet)); got != version {
+		t.Fatalf("버전 = %d, 기대값 %d", got, version)
+	}
+	if version == 10 {
+		parsed.length = int(be.Uint16(packet[2:]))
+	} else {
+		parsed.count = int(be.Uint16(packet[2:]))
+	}
+
+	for pos := headerLen; pos < len(packet); {
+		if len(packet)-pos < 4 {
+			t.Fatalf("오프셋 %d: Set 헤더가 잘림", pos)
+		}
+		setID := be.Uint16(packet[pos:])
+		setLen := int(be.Uint16(packet[pos+2:]))
+		if setLen < 4 || pos+setLen > len(packet) {
+			t.Fatalf("오프셋 %d: 잘못된 Set 길이 %d (패킷 %d바이트)", pos, setLen, len(packet))
+		}
+		body := packet[pos+4 : pos+setLen]
+
+		switch setID {
+		case templateSetID:
+			if got := be.Uint16(body); got != flowTemplateID {
+				t.Fatalf("템플릿 ID = %d, 기대값 %d", got, flowTemplateID)
+			}
+			n := int(be.Uint16(body[2:]))
+			if len(body) != 4+4*n {
+				t.Fatalf("템플릿 Set 길이 %d가 필드 %d개와 맞지 않음", setLen, n)
+			}
+			for i := 0; i < n; i++ {
+				parsed.template = append(parsed.template, flowField{
+					id:     be.Uint16(body[4+4*i:]),
+					length: be.Uint16(body[6+4*i:]),
+				})
+			}
+			fields = parsed.template
+		case flowTemplateID:
+			recordLen := flowRecordLength(fields)
+			if recordLen == 0 {
+				t.Fatal("템플릿보다 데이터 Set이 먼저 옴")
+			}
+			for len(body) >= recordLen {
+				parsed.records = append(parsed.records, body[:recordLen])
+				body = body[recordLen:]
+			}
+			// 남는 바이트는 4바이트 정렬 패딩(0)만 허용
+			if len(body) >= 4 {
+				t.Fatalf("데이터 Set에 레코드보다 짧은 %d바이트가 남음", len(body))
+			}
+			for _, b := range body {
+				if b != 0 {
+					t.Fatalf("데이터 Set 패딩이 0이 아님: % x", body)
+				}
+			}
+		default:
+			t.Fatalf("오프셋 %d: 알 수 없는 Set ID %d", pos, setID)
+		}
+		pos += setLen
+	}
+	return parsed
+}
+
+// flowRecordLength - 템플릿 필드 길이 합
+func flowRecordLength(fields []flowField) int {
+	total := 0
+	for _, field := range fields {
+		total += int(field.length)
+	}
+	return total
+}
+
+// flowFieldValue - 템플릿 순서대로 레코드를 읽어 정보 요소 id의 값 반환
+func flowFieldValue(t *testing.T, fields []flowField, record []byte, id uint16) uint64 {
+	t.Helper()
+	pos := 0
+	for _, field := range fields {
+		if field.id == id {
+			var value uint64
+			for _, b := range record[pos : pos+int(field.length)] {
+				value = value<<8 | uint64(b)
+			}
+			return value
+		}
+		pos += int(field.length)
+	}
+	t.Fatalf("템플릿에 정보 요소 %d가 없음", id)
+	return 0
+}
+
+func TestFlowRecordLengthMatchesTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		version    int
+		fields     []flowField
+		recordLen  int
+		records    int
+		start, end uint16 // 플로 시작/종료 시각 정보 요소
+	}{
+		{"netflow_v9", "netflow_v9", 9, netflowV9Fields, 45, netflowV9Records, 22, 21},
+		{"ipfix", "ipfix", 10, ipfixFields, 70, ipfixRecords, 152, 153},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flowRecordLength(tt.fields); got != tt.recordLen {
+				t.Fatalf("템플릿 필드 길이 합 = %d, 기대값 %d", got, tt.recordLen)
+			}
+
+			options := DefaultGeneratorOptions()
+			options.Flow.TemplateRefresh = 2
+			formatter, err := NewFormatter(tt.format, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 템플릿 포함 → 미포함 → 포함 순서
+			var fields []flowField
+			for i, wantTemplate := range []bool{true, false, true} {
+				packet := formatter.Generate()
+				parsed := parseFlowPacket(t, packet, tt.version, fields)
+
+				if (parsed.template != nil) != wantTemplate {
+					t.Fatalf("패킷 %d: 템플릿 포함 = %v, 기대값 %v", i, parsed.template != nil, wantTemplate)
+				}
+				if parsed.template != nil {
+					if len(parsed.template) != len(tt.fields) {
+						t.Fatalf("패킷 %d: 템플릿 필드 %d개, 기대값 %d개", i, len(parsed.template), len(tt.fields))
+					}
+					for j, field := range parsed.template {
+						if field != tt.fields[j] {
+							t.Fatalf("패킷 %d: 템플릿 필드 %d = %+v, 기대값 %+v", i, j, field, tt.fields[j])
+						}
+					}
+					fields = parsed.template
+				}
+				if len(parsed.records) != tt.records {
+					t.Fatalf("패킷 %d: 데이터 레코드 %d개, 기대값 %d개", i, len(parsed.records), tt.records)
+				}
+
+				switch tt.version {
+				case 9:
+					want := tt.records
+					if wantTemplate {
+						want++
+					}
+					if parsed.count != want {
+						t.Fatalf("패킷 %d: 헤더 레코드 수 = %d, 기대값 %d", i, parsed.count, want)
+					}
+				case 10:
+					if parsed.length != len(packet) {
+						t.Fatalf("패킷 %d: 헤더 메시지 길이 = %d, 실제 %d", i, parsed.length, len(packet))
+					}
+				}
+
+				// 필드 순서가 템플릿과 같으면 값이 각 정보 요소의 범위 안에 있어야 함
+				for j, record := range parsed.records {
+					switch protocol := flowFieldValue(t, fields, record, 4); protocol {
+					case 1, 6, 17:
+					default:
+						t.Fatalf("패킷 %d 레코드 %d: 잘못된 프로토콜 %d", i, j, protocol)
+					}
+					octets := flowFieldValue(t, fields, record, 1)
+					packets := flowFieldValue(t, fields, record, 2)
+					if packets == 0 || octets < packets {
+						t.Fatalf("패킷 %d 레코드 %d: 바이트 %d, 패킷 %d", i, j, octets, packets)
+					}
+					start := flowFieldValue(t, fields, record, tt.start)
+					end := flowFieldValue(t, fields, record, tt.end)
+					if start > end {
+						t.Fatalf("패킷 %d 레코드 %d: 시작 %d > 종료 %d", i, j, start, end)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 호스트명 접두사 사용 시 생성할 호스트 수
+const defaultHostCount = 20
+
+// 기본 서버 호스트명 풀 (실제 환경과 유사)
+var defaultHostnames = []string{
+	"server01", "server02", "server03", "server04", "server05",
+	"web01", "web02", "web03", "db01", "db02", "cache01", "cache02",
+	"app01", "app02", "app03", "proxy01", "proxy02", "lb01", "lb02",
+}
+
+// 기본 시스템 서비스명 풀 (PRD 명세 반영)
+var defaultServices = []string{
+	"systemd", "kernel", "sshd", "nginx", "apache2", "mysqld",
+	"redis-server", "cron", "rsyslog", "NetworkManager", "docker",
+	"kubelet", "containerd", "etcd", "prometheus", "grafana",
+}
+
+// SyslogFormat - syslog 헤더 형식
+type SyslogFormat string
+
+const (
+	// SyslogRFC3164 - <PRI>TIMESTAMP HOST TAG[PID]: MSG (기존 기본값)
+	SyslogRFC3164 SyslogFormat = "rfc3164"
+	// SyslogRFC5424 - <PRI>1 TIMESTAMP HOST APP PROCID MSGID [SD] MSG
+	SyslogRFC5424 SyslogFormat = "rfc5424"
+)
+
+// ParseSyslogFormat - 문자열을 SyslogFormat으로 변환 (빈 값은 RFC 3164)
+func ParseSyslogFormat(name string) (SyslogFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "rfc3164", "3164", "bsd":
+		return SyslogRFC3164, nil
+	case "rfc5424", "5424", "ietf":
+		return SyslogRFC5424, nil
+	}
+	return "", fmt.Errorf("지원하지 않는 syslog 형식: %s (rfc3164, rfc5424)", name)
+}
+
+// GeneratorOptions - 로그 생성기 출력 옵션 (모든 포맷터 공용)
+type GeneratorOptions struct {
+	SyslogFormat SyslogFormat         // 헤더 형식 (기본 RFC 3164)
+	UseBOM       bool                 // RFC 5424 MSG 앞에 UTF-8 BOM 추가
+	Priority     PriorityDistribution // 퍼실리티/심각도 가중치 분포 (비어 있는 가중치는 DefaultPriorityDistribution)
+
+	// 호스트/서비스 풀 (비어 있으면 기본 풀 사용)
+	HostnamePrefix string   // 접두사 + 01..20 형태로 호스트명 생성
+	Services       []string // syslog TAG로 사용할 서비스 목록
+
+	// 사용자 정의 메시지 템플릿 (nil이면 내장 메시지, syslog/ecs/gelf에 적용)
+	Templates *MessageTemplates
+
+	// 역할 기반 호스트 인벤토리 (nil이면 호스트명 풀 사용, 설정 시 HostnamePrefix 무시)
+	Inventory *Inventory
+
+	// 형식별 옵션
+	Web     WebAccessOptions // 웹 서버 접근 로그
+	CEF     CEFOptions       // ArcSight CEF 장비 식별자
+	LEEF    LEEFOptions      // QRadar LEEF 장비 식별자/구분자
+	Audit   AuditOptions     // auditd 규칙 키
+	DNS     DNSOptions       // DNS 서버 로그 의심 도메인 비율
+	VPCFlow VPCFlowOptions   // VPC 흐름 로그 사용자 지정 필드
+	Flow    FlowOptions      // NetFlow/IPFIX 수집기 포트/템플릿 주기
+	GELF    GELFOptions      // GELF 압축/UDP 청크 크기
+
+	StackTrace StackTraceOptions // 멀티라인 예외 로그 언어/깊이
+	Session    SessionOptions    // 로그인 세션 동시 수/지속 시간
+
+	// 메시지 크기 분포 (Distribution이 빈 값이면 끔, 플로/GELF 형식 제외)
+	Size SizeOptions
+
+	// 비정상 메시지 퍼징 (Ratio가 0이면 끔, 플로/GELF 형식 제외)
+	Fuzz FuzzOptions
+
+	// 공격 시나리오 주입 (nil이면 끔, 플로/GELF 형식 제외, 모든 워커가 공유)
+	Scenarios *ScenarioLibrary
+
+	// 주입 메시지(시나리오/퍼징) 레이블 보관 (정답 파일을 쓸 때 워커 풀이 설정, InjectionSource 참고)
+	TrackInjections bool
+
+	// 로그 한 건의 최대 전송 바이트 수 (0 = 무제한, UDP 전송이면 워커 풀이 최대 데이터그램 크기로 설정)
+	MessageLimit int
+
+	// 포맷터 형식 이름 (NewFormatter가 설정, 인벤토리에서 형식별 호스트 선택용)
+	format string
+}
+
+// Validate - 출력 옵션 검증
+func (o GeneratorOptions) Validate() error {
+	if _, err := ParseSyslogFormat(string(o.SyslogFormat)); err != nil {
+		return err
+	}
+	if strings.ContainsAny(o.HostnamePrefix, " \t\r\n") {
+		return fmt.Errorf("호스트명 접두사에 공백을 사용할 수 없습니다: %q", o.HostnamePrefix)
+	}
+	for _, service := range o.Services {
+		if service == "" || strings.ContainsAny(service, " \t\r\n[]:") {
+			return fmt.Errorf("잘못된 서비스명: %q", service)
+		}
+	}
+	if err := o.Web.Validate(); err != nil {
+		return err
+	}
+	if err := o.CEF.Validate(); err != nil {
+		return err
+	}
+	if err := o.LEEF.Validate(); err != nil {
+		return err
+	}
+	if err := o.Audit.Validate(); err != nil {
+		return err
+	}
+	if err := o.DNS.Validate(); err != nil {
+		return err
+	}
+	if err := o.VPCFlow.Validate(); err != nil {
+		return err
+	}
+	if err := o.Flow.Validate(); err != nil {
+		return err
+	}
+	if err := o.GELF.Validate(); err != nil {
+		return err
+	}
+	if err := o.StackTrace.Validate(); err != nil {
+		return err
+	}
+	if err := o.Session.Validate(); err != nil {
+		return err
+	}
+	if err := o.Size.Validate(); err != nil {
+		return err
+	}
+	if err := o.Fuzz.Validate(); err != nil {
+		return err
+	}
+	return o.Priority.Validate()
+}
+
+// DefaultGeneratorOptions - 기존 동작(RFC 3164)과 동일한 기본 옵션
+func DefaultGeneratorOptions() GeneratorOptions {
+	return GeneratorOptions{
+		SyslogFormat: SyslogRFC3164,
+		DNS:          DNSOptions{SuspiciousRatio: defaultDNSSuspiciousRatio},
+	}
+}
+
+// hostnames - 옵션에 따른 호스트명 풀 (인벤토리가 있으면 형식을 내보내는 역할의 호스트)
+func (o GeneratorOptions) hostnames() []string {
+	if o.Inventory != nil {
+		_, names := o.Inventory.hostsFor(o.format)
+		return names
+	}
+	if o.HostnamePrefix == "" {
+		return defaultHostnames
+	}
+	hostnames := make([]string, defaultHostCount)
+	for i := range hostnames {
+		hostnames[i] = fmt.Sprintf("%s%02d", o.HostnamePrefix, i+1)
+	}
+	return hostnames
+}
+
+// inventoryHosts - hostnames()와 인덱스가 1:1인 인벤토리 호스트 (인벤토리가 없으면 nil)
+func (o GeneratorOptions) inventoryHosts() []*InventoryHost {
+	if o.Inventory == nil {
+		return nil
+	}
+	hosts, _ := o.Inventory.hostsFor(o.format)
+	return hosts
+}
+
+// inventoryIPs - hostnames()와 인덱스가 1:1인 호스트 IP (인벤토리가 없으면 nil)
+func (o GeneratorOptions) inventoryIPs() []string {
+	hosts := o.inventoryHosts()
+	if hosts == nil {
+		return nil
+	}
+	ips := make([]string, len(hosts))
+	for i, host := range hosts {
+		ips[i] = host.IP
+	}
+	return ips
+}
+
+// services - 옵션에 따른 서비스 풀
+func (o GeneratorOptions) services() []string {
+	if len(o.Services) == 0 {
+		return defaultServices
+	}
+	return o.Services
+}
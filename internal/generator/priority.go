@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RFC 5424 §6.2.1 퍼실리티 (코드 = 인덱스)
+var facilityNames = [24]string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// RFC 5424 §6.2.1 심각도 (코드 = 인덱스)
+var severityNames = [8]string{
+	"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug",
+}
+
+// 심각도 별칭 (syslog 구현체마다 표기가 다름)
+var severityAliases = map[string]string{
+	"emergency":     "emerg",
+	"panic":         "emerg",
+	"critical":      "crit",
+	"error":         "err",
+	"warn":          "warning",
+	"informational": "info",
+}
+
+// 서비스별 기본 퍼실리티 (실제 배포판 기본 설정 기준)
+var defaultServiceFacilities = map[string]string{
+	"kernel":         "kern",
+	"sshd":           "authpriv",
+	"sudo":           "authpriv",
+	"su":             "auth",
+	"login":          "auth",
+	"cron":           "cron",
+	"CRON":           "cron",
+	"rsyslog":        "syslog",
+	"rsyslogd":       "syslog",
+	"postfix":        "mail",
+	"dovecot":        "mail",
+	"ntpd":           "ntp",
+	"chronyd":        "daemon",
+	"vsftpd":         "ftp",
+	"systemd":        "daemon",
+	"NetworkManager": "daemon",
+	"docker":         "daemon",
+	"containerd":     "daemon",
+	"kubelet":        "daemon",
+	"etcd":           "daemon",
+	"mysqld":         "daemon",
+	"redis-server":   "daemon",
+	"prometheus":     "daemon",
+	"grafana":        "daemon",
+	"nginx":          "local7",
+	"apache2":        "local7",
+	"apache":         "local7",
+	"httpd":          "local7",
+}
+
+// PriorityDistribution - PRI(퍼실리티 × 심각도) 가중치 분포
+type PriorityDistribution struct {
+	// 서비스 매핑이 없는 경우 사용할 퍼실리티 가중치 (예: user=50, daemon=30)
+	FacilityWeights map[string]float64 `json:"facility_weights,omitempty"`
+	// 기본 심각도 가중치 (예: info=70, notice=20, warning=8, err=2 / SeverityWeightFormats에만 적용)
+	SeverityWeights map[string]float64 `json:"severity_weights,omitempty"`
+	// 퍼실리티별 심각도 가중치 오버라이드 (예: kern → {info: 60, warning: 40} / SeverityWeightFormats에만 적용)
+	FacilitySeverityWeights map[string]map[string]float64 `json:"facility_severity_weights,omitempty"`
+	// 서비스 → 퍼실리티 매핑 오버라이드 (기본 매핑에 병합)
+	ServiceFacilities map[string]string `json:"service_facilities,omitempty"`
+}
+
+// SeverityWeightFormats - 심각도 가중치(SeverityWeights, FacilitySeverityWeights)를 사용하는 형식
+//
+// 나머지 형식은 이벤트마다 심각도가 정해져 있어(예: stacktrace는 err) 심각도 가중치를 무시한다.
+var SeverityWeightFormats = []string{"syslog", "ecs", "gelf"}
+
+// DefaultPriorityDistribution - 운영 환경과 유사한 기본 분포
+func DefaultPriorityDistribution() PriorityDistribution {
+	return PriorityDistribution{
+		FacilityWeights: map[string]float64{
+			"user":   40,
+			"daemon": 40,
+			"local0": 10,
+			"local7": 10,
+		},
+		SeverityWeights: map[string]float64{
+			"info":    70,
+			"notice":  20,
+			"warning": 8,
+			"err":     2,
+		},
+	}
+}
+
+// ParseFacility - 퍼실리티 이름 또는 코드를 코드 값으로 변환
+func ParseFacility(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, facility := range facilityNames {
+		if facility == name {
+			return i, nil
+		}
+	}
+	if code, err := strconv.Atoi(name); err == nil && code >= 0 && code < len(facilityNames) {
+		return code, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 퍼실리티: %s", name)
+}
+
+// ParseSeverity - 심각도 이름 또는 코드를 코드 값으로 변환
+func ParseSeverity(name string) (int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := severityAliases[name]; ok {
+		name = alias
+	}
+	for i, severity := range severityNames {
+		if severity == name {
+			return i, nil
+		}
+	}
+	if code, err := strconv.Atoi(name); err == nil && code >= 0 && code < len(severityNames) {
+		return code, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 심각도: %s", name)
+}
+
+// ParseWeights - "info=70,notice=20" 형식의 가중치 문자열 파싱
+func ParseWeights(spec string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+	if strings.TrimSpace(spec) == "" {
+		return weights, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("가중치 형식 오류: %q (name=weight)", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("가중치 값 오류: %q", pair)
+		}
+		weights[strings.TrimSpace(kv[0])] = weight
+	}
+	return weights, nil
+}
+
+// Validate - 분포 설정 검증
+func (d PriorityDistribution) Validate() error {
+	if _, err := facilityTable(d.FacilityWeights); err != nil {
+		return err
+	}
+	if _, err := severityTable(d.SeverityWeights); err != nil {
+		return err
+	}
+	for facility, weights := range d.FacilitySeverityWeights {
+		if _, err := ParseFacility(facility); err != nil {
+			return err
+		}
+		if _, err := severityTable(weights); err != nil {
+			return err
+		}
+	}
+	for _, facility := range d.ServiceFacilities {
+		if _, err := ParseFacility(facility); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateFormats - 심각도 가중치를 지정했는데 선택한 형식 중 이를 사용하는 형식이 없으면 오류
+func (d PriorityDistribution) ValidateFormats(formats []string) error {
+	if len(d.SeverityWeights) == 0 && len(d.FacilitySeverityWeights) == 0 {
+		return nil
+	}
+	for _, name := range formats {
+		if slices.Contains(SeverityWeightFormats, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("심각도 가중치는 %s 형식에만 적용됩니다 (선택한 형식: %s)",
+		strings.Join(SeverityWeightFormats, ", "), strings.Join(formats, ", "))
+}
+
+// cumulativeTable - 누적 가중치 테이블 (소수 항목 대상 선형 탐색)
+type cumulativeTable struct {
+	codes      []int
+	cumulative []float64
+}
+
+func (t cumulativeTable) pick(rng *rand.Rand) int {
+	if len(t.codes) == 1 {
+		return t.codes[0]
+	}
+	r := rng.Float64() * t.cumulative[len(t.cumulative)-1]
+	for i, c := range t.cumulative {
+		if r < c {
+			return t.codes[i]
+		}
+	}
+	return t.codes[len(t.codes)-1]
+}
+
+// newCumulativeTable - 코드별 가중치로 누적 테이블 생성 (코드 순 정렬로 결정적 순서 보장)
+func newCumulativeTable(weights map[int]float64, kind string) (cumulativeTable, error) {
+	codes := make([]int, 0, len(weights))
+	for code, weight := range weights {
+		if weight > 0 {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return cumulativeTable{}, fmt.Errorf("%s 가중치 합이 0입니다", kind)
+	}
+	sort.Ints(codes)
+
+	table := cumulativeTable{codes: codes, cumulative: make([]float64, len(codes))}
+	total := 0.0
+	for i, code := range codes {
+		total += weights[code]
+		table.cumulative[i] = total
+	}
+	return table, nil
+}
+
+func facilityTable(weights map[string]float64) (cumulativeTable, error) {
+	if len(weights) == 0 {
+		weights = DefaultPriorityDistribution().FacilityWeights
+	}
+	byCode := make(map[int]float64, len(weights))
+	for name, weight := range weights {
+		code, err := ParseFacility(name)
+		if err != nil {
+			return cumulativeTable{}, err
+		}
+		byCode[code] += weight
+	}
+	return newCumulativeTable(byCode, "퍼실리티")
+}
+
+func severityTable(weights map[string]float64) (cumulativeTable, error) {
+	if len(weights) == 0 {
+		weights = DefaultPriorityDistribution().SeverityWeights
+	}
+	byCode := make(map[int]float64, len(weights))
+	for name, weight := range weights {
+		code, err := ParseSeverity(name)
+		if err != nil {
+			return cumulativeTable{}, err
+		}
+		byCode[code] += weight
+	}
+	return newCumulativeTable(byCode, "심각도")
+}
+
+// prioritySampler - 서비스에 맞는 PRI 선택기 (사전 계산 테이블)
+type prioritySampler struct {
+	priorities      [192]string // "<0>" ~ "<191>"
+	serviceFacility []int       // 서비스 인덱스별 퍼실리티 (-1 = 가중치 선택)
+	facilities      cumulativeTable
+	severities      [24]cumulativeTable
+
+	// 심각도 가중치를 지정했는지 (지정하면 카테고리 이벤트의 고정 심각도 대신 가중치 분포를 따름)
+	severityWeighted bool
+}
+
+// newPrioritySampler - 서비스 목록과 분포로 선택기 생성
+func newPrioritySampler(services []string, dist PriorityDistribution) (*prioritySampler, error) {
+	sampler := &prioritySampler{
+		priorities:       syslogPriorities,
+		serviceFacility:  make([]int, len(services)),
+		severityWeighted: len(dist.SeverityWeights) > 0 || len(dist.FacilitySeverityWeights) > 0,
+	}
+
+	var err error
+	sampler.facilities, err = facilityTable(dist.FacilityWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultSeverities, err := severityTable(dist.SeverityWeights)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sampler.severities {
+		sampler.severities[i] = defaultSeverities
+	}
+	for name, weights := range dist.FacilitySeverityWeights {
+		facility, err := ParseFacility(name)
+		if err != nil {
+			return nil, err
+		}
+		if sampler.severities[facility], err = severityTable(weights); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, service := range services {
+		sampler.serviceFacility[i] = -1
+		name, ok := dist.ServiceFacilities[service]
+		if !ok {
+			name, ok = defaultServiceFacilities[service]
+		}
+		if !ok {
+			continue
+		}
+		facility, err := ParseFacility(name)
+		if err != nil {
+			return nil, err
+		}
+		sampler.serviceFacility[i] = facility
+	}
+
+	return sampler, nil
+}
+
+// pick - 서비스 인덱스에 맞는 퍼실리티/심각도 선택 (호출자가 rng 락 보유)
+func (s *prioritySampler) pick(rng *rand.Rand, serviceIdx int) (facility, severity int) {
+	facility = s.facility(rng, serviceIdx)
+	severity = s.severity(rng, facility)
+	return facility, severity
+}
+
+// severity - 퍼실리티의 심각도 가중치로 심각도 선택 (호출자가 rng 락 보유)
+func (s *prioritySampler) severity(rng *rand.Rand, facility int) int {
+	return s.severities[facility].pick(rng)
+}
+
+// facility - 서비스 매핑 퍼실리티 (매핑이 없으면 가중치 선택, 호출자가 rng 락 보유)
+func (s *prioritySampler) facility(rng *rand.Rand, serviceIdx int) int {
+	if facility := s.serviceFacility[serviceIdx]; facility >= 0 {
+		return facility
+	}
+	return s.facilities.pick(rng)
+}
+
+// priority - PRI 문자열 반환 ("<N>")
+func (s *prioritySampler) priority(facility, severity int) string {
+	return s.priorities[facility*8+severity]
+}
@@ -0,0 +1,38 @@
+package generator
+
+// recordQueue - 한 이벤트를 구성하는 여러 줄을 미리 렌더링해 두고 한 줄씩 꺼내는 대기열
+//
+// auditd 다중 레코드나 DHCP DORA 흐름처럼 순서가 있는 줄 묶음을 Generate 호출마다
+// 한 줄씩 내보낼 때 사용한다. 버퍼는 이벤트 간에 재사용되며 호출자가 락을 보유한다.
+type recordQueue struct {
+	data   []byte
+	starts []int
+	cursor int
+}
+
+// empty - 꺼낼 줄이 남아 있지 않은지 여부
+func (q *recordQueue) empty() bool {
+	return q.cursor >= len(q.starts)
+}
+
+// reset - 새 이벤트 렌더링 시작 (남은 줄은 버림)
+func (q *recordQueue) reset() {
+	q.data = q.data[:0]
+	q.starts = q.starts[:0]
+	q.cursor = 0
+}
+
+// begin - 새 줄 시작 (이후 q.data에 이어 붙인 내용이 한 줄이 됨)
+func (q *recordQueue) begin() {
+	q.starts = append(q.starts, len(q.data))
+}
+
+// pop - 다음 줄 (반환값은 다음 reset 전까지만 유효)
+func (q *recordQueue) pop() []byte {
+	start, end := q.starts[q.cursor], len(q.data)
+	if q.cursor+1 < len(q.starts) {
+		end = q.starts[q.cursor+1]
+	}
+	q.cursor++
+	return q.data[start:end]
+}
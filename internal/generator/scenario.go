@@ -0,0 +1,754 @@
+package generator
+
+import (
+	"bytes"
+	"container/heap"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 시나리오 메시지에서 항상 쓸 수 있는 변수 (대상 호스트 이름/IP)
+const (
+	scenarioVarHost   = "host"
+	scenarioVarHostIP = "host_ip"
+)
+
+// 시나리오 간격/단계 지연/시작 비율/동시 실행 상한
+//
+// 시나리오 하나는 수십~수백 줄이지만 기한이 된 줄은 Generate마다 하나씩만 나가므로,
+// 비율 × 평균 줄 수가 1에 가까우면 진행 중인 시나리오가 쌓이고 줄이 기한보다 계속 늦어진다.
+const (
+	maxScenarioInterval = 7 * 24 * 60 * 60 // 초
+	maxScenarioDelay    = 24 * time.Hour
+	maxScenarioRatio    = 0.01
+	maxScenarioRuns     = 64 // 워커당
+)
+
+// builtinScenarioData - 내장 공격 시나리오 정의 (scenarios.json)
+//
+//go:embed scenarios.json
+var builtinScenarioData []byte
+
+// builtinScenarios - 내장 시나리오 컴파일 결과 (읽기 전용이므로 라이브러리끼리 공유)
+var builtinScenarios = sync.OnceValues(func() ([]*scenario, error) {
+	return parseScenarios("내장 시나리오", bytes.NewReader(builtinScenarioData))
+})
+
+// ScenarioOptions - 공격 시나리오 주입 옵션
+type ScenarioOptions struct {
+	// 시나리오 정의 JSON 파일 (빈 값 = 내장 라이브러리)
+	File string `json:"file,omitempty"`
+	// 로그 한 건마다 시나리오를 시작할 확률 (0~0.01, 예: 0.0001 = 0.01%)
+	Ratio float64 `json:"ratio,omitempty"`
+	// 전체 워커 기준 시나리오 시작 간격 (초, 0 = 간격 주입 끔)
+	Interval int `json:"interval,omitempty"`
+	// 주입할 시나리오 이름 (비어 있으면 전체)
+	Names []string `json:"names,omitempty"`
+}
+
+// Validate - 시나리오 옵션 검증
+func (o ScenarioOptions) Validate() error {
+	if o.Ratio < 0 || o.Ratio > maxScenarioRatio {
+		return fmt.Errorf("시나리오 비율은 0~%g 사이여야 합니다: %g", maxScenarioRatio, o.Ratio)
+	}
+	if o.Interval < 0 || o.Interval > maxScenarioInterval {
+		return fmt.Errorf("시나리오 간격은 0~%d초 사이여야 합니다: %d", maxScenarioInterval, o.Interval)
+	}
+	for _, name := range o.Names {
+		if name == "" {
+			return fmt.Errorf("빈 시나리오 이름")
+		}
+	}
+	return nil
+}
+
+// Enabled - 비율 또는 간격이 설정되어 주입이 켜졌는지
+func (o ScenarioOptions) Enabled() bool {
+	return o.Ratio > 0 || o.Interval > 0
+}
+
+// ScenarioCounter - 주입한 시나리오를 이름별로 세는 포맷터 (시나리오 주입 모드)
+type ScenarioCounter interface {
+	// ScenarioCounts - 이름별 누적 시작 수 (0인 이름 제외)
+	ScenarioCounts() map[string]int64
+}
+
+// scenarioFileSpec - 시나리오 정의 파일 최상위 ({"scenarios": [...]})
+type scenarioFileSpec struct {
+	Scenarios []scenarioSpec `json:"scenarios"`
+}
+
+// scenarioSpec - 시나리오 하나의 JSON 정의
+type scenarioSpec struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Techniques  []string           `json:"techniques,omitempty"` // MITRE ATT&CK 기법 ID
+	Weight      float64            `json:"weight,omitempty"`     // 선택 가중치 (기본 1)
+	Role        string             `json:"role,omitempty"`       // 대상 호스트 역할 (인벤토리 사용 시)
+	Vars        map[string]string  `json:"vars,omitempty"`       // 시작할 때 한 번 채우는 템플릿 변수
+	Steps       []scenarioStepSpec `json:"steps"`
+}
+
+// scenarioStepSpec - 시나리오 단계 (같은 메시지 템플릿을 repeat번 내보냄)
+type scenarioStepSpec struct {
+	Role     string    `json:"role,omitempty"` // 다른 역할의 호스트에서 기록 (예: 방화벽)
+	App      string    `json:"app"`
+	NoPID    bool      `json:"no_pid,omitempty"`
+	Facility string    `json:"facility,omitempty"` // 기본 user
+	Severity string    `json:"severity,omitempty"` // 기본 info
+	Message  string    `json:"message"`
+	Repeat   []int     `json:"repeat,omitempty"` // [횟수] 또는 [최소, 최대] (기본 1)
+	Delay    []float64 `json:"delay,omitempty"`  // 각 줄 앞의 대기 시간 [초] 또는 [최소, 최대] (기본 0)
+}
+
+// scenario - 컴파일된 시나리오
+type scenario struct {
+	name       string
+	techniques []string
+	weight     float64
+	role       string
+	vars       [][]templatePart // 인덱스 = 변수 인덱스 - 2 (0, 1은 host, host_ip)
+	steps      []scenarioStep
+}
+
+// scenarioStep - 컴파일된 시나리오 단계
+type scenarioStep struct {
+	role               string
+	app                string
+	noPID              bool
+	facility, severity int
+	message            []scenarioSegment
+	repeatMin          int
+	repeatMax          int
+	delayMin, delayMax time.Duration
+}
+
+// scenarioSegment - 메시지 조각 (템플릿 조각 또는 변수 참조)
+type scenarioSegment struct {
+	parts  []templatePart
+	varIdx int // -1이면 parts
+}
+
+// parseScenarios - 시나리오 JSON 컴파일 (source는 오류 메시지용 이름)
+func parseScenarios(source string, reader io.Reader) ([]*scenario, error) {
+	var file scenarioFileSpec
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("시나리오 파일 해석 실패 (%s): %v", source, err)
+	}
+	if len(file.Scenarios) == 0 {
+		return nil, fmt.Errorf("시나리오가 없습니다: %s", source)
+	}
+
+	scenarios := make([]*scenario, 0, len(file.Scenarios))
+	seen := make(map[string]bool)
+	for i := range file.Scenarios {
+		spec := &file.Scenarios[i]
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("%s: 시나리오 이름 중복: %s", source, spec.Name)
+		}
+		seen[spec.Name] = true
+
+		compiled, err := compileScenario(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 시나리오 %q: %v", source, spec.Name, err)
+		}
+		scenarios = append(scenarios, compiled)
+	}
+	return scenarios, nil
+}
+
+// compileScenario - 시나리오 정의 검증 및 컴파일
+func compileScenario(spec *scenarioSpec) (*scenario, error) {
+	if spec.Name == "" || strings.ContainsAny(spec.Name, " \t\r\n,=") {
+		return nil, fmt.Errorf("잘못된 시나리오 이름")
+	}
+	if spec.Weight < 0 {
+		return nil, fmt.Errorf("잘못된 가중치: %g", spec.Weight)
+	}
+	if spec.Role != "" && inventoryRoleIndex(spec.Role) < 0 {
+		return nil, fmt.Errorf("알 수 없는 역할: %s (사용 가능: %s)", spec.Role, strings.Join(ListRoles(), ", "))
+	}
+	if len(spec.Steps) == 0 {
+		return nil, fmt.Errorf("단계가 없습니다")
+	}
+
+	compiled := &scenario{
+		name:       spec.Name,
+		techniques: spec.Techniques,
+		weight:     spec.Weight,
+		role:       spec.Role,
+	}
+	if compiled.weight == 0 {
+		compiled.weight = 1
+	}
+
+	// 변수 인덱스: host, host_ip 다음에 이름 순
+	names := make([]string, 0, len(spec.Vars))
+	for name := range spec.Vars {
+		if name == scenarioVarHost || name == scenarioVarHostIP {
+			return nil, fmt.Errorf("예약된 변수 이름: %s", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	varIndex := map[string]int{scenarioVarHost: 0, scenarioVarHostIP: 1}
+	for _, name := range names {
+		parts, err := compileTemplate(spec.Vars[name])
+		if err != nil {
+			return nil, fmt.Errorf("변수 %s: %v", name, err)
+		}
+		varIndex[name] = 2 + len(compiled.vars)
+		compiled.vars = append(compiled.vars, parts)
+	}
+
+	for i := range spec.Steps {
+		step, err := compileScenarioStep(&spec.Steps[i], varIndex)
+		if err != nil {
+			return nil, fmt.Errorf("단계 %d: %v", i+1, err)
+		}
+		compiled.steps = append(compiled.steps, step)
+	}
+	return compiled, nil
+}
+
+// compileScenarioStep - 단계 정의 검증 및 컴파일
+func compileScenarioStep(spec *scenarioStepSpec, varIndex map[string]int) (scenarioStep, error) {
+	step := scenarioStep{role: spec.Role, app: spec.App, noPID: spec.NoPID}
+	if spec.Role != "" && inventoryRoleIndex(spec.Role) < 0 {
+		return step, fmt.Errorf("알 수 없는 역할: %s (사용 가능: %s)", spec.Role, strings.Join(ListRoles(), ", "))
+	}
+	if spec.App == "" || spec.App != syslogAppName(spec.App) || strings.ContainsAny(spec.App, "[]:") {
+		return step, fmt.Errorf("잘못된 app: %q", spec.App)
+	}
+
+	facility, severity := spec.Facility, spec.Severity
+	if facility == "" {
+		facility = "user"
+	}
+	if severity == "" {
+		severity = "info"
+	}
+	var err error
+	if step.facility, err = ParseFacility(facility); err != nil {
+		return step, err
+	}
+	if step.severity, err = ParseSeverity(severity); err != nil {
+		return step, err
+	}
+
+	if spec.Message == "" {
+		return step, fmt.Errorf("메시지가 없습니다")
+	}
+	if step.message, err = compileScenarioMessage(spec.Message, varIndex); err != nil {
+		return step, err
+	}
+
+	switch len(spec.Repeat) {
+	case 0:
+		step.repeatMin, step.repeatMax = 1, 1
+	case 1:
+		step.repeatMin, step.repeatMax = spec.Repeat[0], spec.Repeat[0]
+	case 2:
+		step.repeatMin, step.repeatMax = spec.Repeat[0], spec.Repeat[1]
+	default:
+		return step, fmt.Errorf("repeat는 [횟수] 또는 [최소, 최대]여야 합니다")
+	}
+	if step.repeatMin < 1 || step.repeatMax < step.repeatMin {
+		return step, fmt.Errorf("잘못된 repeat 범위: %v", spec.Repeat)
+	}
+
+	var delayMin, delayMax float64
+	switch len(spec.Delay) {
+	case 0:
+	case 1:
+		delayMin, delayMax = spec.Delay[0], spec.Delay[0]
+	case 2:
+		delayMin, delayMax = spec.Delay[0], spec.Delay[1]
+	default:
+		return step, fmt.Errorf("delay는 [초] 또는 [최소, 최대]여야 합니다")
+	}
+	step.delayMin = time.Duration(delayMin * float64(time.Second))
+	step.delayMax = time.Duration(delayMax * float64(time.Second))
+	if step.delayMin < 0 || step.delayMax < step.delayMin || step.delayMax > maxScenarioDelay {
+		return step, fmt.Errorf("잘못된 delay 범위: %v", spec.Delay)
+	}
+	return step, nil
+}
+
+// compileScenarioMessage - {{var 이름}}을 변수 참조로, 나머지는 일반 템플릿으로 컴파일
+func compileScenarioMessage(text string, varIndex map[string]int) ([]scenarioSegment, error) {
+	var segments []scenarioSegment
+	chunk, pos := 0, 0
+	for {
+		start := strings.Index(text[pos:], "{{")
+		if start < 0 {
+			break
+		}
+		start += pos
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			break // 닫히지 않은 자리표시자는 compileTemplate이 보고
+		}
+		end += start + 2
+
+		args, err := splitTemplateArgs(text[start+2 : end-2])
+		if err != nil {
+			return nil, err
+		}
+		pos = end
+		if len(args) == 0 || args[0] != "var" {
+			continue
+		}
+		if len(args) != 2 {
+			return nil, fmt.Errorf("var는 변수 이름 하나가 필요합니다")
+		}
+		idx, ok := varIndex[args[1]]
+		if !ok {
+			return nil, fmt.Errorf("정의되지 않은 변수: %s", args[1])
+		}
+		if segments, err = appendScenarioChunk(segments, text[chunk:start]); err != nil {
+			return nil, err
+		}
+		segments = append(segments, scenarioSegment{varIdx: idx})
+		chunk = end
+	}
+	return appendScenarioChunk(segments, text[chunk:])
+}
+
+// appendScenarioChunk - 변수 참조 사이의 템플릿 조각 추가
+func appendScenarioChunk(segments []scenarioSegment, text string) ([]scenarioSegment, error) {
+	if text == "" {
+		return segments, nil
+	}
+	parts, err := compileTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	return append(segments, scenarioSegment{parts: parts, varIdx: -1}), nil
+}
+
+// BuiltinScenarioNames - 내장 시나리오 이름 목록
+func BuiltinScenarioNames() []string {
+	scenarios, err := builtinScenarios()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(scenarios))
+	for i, s := range scenarios {
+		names[i] = s.name
+	}
+	return names
+}
+
+// ScenarioLibrary - 주입할 시나리오 모음과 전체 워커가 공유하는 간격 일정
+//
+// 모든 워커의 포맷터가 같은 라이브러리를 공유하므로 간격 주입은 워커 수와 관계없이
+// Interval마다 한 번만 일어난다. 비율 주입은 포맷터마다 로그 건수 기준으로 따로 뽑는다.
+type ScenarioLibrary struct {
+	source    string
+	scenarios []*scenario
+	weights   aliasTable
+	ratio     float64
+	interval  int64        // 나노초 (0 = 간격 주입 끔)
+	next      atomic.Int64 // 다음 간격 주입 시각 (UnixNano, 0 = 첫 호출에서 바로 주입)
+}
+
+// NewScenarioLibrary - 옵션으로 시나리오 라이브러리 생성 (파일이 없으면 내장 라이브러리)
+func NewScenarioLibrary(options ScenarioOptions) (*ScenarioLibrary, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	library := &ScenarioLibrary{
+		source:   options.File,
+		ratio:    options.Ratio,
+		interval: int64(options.Interval) * int64(time.Second),
+	}
+	var all []*scenario
+	var err error
+	if options.File == "" {
+		library.source = "내장 시나리오"
+		all, err = builtinScenarios()
+	} else {
+		all, err = loadScenarioFile(options.File)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	library.scenarios = all
+	if len(options.Names) > 0 {
+		library.scenarios = nil
+		for _, name := range options.Names {
+			found := false
+			for _, s := range all {
+				if s.name == name {
+					library.scenarios = append(library.scenarios, s)
+					found = true
+					break
+				}
+			}
+			if !found {
+				names := make([]string, len(all))
+				for i, s := range all {
+					names[i] = s.name
+				}
+				return nil, fmt.Errorf("알 수 없는 시나리오: %s (사용 가능: %s)", name, strings.Join(names, ", "))
+			}
+		}
+	}
+
+	weights := make([]float64, len(library.scenarios))
+	for i, s := range library.scenarios {
+		weights[i] = s.weight
+	}
+	if library.weights, err = newAliasTable(weights, "시나리오"); err != nil {
+		return nil, err
+	}
+	return library, nil
+}
+
+// loadScenarioFile - 시나리오 정의 파일 읽기 및 컴파일
+func loadScenarioFile(path string) ([]*scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("시나리오 파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+	return parseScenarios(path, file)
+}
+
+// Source - 시나리오를 읽은 파일 경로 (내장 라이브러리면 "내장 시나리오")
+func (l *ScenarioLibrary) Source() string {
+	return l.source
+}
+
+// Names - 주입 대상 시나리오 이름
+func (l *ScenarioLibrary) Names() []string {
+	names := make([]string, len(l.scenarios))
+	for i, s := range l.scenarios {
+		names[i] = s.name
+	}
+	return names
+}
+
+// claim - 간격 주입 차례면 true (여러 워커가 동시에 만나도 한 번만 true)
+func (l *ScenarioLibrary) claim(now int64) bool {
+	if l.interval == 0 {
+		return false
+	}
+	next := l.next.Load()
+	if now < next {
+		return false
+	}
+	return l.next.CompareAndSwap(next, now+l.interval)
+}
+
+// scenarioRun - 진행 중인 시나리오 인스턴스
+type scenarioRun struct {
+	scenario  *scenario
+	due       int64 // 다음 줄 시각 (UnixNano)
+	step      int
+	remaining int // 현재 단계에서 남은 줄 수
+	hostIdx   int
+	roleHosts map[string]int    // 단계 역할 → 호스트 인덱스 (처음 쓸 때 선택)
+	pids      map[string]string // app → PID (인벤토리가 없을 때)
+	vars      []string
+	instance  string // 정답 기록용 인스턴스 ID (추적하지 않으면 빈 값)
+}
+
+// scenarioSerial - 프로세스 안에서 유일한 시나리오 인스턴스 번호
+var scenarioSerial atomic.Uint64
+
+// scenarioHeap - 다음 줄 시각 순 인스턴스 힙 (container/heap)
+type scenarioHeap []*scenarioRun
+
+func (h scenarioHeap) Len() int            { return len(h) }
+func (h scenarioHeap) Less(i, j int) bool  { return h[i].due < h[j].due }
+func (h scenarioHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *scenarioHeap) Push(x interface{}) { *h = append(*h, x.(*scenarioRun)) }
+func (h *scenarioHeap) Pop() interface{} {
+	old := *h
+	run := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return run
+}
+
+// scenarioFormatter - 다른 포맷터의 출력 사이에 공격 시나리오 줄을 끼워 넣는 래퍼
+//
+// 시작한 시나리오는 단계 지연에 맞춰 실제 시각에 진행되며, 기한이 된 줄이 있으면
+// 감싼 포맷터 대신 그 줄을 내보내므로 전체 EPS는 그대로다. 시나리오 줄은 형식과
+// 관계없이 syslog 헤더를 붙이며 인벤토리가 있으면 역할에 맞는 호스트에서 기록한다.
+type scenarioFormatter struct {
+	inner     LogFormatter
+	library   *ScenarioLibrary
+	header    *syslogWrapper
+	hostIPs   []string         // header.hostnames와 1:1
+	roleHosts map[string][]int // 역할 → 호스트 인덱스 (인벤토리가 없으면 nil)
+	runs      scenarioHeap
+	counts    []atomic.Int64
+
+	// 정답 기록용 레이블 (track이 false면 보관하지 않음)
+	track  bool
+	labels injectionLabels
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newScenarioFormatter - 시나리오 주입 래퍼 생성
+func newScenarioFormatter(inner LogFormatter, options GeneratorOptions) (*scenarioFormatter, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &scenarioFormatter{
+		inner:   inner,
+		library: options.Scenarios,
+		header:  header,
+		counts:  make([]atomic.Int64, len(options.Scenarios.scenarios)),
+		track:   options.TrackInjections,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	// 시나리오는 형식과 관계없이 인벤토리 전체에서 역할로 호스트를 고름
+	if options.Inventory != nil {
+		hosts := options.Inventory.Hosts()
+		header.hosts = hosts
+		header.hostnames = make([]string, len(hosts))
+		f.hostIPs = make([]string, len(hosts))
+		f.roleHosts = make(map[string][]int)
+		for i, host := range hosts {
+			header.hostnames[i] = host.Name
+			f.hostIPs[i] = host.IP
+			f.roleHosts[host.Role] = append(f.roleHosts[host.Role], i)
+		}
+	} else {
+		f.hostIPs = make([]string, len(header.hostnames))
+		for i := range f.hostIPs {
+			f.hostIPs[i] = string(appendRandomIPv4(nil, f.rng, 10))
+		}
+	}
+	return f, nil
+}
+
+// Name - LogFormatter 구현 (감싼 형식 이름)
+func (f *scenarioFormatter) Name() string {
+	return f.inner.Name()
+}
+
+// Generate - LogFormatter 구현 (기한이 된 시나리오 줄이 있으면 그 줄)
+func (f *scenarioFormatter) Generate() []byte {
+	f.rngMutex.Lock()
+	message := f.next()
+	f.rngMutex.Unlock()
+
+	if message != nil {
+		return message
+	}
+	return f.inner.Generate()
+}
+
+// next - 새 시나리오 시작 여부를 정하고 기한이 된 줄 생성 (없으면 nil, 호출자가 rng 락 보유)
+func (f *scenarioFormatter) next() []byte {
+	var now int64
+	if len(f.runs) > 0 || f.library.interval > 0 {
+		now = time.Now().UnixNano()
+	}
+	if f.library.claim(now) || (f.library.ratio > 0 && f.rng.Float64() < f.library.ratio && f.canStart(now)) {
+		if now == 0 {
+			now = time.Now().UnixNano()
+		}
+		f.start(now)
+	}
+	if len(f.runs) == 0 || f.runs[0].due > now {
+		return nil
+	}
+
+	run := f.runs[0]
+	message := f.render(run)
+	if f.track {
+		f.labels.add(message, &Injection{
+			Kind:       InjectionScenario,
+			Name:       run.scenario.name,
+			Instance:   run.instance,
+			Step:       run.step + 1,
+			Techniques: run.scenario.techniques,
+		})
+	}
+	if f.advance(run) {
+		heap.Fix(&f.runs, 0)
+	} else {
+		heap.Pop(&f.runs)
+	}
+	return message
+}
+
+// canStart - 비율 주입으로 새 시나리오를 시작할 수 있는지 (호출자가 rng 락 보유)
+//
+// 기한이 지난 줄이 밀려 있거나 진행 중인 시나리오가 상한이면 시작하지 않는다.
+// 간격 주입은 전체 워커에서 드물게 하나씩이므로 제한하지 않는다.
+func (f *scenarioFormatter) canStart(now int64) bool {
+	if len(f.runs) == 0 {
+		return true
+	}
+	return len(f.runs) < maxScenarioRuns && f.runs[0].due > now
+}
+
+// start - 시나리오 하나 시작 (호출자가 rng 락 보유)
+func (f *scenarioFormatter) start(now int64) {
+	idx := f.library.weights.pick(f.rng)
+	s := f.library.scenarios[idx]
+	f.counts[idx].Add(1)
+
+	run := &scenarioRun{
+		scenario: s,
+		due:      now,
+		hostIdx:  f.pickHost(s.role),
+		vars:     make([]string, 2+len(s.vars)),
+	}
+	if f.track {
+		run.instance = s.name + "-" + strconv.FormatUint(scenarioSerial.Add(1), 10)
+	}
+	run.vars[0] = f.header.hostnames[run.hostIdx]
+	run.vars[1] = f.hostIPs[run.hostIdx]
+	for i, parts := range s.vars {
+		var buffer []byte
+		for _, part := range parts {
+			buffer = part(buffer, f.rng)
+		}
+		run.vars[2+i] = string(buffer)
+	}
+
+	step := &s.steps[0]
+	run.remaining = step.repeatMin + f.rng.Intn(step.repeatMax-step.repeatMin+1)
+	run.due += int64(f.delay(step))
+	heap.Push(&f.runs, run)
+}
+
+// advance - 줄을 내보낸 뒤 다음 줄 시각 결정 (시나리오가 끝나면 false, 호출자가 rng 락 보유)
+func (f *scenarioFormatter) advance(run *scenarioRun) bool {
+	steps := run.scenario.steps
+	run.remaining--
+	if run.remaining == 0 {
+		run.step++
+		if run.step == len(steps) {
+			return false
+		}
+		step := &steps[run.step]
+		run.remaining = step.repeatMin + f.rng.Intn(step.repeatMax-step.repeatMin+1)
+	}
+	run.due += int64(f.delay(&steps[run.step]))
+	return true
+}
+
+// delay - 단계 줄 앞의 대기 시간 (균등 분포, 호출자가 rng 락 보유)
+func (f *scenarioFormatter) delay(step *scenarioStep) time.Duration {
+	if step.delayMax == step.delayMin {
+		return step.delayMin
+	}
+	return step.delayMin + time.Duration(f.rng.Int63n(int64(step.delayMax-step.delayMin)))
+}
+
+// pickHost - 역할의 호스트 선택 (인벤토리가 없거나 해당 역할 호스트가 없으면 아무 호스트, 호출자가 rng 락 보유)
+func (f *scenarioFormatter) pickHost(role string) int {
+	if hosts := f.roleHosts[role]; len(hosts) > 0 {
+		return hosts[f.rng.Intn(len(hosts))]
+	}
+	return f.header.pickHost(f.rng)
+}
+
+// render - 현재 단계의 줄 생성 (호출자가 rng 락 보유)
+func (f *scenarioFormatter) render(run *scenarioRun) []byte {
+	step := &run.scenario.steps[run.step]
+
+	hostIdx := run.hostIdx
+	if step.role != "" && step.role != run.scenario.role && f.roleHosts != nil {
+		idx, ok := run.roleHosts[step.role]
+		if !ok {
+			idx = f.pickHost(step.role)
+			if run.roleHosts == nil {
+				run.roleHosts = make(map[string]int)
+			}
+			run.roleHosts[step.role] = idx
+		}
+		hostIdx = idx
+	}
+
+	pid := ""
+	if !step.noPID {
+		pid = f.pid(run, hostIdx, step.app)
+	}
+
+	buffer := getBuffer()
+	buffer = f.header.appendHeader(buffer, step.facility, step.severity, hostIdx, step.app, pid, "")
+	for _, segment := range step.message {
+		if segment.varIdx >= 0 {
+			buffer = append(buffer, run.vars[segment.varIdx]...)
+			continue
+		}
+		for _, part := range segment.parts {
+			buffer = part(buffer, f.rng)
+		}
+	}
+	return finishBuffer(buffer)
+}
+
+// pid - 단계 app의 PID (인벤토리 호스트는 서비스 PID, 아니면 인스턴스 안에서 고정, 호출자가 rng 락 보유)
+func (f *scenarioFormatter) pid(run *scenarioRun, hostIdx int, app string) string {
+	if f.header.hosts != nil {
+		return f.header.procID(f.rng, hostIdx, app, "")
+	}
+	pid, ok := run.pids[app]
+	if !ok {
+		pid = strconv.Itoa(1000 + f.rng.Intn(60000))
+		if run.pids == nil {
+			run.pids = make(map[string]string)
+		}
+		run.pids[app] = pid
+	}
+	return pid
+}
+
+// ScenarioCounts - ScenarioCounter 구현
+func (f *scenarioFormatter) ScenarioCounts() map[string]int64 {
+	counts := make(map[string]int64)
+	for i := range f.counts {
+		if n := f.counts[i].Load(); n > 0 {
+			counts[f.library.scenarios[i].name] = n
+		}
+	}
+	return counts
+}
+
+// TakeInjection - InjectionSource 구현 (시나리오 줄이 아니면 감싼 퍼징 래퍼에서 찾음)
+func (f *scenarioFormatter) TakeInjection(message []byte) *Injection {
+	if label := f.labels.take(message); label != nil {
+		return label
+	}
+	if source, ok := f.inner.(InjectionSource); ok {
+		return source.TakeInjection(message)
+	}
+	return nil
+}
+
+// MutationCounts - MutationCounter 구현 (감싼 퍼징 래퍼의 값, 퍼징 모드가 아니면 nil)
+func (f *scenarioFormatter) MutationCounts() map[string]int64 {
+	if counter, ok := f.inner.(MutationCounter); ok {
+		return counter.MutationCounts()
+	}
+	return nil
+}
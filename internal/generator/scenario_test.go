@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScenarioOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ScenarioOptions
+		ok      bool
+	}{
+		{"끔", ScenarioOptions{}, true},
+		{"비율", ScenarioOptions{Ratio: 0.0001}, true},
+		{"비율 상한", ScenarioOptions{Ratio: maxScenarioRatio}, true},
+		{"비율 상한 초과", ScenarioOptions{Ratio: 0.02}, false},
+		{"비율 1", ScenarioOptions{Ratio: 1}, false},
+		{"음수 비율", ScenarioOptions{Ratio: -0.001}, false},
+		{"간격", ScenarioOptions{Interval: 300}, true},
+		{"간격 상한 초과", ScenarioOptions{Interval: maxScenarioInterval + 1}, false},
+		{"빈 이름", ScenarioOptions{Ratio: 0.001, Names: []string{""}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err == nil) != tt.ok {
+				t.Fatalf("Validate() = %v, 성공 기대 %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+// newTestScenarioFormatter - 시나리오 주입 포맷터 생성 (syslog 배경 로그)
+func newTestScenarioFormatter(t *testing.T, scenario ScenarioOptions) *scenarioFormatter {
+	t.Helper()
+	library, err := NewScenarioLibrary(scenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := DefaultGeneratorOptions()
+	options.Scenarios = library
+	options.TrackInjections = true
+	formatter, err := NewFormatter("syslog", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return formatter.(*scenarioFormatter)
+}
+
+// 내장 시나리오는 단계 사이에 초 단위 지연이 있어 빠르게 생성하면 줄이 기한 전에 쌓이지 않고
+// 진행 중인 시나리오만 늘어나므로, 상한에서 멈춰야 한다.
+func TestScenarioRunsBounded(t *testing.T) {
+	f := newTestScenarioFormatter(t, ScenarioOptions{Ratio: maxScenarioRatio})
+
+	for i := 0; i < 200000; i++ {
+		message := f.Generate()
+		f.TakeInjection(message)
+	}
+	if n := len(f.runs); n > maxScenarioRuns {
+		t.Fatalf("진행 중인 시나리오 %d개, 상한 %d개", n, maxScenarioRuns)
+	}
+	started := int64(0)
+	for _, count := range f.ScenarioCounts() {
+		started += count
+	}
+	if started == 0 {
+		t.Fatal("시나리오가 하나도 시작되지 않음")
+	}
+}
+
+func TestScenarioStepOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenarios.json")
+	data := `{"scenarios": [{
+		"name": "order",
+		"techniques": ["T0000"],
+		"steps": [
+			{"app": "a", "message": "step one", "repeat": [2]},
+			{"app": "b", "message": "step two"},
+			{"app": "c", "no_pid": true, "message": "step three"}
+		]
+	}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f := newTestScenarioFormatter(t, ScenarioOptions{File: path, Ratio: maxScenarioRatio})
+
+	want := []struct {
+		step    int
+		message string
+	}{{1, "a["}, {1, "a["}, {2, "b["}, {3, "c: step three"}}
+	texts := []string{"step one", "step one", "step two", "step three"}
+
+	// 지연이 없으므로 한 시나리오의 줄은 시작한 Generate부터 연속으로 나옴
+	steps := map[string]int{}
+	var order []string
+	for i := 0; i < 50000; i++ {
+		message := f.Generate()
+		label := f.TakeInjection(message)
+		if label == nil {
+			continue
+		}
+		if label.Kind != InjectionScenario || label.Name != "order" || len(label.Techniques) != 1 {
+			t.Fatalf("잘못된 레이블: %+v", label)
+		}
+		n := steps[label.Instance]
+		if n == 0 {
+			order = append(order, label.Instance)
+		}
+		if n >= len(want) {
+			t.Fatalf("%s: 단계보다 많은 줄", label.Instance)
+		}
+		if label.Step != want[n].step {
+			t.Fatalf("%s: %d번째 줄 단계 %d, 기대값 %d", label.Instance, n+1, label.Step, want[n].step)
+		}
+		text := string(message)
+		if !strings.Contains(text, want[n].message) || !strings.HasSuffix(text, texts[n]) {
+			t.Fatalf("%s: %d번째 줄 %q", label.Instance, n+1, text)
+		}
+		steps[label.Instance] = n + 1
+	}
+
+	if len(order) == 0 {
+		t.Fatal("시나리오가 하나도 시작되지 않음")
+	}
+	// 마지막으로 시작한 인스턴스 외에는 모두 끝까지 진행되어야 함
+	for _, instance := range order[:len(order)-1] {
+		if steps[instance] != len(want) {
+			t.Fatalf("%s: %d줄만 나옴", instance, steps[instance])
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package generator
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 세션 엔진 기본값
+const (
+	defaultSessionCount    = 1000
+	defaultSessionDuration = 300 // 초
+	maxSessionCount        = 1000000
+	maxSessionDuration     = 7 * 24 * 3600
+)
+
+// 세션 로그 퍼실리티 (sshd/sudo는 authpriv, systemd-logind는 auth)
+const (
+	sessionAuthFacility     = 4
+	sessionAuthPrivFacility = 10
+)
+
+// SessionOptions - 상태 기반 로그인 세션 옵션
+type SessionOptions struct {
+	// 포맷터마다 동시에 진행하는 세션 수 (0 = 1000)
+	Count int `json:"count,omitempty"`
+	// 세션 평균 지속 시간 (초, 0 = 300)
+	Duration int `json:"duration,omitempty"`
+}
+
+// Validate - 세션 옵션 검증
+func (o SessionOptions) Validate() error {
+	if o.Count < 0 || o.Count > maxSessionCount {
+		return fmt.Errorf("동시 세션 수는 1~%d 사이여야 합니다: %d", maxSessionCount, o.Count)
+	}
+	if o.Duration < 0 || o.Duration > maxSessionDuration {
+		return fmt.Errorf("세션 지속 시간은 1~%d초 사이여야 합니다: %d", maxSessionDuration, o.Duration)
+	}
+	return nil
+}
+
+// sessionState - 세션 상태 (각 상태에서 로그 한 줄을 내보내고 다음 상태로 이동)
+type sessionState int
+
+const (
+	sessionAccepted      sessionState = iota // sshd: Accepted ... for USER from IP
+	sessionPAMOpened                         // sshd: pam_unix(sshd:session): session opened
+	sessionLogindNew                         // systemd-logind: New session N of user USER.
+	sessionSudoCommand                       // sudo: USER : TTY=... ; COMMAND=...
+	sessionSudoOpened                        // sudo: pam_unix(sudo:session): session opened for user root
+	sessionSudoClosed                        // sudo: pam_unix(sudo:session): session closed for user root
+	sessionDisconnect                        // sshd: Received disconnect from IP
+	sessionDisconnected                      // sshd: Disconnected from user USER IP
+	sessionPAMClosed                         // sshd: pam_unix(sshd:session): session closed
+	sessionLogindLogout                      // systemd-logind: Session N logged out.
+	sessionLogindRemoved                     // systemd-logind: Removed session N.
+)
+
+// 세션 사용자가 sudo로 실행하는 명령
+var sessionSudoCommands = []string{
+	"/usr/bin/systemctl restart nginx",
+	"/usr/bin/systemctl status mysqld",
+	"/usr/bin/journalctl -u kubelet --since today",
+	"/usr/bin/apt-get update",
+	"/usr/bin/tail -n 200 /var/log/secure",
+	"/usr/bin/cat /etc/shadow",
+	"/usr/sbin/useradd -m tempuser",
+	"/usr/bin/docker ps -a",
+	"/bin/bash",
+	"/usr/bin/vim /etc/ssh/sshd_config",
+}
+
+// 인증 방식 (Accepted 뒤 표기)
+var sessionAuthMethods = []string{"password", "publickey", "publickey", "publickey"}
+
+// 외부 무차별 대입에서 시도하는 계정 (인증 전 잡음)
+var sessionInvalidUsers = []string{"test", "guest", "oracle", "ftpuser", "pi", "user1", "support", "ubnt", "hadoop", "git"}
+
+// 로그인 세션 번호 (systemd-logind, 모든 세션 포맷터에서 고유)
+var sessionSerial atomic.Uint64
+
+// session - 진행 중인 로그인 세션 하나 (같은 사용자/호스트/sshd PID로 묶인 이벤트)
+type session struct {
+	state    sessionState
+	due      int64 // 다음 이벤트 시각 (UnixNano)
+	end      int64 // 로그아웃 시작 시각 (UnixNano)
+	heapIdx  int
+	hostIdx  int
+	userIdx  int
+	srcIP    string
+	srcPort  string
+	method   string
+	sshdPID  string
+	tty      string
+	id       string // logind 세션 번호
+	commands int    // 남은 sudo 명령 수
+	command  int    // 현재 sudo 명령 인덱스
+	sudoPID  string
+}
+
+// sessionHeap - 다음 이벤트 시각 순 세션 힙 (container/heap)
+type sessionHeap []*session
+
+func (h sessionHeap) Len() int           { return len(h) }
+func (h sessionHeap) Less(i, j int) bool { return h[i].due < h[j].due }
+func (h sessionHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIdx = i
+	h[j].heapIdx = j
+}
+func (h *sessionHeap) Push(x interface{}) {
+	s := x.(*session)
+	s.heapIdx = len(*h)
+	*h = append(*h, s)
+}
+func (h *sessionHeap) Pop() interface{} {
+	old := *h
+	s := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return s
+}
+
+// SessionGenerator - 로그인 → 활동 → 로그아웃 상태 기계를 따르는 세션 로그 생성기
+//
+// 동시 세션 풀을 유지하며 각 세션은 sshd 인증, PAM 세션 열림, logind 등록,
+// sudo 명령, 연결 종료, PAM 세션 닫힘 순서로 진행한다. 한 세션의 줄은 모두 같은
+// 호스트/사용자/sshd PID를 사용하고 실제 시각에 맞춰 세션 지속 시간에 걸쳐 나온다.
+// 기한이 된 세션 이벤트가 없으면 외부 무차별 대입(인증 전) 잡음을 내보낸다.
+type SessionGenerator struct {
+	header     *syslogWrapper
+	sessions   sessionHeap
+	duration   time.Duration
+	srcIPs     []string
+	noiseIPs   []string
+	logindPIDs []string // 호스트별 systemd-logind PID (인벤토리가 없을 때)
+	clock      *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newSessionGenerator - 레지스트리용 팩토리 ("session")
+func newSessionGenerator(options GeneratorOptions) (LogFormatter, error) {
+	if err := options.Session.Validate(); err != nil {
+		return nil, err
+	}
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	count := options.Session.Count
+	if count == 0 {
+		count = defaultSessionCount
+	}
+	seconds := options.Session.Duration
+	if seconds == 0 {
+		seconds = defaultSessionDuration
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &SessionGenerator{
+		header:     header,
+		sessions:   make(sessionHeap, 0, count),
+		duration:   time.Duration(seconds) * time.Second,
+		srcIPs:     newIPPool(rng, []string{"10.1", "10.2", "192.168"}, 256),
+		noiseIPs:   newIPPool(rng, []string{"45.134", "103.77", "185.220", "61.177", "218.92"}, 1024),
+		logindPIDs: make([]string, len(header.hostnames)),
+		clock:      getClock(),
+		rng:        rng,
+	}
+	for i := range gen.logindPIDs {
+		gen.logindPIDs[i] = strconv.Itoa(600 + rng.Intn(400))
+	}
+
+	// 시작 시각을 평균 지속 시간(최대 1분)에 흩어 로그인이 한꺼번에 몰리지 않게 함
+	now := gen.clock.Now().UnixNano()
+	spread := min(gen.duration, time.Minute)
+	for i := 0; i < count; i++ {
+		s := &session{}
+		gen.start(s, now+rng.Int63n(int64(spread)))
+		heap.Push(&gen.sessions, s)
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("session", newSessionGenerator)
+}
+
+// Name - LogFormatter 구현
+func (g *SessionGenerator) Name() string {
+	return "session"
+}
+
+// start - 새 세션 시작 (호출자가 rng 락 보유)
+func (g *SessionGenerator) start(s *session, at int64) {
+	// 지속 시간은 지수 분포 (최소 1초, 평균의 10배에서 자름)
+	duration := time.Duration(g.rng.ExpFloat64() * float64(g.duration))
+	duration = max(time.Second, min(duration, 10*g.duration))
+
+	*s = session{
+		state:   sessionAccepted,
+		due:     at,
+		end:     at + int64(duration),
+		heapIdx: s.heapIdx,
+		hostIdx: g.header.pickHost(g.rng),
+		userIdx: g.rng.Intn(len(commonUsernames)),
+		srcIP:   g.srcIPs[skewedIndex(g.rng, len(g.srcIPs))],
+		srcPort: strconv.Itoa(32768 + g.rng.Intn(28232)),
+		method:  sessionAuthMethods[g.rng.Intn(len(sessionAuthMethods))],
+		tty:     "pts/" + strconv.Itoa(g.rng.Intn(8)),
+		id:      strconv.FormatUint(sessionSerial.Add(1), 10),
+	}
+	s.sshdPID = g.newPID(s.hostIdx)
+	if commonUsernames[s.userIdx] != "root" {
+		s.commands = g.rng.Intn(4)
+	}
+}
+
+// newPID - 새로 포크된 프로세스 PID (인벤토리 호스트는 호스트 PID 순서를 따름, 호출자가 rng 락 보유)
+func (g *SessionGenerator) newPID(hostIdx int) string {
+	if g.header.hosts != nil {
+		return *g.header.hosts[hostIdx].allocatePID(g.rng)
+	}
+	return strconv.Itoa(1000 + g.rng.Intn(60000))
+}
+
+// advance - 현재 상태의 줄을 내보낸 뒤 다음 상태와 시각 결정 (끝나면 false, 호출자가 rng 락 보유)
+func (g *SessionGenerator) advance(s *session) bool {
+	gap := func(minMs, maxMs int) int64 {
+		return int64(logUniform(g.rng, minMs, maxMs)) * int64(time.Millisecond)
+	}
+
+	switch s.state {
+	case sessionAccepted:
+		s.state, s.due = sessionPAMOpened, s.due+gap(5, 50)
+	case sessionPAMOpened:
+		s.state, s.due = sessionLogindNew, s.due+gap(10, 100)
+	case sessionLogindNew, sessionSudoClosed:
+		if s.commands == 0 {
+			s.state, s.due = sessionDisconnect, max(s.end, s.due+gap(10, 1000))
+			break
+		}
+		// 남은 명령을 로그아웃 전까지 고르게 흩음
+		slot := (s.end - s.due) / int64(s.commands+1)
+		s.state = sessionSudoCommand
+		s.due += int64(float64(max(slot, 0)) * (0.5 + g.rng.Float64()))
+		s.command = g.rng.Intn(len(sessionSudoCommands))
+		s.sudoPID = g.newPID(s.hostIdx)
+		s.commands--
+	case sessionSudoCommand:
+		s.state, s.due = sessionSudoOpened, s.due+gap(2, 20)
+	case sessionSudoOpened:
+		// 명령 실행 시간 (로그아웃 시각을 넘지 않음)
+		s.state, s.due = sessionSudoClosed, s.due+min(gap(50, 30000), max(s.end-s.due, int64(50*time.Millisecond)))
+	case sessionDisconnect:
+		s.state, s.due = sessionDisconnected, s.due+gap(1, 3)
+	case sessionDisconnected:
+		s.state, s.due = sessionPAMClosed, s.due+gap(1, 5)
+	case sessionPAMClosed:
+		s.state, s.due = sessionLogindLogout, s.due+gap(1, 10)
+	case sessionLogindLogout:
+		s.state, s.due = sessionLogindRemoved, s.due+gap(10, 500)
+	default:
+		return false
+	}
+	return true
+}
+
+// Generate - LogFormatter 구현
+func (g *SessionGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	now := g.clock.Now().UnixNano()
+	if len(g.sessions) == 0 || g.sessions[0].due > now {
+		return finishBuffer(g.appendNoise(buffer))
+	}
+
+	s := g.sessions[0]
+	buffer = g.appendSessionEvent(buffer, s)
+	if !g.advance(s) {
+		// 끝난 세션 자리에 잠시 후 새 세션 시작 (동시 세션 수 유지)
+		g.start(s, now+g.rng.Int63n(int64(5*time.Second)))
+	}
+	heap.Fix(&g.sessions, 0)
+	return finishBuffer(buffer)
+}
+
+// appendSessionEvent - 세션의 현재 상태 줄 추가
+func (g *SessionGenerator) appendSessionEvent(buffer []byte, s *session) []byte {
+	user := commonUsernames[s.userIdx]
+	uid := "0"
+	if user != "root" {
+		uid = strconv.Itoa(1000 + s.userIdx)
+	}
+
+	switch s.state {
+	case sessionAccepted:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sshd", s.sshdPID, "SSH")
+		buffer = append(buffer, "Accepted "...)
+		buffer = append(buffer, s.method...)
+		buffer = append(buffer, " for "...)
+		buffer = append(buffer, user...)
+		buffer = g.appendFromPort(buffer, s.srcIP, s.srcPort)
+		buffer = append(buffer, " ssh2"...)
+		if s.method == "publickey" {
+			buffer = append(buffer, ": ED25519 SHA256:"...)
+			buffer = appendLowerHex(buffer, g.rng, 43)
+		}
+	case sessionPAMOpened:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sshd", s.sshdPID, "SSH")
+		buffer = append(buffer, "pam_unix(sshd:session): session opened for user "...)
+		buffer = append(buffer, user...)
+		buffer = append(buffer, "(uid="...)
+		buffer = append(buffer, uid...)
+		buffer = append(buffer, ") by (uid=0)"...)
+	case sessionLogindNew:
+		buffer = g.appendLogindHeader(buffer, s.hostIdx)
+		buffer = append(buffer, "New session "...)
+		buffer = append(buffer, s.id...)
+		buffer = append(buffer, " of user "...)
+		buffer = append(buffer, user...)
+		buffer = append(buffer, '.')
+	case sessionSudoCommand:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityNotice, s.hostIdx, "sudo", s.sudoPID, "")
+		buffer = append(buffer, user...)
+		buffer = append(buffer, " : TTY="...)
+		buffer = append(buffer, s.tty...)
+		buffer = append(buffer, " ; PWD=/home/"...)
+		buffer = append(buffer, user...)
+		buffer = append(buffer, " ; USER=root ; COMMAND="...)
+		buffer = append(buffer, sessionSudoCommands[s.command]...)
+	case sessionSudoOpened:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sudo", s.sudoPID, "")
+		buffer = append(buffer, "pam_unix(sudo:session): session opened for user root(uid=0) by "...)
+		buffer = append(buffer, user...)
+		buffer = append(buffer, "(uid="...)
+		buffer = append(buffer, uid...)
+		buffer = append(buffer, ')')
+	case sessionSudoClosed:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sudo", s.sudoPID, "")
+		buffer = append(buffer, "pam_unix(sudo:session): session closed for user root"...)
+	case sessionDisconnect:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sshd", s.sshdPID, "SSH")
+		buffer = append(buffer, "Received disconnect"...)
+		buffer = g.appendFromPort(buffer, s.srcIP, s.srcPort)
+		buffer = append(buffer, ":11: disconnected by user"...)
+	case sessionDisconnected:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sshd", s.sshdPID, "SSH")
+		buffer = append(buffer, "Disconnected from user "...)
+		buffer = append(buffer, user...)
+		buffer = append(buffer, ' ')
+		buffer = append(buffer, s.srcIP...)
+		buffer = append(buffer, " port "...)
+		buffer = append(buffer, s.srcPort...)
+	case sessionPAMClosed:
+		buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, s.hostIdx, "sshd", s.sshdPID, "SSH")
+		buffer = append(buffer, "pam_unix(sshd:session): session closed for user "...)
+		buffer = append(buffer, user...)
+	case sessionLogindLogout:
+		buffer = g.appendLogindHeader(buffer, s.hostIdx)
+		buffer = append(buffer, "Session "...)
+		buffer = append(buffer, s.id...)
+		buffer = append(buffer, " logged out. Waiting for processes to exit."...)
+	case sessionLogindRemoved:
+		buffer = g.appendLogindHeader(buffer, s.hostIdx)
+		buffer = append(buffer, "Removed session "...)
+		buffer = append(buffer, s.id...)
+		buffer = append(buffer, '.')
+	}
+	return buffer
+}
+
+// appendLogindHeader - systemd-logind 헤더 (호스트별 데몬 PID)
+func (g *SessionGenerator) appendLogindHeader(buffer []byte, hostIdx int) []byte {
+	pid := g.header.procID(g.rng, hostIdx, "systemd-logind", g.logindPIDs[hostIdx])
+	return g.header.appendHeader(buffer, sessionAuthFacility, severityInfo, hostIdx, "systemd-logind", pid, "")
+}
+
+// appendFromPort - " from IP port PORT"
+func (g *SessionGenerator) appendFromPort(buffer []byte, ip, port string) []byte {
+	buffer = append(buffer, " from "...)
+	buffer = append(buffer, ip...)
+	buffer = append(buffer, " port "...)
+	return append(buffer, port...)
+}
+
+// appendNoise - 세션과 무관한 인증 전 무차별 대입 잡음 (매번 새 sshd PID)
+func (g *SessionGenerator) appendNoise(buffer []byte) []byte {
+	hostIdx := g.header.pickHost(g.rng)
+	ip := g.noiseIPs[skewedIndex(g.rng, len(g.noiseIPs))]
+	port := strconv.Itoa(1024 + g.rng.Intn(64511))
+	buffer = g.header.appendHeader(buffer, sessionAuthPrivFacility, severityInfo, hostIdx, "sshd", g.newPID(hostIdx), "SSH")
+
+	switch r := g.rng.Intn(10); {
+	case r < 4:
+		buffer = append(buffer, "Failed password for invalid user "...)
+		buffer = append(buffer, sessionInvalidUsers[g.rng.Intn(len(sessionInvalidUsers))]...)
+		buffer = g.appendFromPort(buffer, ip, port)
+		buffer = append(buffer, " ssh2"...)
+	case r < 6:
+		buffer = append(buffer, "Failed password for root"...)
+		buffer = g.appendFromPort(buffer, ip, port)
+		buffer = append(buffer, " ssh2"...)
+	case r < 8:
+		buffer = append(buffer, "Invalid user "...)
+		buffer = append(buffer, sessionInvalidUsers[g.rng.Intn(len(sessionInvalidUsers))]...)
+		buffer = g.appendFromPort(buffer, ip, port)
+	default:
+		buffer = append(buffer, "Connection closed by authenticating user root "...)
+		buffer = append(buffer, ip...)
+		buffer = append(buffer, " port "...)
+		buffer = append(buffer, port...)
+		buffer = append(buffer, " [preauth]"...)
+	}
+	return buffer
+}
@@ -0,0 +1,345 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 메시지 크기 분포
+const (
+	SizeFixed     = "fixed"     // 항상 Mean 바이트
+	SizeUniform   = "uniform"   // Min~Max 균등
+	SizeNormal    = "normal"    // 평균 Mean, 표준편차 StdDev (Min~Max로 자름)
+	SizeLogNormal = "lognormal" // 평균 Mean, 표준편차 StdDev인 로그 정규 (긴 꼬리)
+)
+
+// 패딩으로 늘릴 수 있는 최대 메시지 크기
+const sizeLimit = 1 << 20
+
+// SizeOptions - 메시지 크기 분포 옵션 (패딩으로 목표 크기까지 늘림)
+type SizeOptions struct {
+	// 분포 (fixed, uniform, normal, lognormal / 빈 값 = 패딩 끔)
+	Distribution string `json:"distribution,omitempty"`
+	// 평균 크기 (바이트, fixed/normal/lognormal)
+	Mean int `json:"mean,omitempty"`
+	// 표준편차 (바이트, normal/lognormal)
+	StdDev int `json:"stddev,omitempty"`
+	// 최소/최대 크기 (바이트, 샘플을 이 범위로 자름)
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+// Validate - 크기 분포 옵션 검증
+func (o SizeOptions) Validate() error {
+	switch o.Distribution {
+	case "":
+		return nil
+	case SizeFixed, SizeUniform, SizeNormal, SizeLogNormal:
+	default:
+		return fmt.Errorf("지원하지 않는 크기 분포: %s (fixed, uniform, normal, lognormal)", o.Distribution)
+	}
+	if o.Min < 0 || o.Max <= 0 || o.Min > o.Max {
+		return fmt.Errorf("잘못된 메시지 크기 범위: %d~%d", o.Min, o.Max)
+	}
+	if o.Max > sizeLimit {
+		return fmt.Errorf("최대 메시지 크기는 %d바이트 이하여야 합니다: %d", sizeLimit, o.Max)
+	}
+	if o.Distribution != SizeUniform && (o.Mean < o.Min || o.Mean > o.Max) {
+		return fmt.Errorf("평균 메시지 크기는 %d~%d 사이여야 합니다: %d", o.Min, o.Max, o.Mean)
+	}
+	if o.StdDev < 0 {
+		return fmt.Errorf("표준편차는 0 이상이어야 합니다: %d", o.StdDev)
+	}
+	return nil
+}
+
+// ValidateLimit - 최대 메시지 크기가 전송 한도 이하인지 검증 (limit 0 = 무제한)
+//
+// UDP는 로그 한 건이 데이터그램 하나를 넘을 수 없으므로 Max가 최대 데이터그램 크기보다 크면
+// 패딩한 메시지가 전송되지 못하고 버려진다.
+func (o SizeOptions) ValidateLimit(limit int) error {
+	if o.Distribution == "" || limit <= 0 || o.Max <= limit {
+		return nil
+	}
+	return fmt.Errorf("UDP 전송에서는 최대 메시지 크기가 최대 데이터그램 크기(%d바이트) 이하여야 합니다: %d (더 큰 메시지는 TCP 전송 사용)", limit, o.Max)
+}
+
+// sample - 분포에서 목표 크기 하나 선택 (호출자가 rng 락 보유)
+func (o SizeOptions) sample(rng *rand.Rand) int {
+	var size float64
+	switch o.Distribution {
+	case SizeFixed:
+		return o.Mean
+	case SizeUniform:
+		return o.Min + rng.Intn(o.Max-o.Min+1)
+	case SizeNormal:
+		size = float64(o.Mean) + rng.NormFloat64()*float64(o.StdDev)
+	case SizeLogNormal:
+		// 평균/표준편차를 실제 크기 기준으로 맞추는 로그 공간 모수
+		cv := float64(o.StdDev) / float64(o.Mean)
+		sigma := math.Sqrt(math.Log1p(cv * cv))
+		mu := math.Log(float64(o.Mean)) - sigma*sigma/2
+		size = math.Exp(mu + sigma*rng.NormFloat64())
+	}
+	return min(max(int(math.Round(size)), o.Min), o.Max)
+}
+
+// paddingField - 패딩에 쓰는 키/값 (값에는 JSON/XML 이스케이프가 필요한 문자가 없음)
+type paddingField struct {
+	key   string
+	value templatePart
+}
+
+// choicePart - 목록에서 균등 선택하는 값
+func choicePart(values ...string) templatePart {
+	return func(buffer []byte, rng *rand.Rand) []byte {
+		return append(buffer, values[rng.Intn(len(values))]...)
+	}
+}
+
+// intPart - min~max 정수 값
+func intPart(min, max int) templatePart {
+	return func(buffer []byte, rng *rand.Rand) []byte {
+		return strconv.AppendInt(buffer, int64(min+rng.Intn(max-min+1)), 10)
+	}
+}
+
+// hexPart - 소문자 16진수 n자리 값
+func hexPart(n int) templatePart {
+	return func(buffer []byte, rng *rand.Rand) []byte {
+		return appendLowerHex(buffer, rng, n)
+	}
+}
+
+// k8s_pod 값의 워크로드 이름
+var paddingPodPrefixes = []string{"api", "worker", "gateway", "checkout"}
+
+// paddingFields - 애플리케이션 로그에 흔히 붙는 추적/요청/배포 컨텍스트
+var paddingFields = []paddingField{
+	{"trace_id", hexPart(32)},
+	{"span_id", hexPart(16)},
+	{"request_id", appendUUID},
+	{"session_id", hexPart(24)},
+	{"client_ip", func(buffer []byte, rng *rand.Rand) []byte { return appendRandomIPv4(buffer, rng, 10) }},
+	{"user_agent", choicePart(
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4_1) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+		"okhttp/4.12.0",
+		"Go-http-client/1.1",
+		"python-requests/2.31.0",
+	)},
+	{"http_method", choicePart("GET", "GET", "GET", "POST", "PUT", "DELETE")},
+	{"http_path", choicePart("/api/v1/orders", "/api/v1/users/me", "/api/v2/search", "/healthz", "/graphql", "/api/v1/payments/confirm")},
+	{"status", choicePart("200", "200", "200", "201", "204", "302", "400", "404", "500")},
+	{"bytes_in", intPart(0, 8192)},
+	{"bytes_out", intPart(128, 262144)},
+	{"duration_ms", intPart(1, 2500)},
+	{"tenant_id", choicePart("t-1001", "t-1002", "t-2040", "t-3177", "t-9001")},
+	{"region", choicePart("ap-northeast-2", "ap-northeast-1", "us-east-1", "eu-west-1")},
+	{"zone", choicePart("a", "b", "c", "d")},
+	{"k8s_namespace", choicePart("default", "payments", "orders", "search", "platform")},
+	{"k8s_pod", func(buffer []byte, rng *rand.Rand) []byte {
+		buffer = append(buffer, paddingPodPrefixes[rng.Intn(len(paddingPodPrefixes))]...)
+		buffer = append(buffer, '-')
+		buffer = appendLowerHex(buffer, rng, 10)
+		buffer = append(buffer, '-')
+		return appendLowerHex(buffer, rng, 5)
+	}},
+	{"container_id", hexPart(64)},
+	{"image", choicePart("registry.local/api:1.42.0", "registry.local/worker:1.42.0", "registry.local/gateway:2.7.3")},
+	{"build", hexPart(12)},
+	{"feature_flags", choicePart("new-checkout,fast-search", "dark-launch", "none", "beta-ui,async-export,ratelimit-v2")},
+}
+
+// 마지막 채움 필드 (남은 길이를 16진수로 정확히 채움)
+const paddingFillKey = "payload"
+
+// 패딩 위치/인코딩 방식
+type paddingStyle int
+
+const (
+	paddingKV   paddingStyle = iota // 첫 줄 끝에 " key=value" (syslog, CEF 등 텍스트)
+	paddingJSON                     // 마지막 '}' 앞에 "labels" 객체 (ECS 등 JSON)
+	paddingXML                      // </EventData> 앞에 <Data Name='key'> (Windows XML)
+)
+
+// Windows XML 이벤트의 EventData 끝
+var xmlEventDataEnd = []byte("</EventData></Event>")
+
+// paddingFormatter - 다른 포맷터의 출력을 크기 분포에 맞춰 늘리는 래퍼
+//
+// 패딩은 메시지를 늘리기만 하므로 목표보다 큰 원본 메시지는 그대로 전송한다.
+// 목표와 원본의 차이가 채움 필드 하나보다 작으면(수 바이트) 패딩을 생략한다.
+type paddingFormatter struct {
+	inner   LogFormatter
+	options SizeOptions
+	scratch []byte
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newPaddingFormatter - 크기 분포 래퍼 생성
+func newPaddingFormatter(inner LogFormatter, options SizeOptions) *paddingFormatter {
+	return &paddingFormatter{
+		inner:   inner,
+		options: options,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Name - LogFormatter 구현 (감싼 형식 이름)
+func (f *paddingFormatter) Name() string {
+	return f.inner.Name()
+}
+
+// Generate - LogFormatter 구현
+func (f *paddingFormatter) Generate() []byte {
+	message := f.inner.Generate()
+
+	f.rngMutex.Lock()
+	defer f.rngMutex.Unlock()
+
+	target := f.options.sample(f.rng)
+	if len(message) >= target || IsDirective(message) {
+		return message
+	}
+
+	style, pos := paddingPosition(message)
+	remaining := target - len(message)
+	if style == paddingJSON {
+		remaining -= len(`,"labels":{}`)
+	}
+	fill := f.appendFields(f.scratch[:0], style, remaining)
+	f.scratch = fill
+	if len(fill) == 0 {
+		return message
+	}
+
+	result := make([]byte, 0, target)
+	result = append(result, message[:pos]...)
+	if style == paddingJSON {
+		result = append(result, `,"labels":{`...)
+		result = append(result, fill...)
+		result = append(result, '}')
+	} else {
+		result = append(result, fill...)
+	}
+	return append(result, message[pos:]...)
+}
+
+// paddingPosition - 메시지 형태에 따른 패딩 방식과 삽입 위치
+func paddingPosition(message []byte) (paddingStyle, int) {
+	if bytes.HasSuffix(message, xmlEventDataEnd) {
+		return paddingXML, len(message) - len(xmlEventDataEnd)
+	}
+	if n := len(message); n > 0 && message[n-1] == '}' && bytes.Contains(message, []byte(`{"`)) {
+		return paddingJSON, n - 1
+	}
+	// 멀티라인 메시지(스택 트레이스)는 첫 줄(요약) 끝에 붙임
+	if i := bytes.IndexByte(message, '\n'); i >= 0 {
+		return paddingKV, i
+	}
+	return paddingKV, len(message)
+}
+
+// appendFields - 임의 순서의 컨텍스트 필드와 채움 필드로 정확히 n바이트 추가
+//
+// 필드를 넣은 뒤에도 채움 필드가 최소 1자 들어갈 자리를 남기므로, 필드를 하나라도
+// 넣었다면 결과는 항상 n바이트다. n이 채움 필드보다 작으면 빈 결과를 반환한다.
+func (f *paddingFormatter) appendFields(buffer []byte, style paddingStyle, n int) []byte {
+	if n < paddingOverhead(style, paddingFillKey, true)+1 {
+		return buffer[:0]
+	}
+
+	start := f.rng.Intn(len(paddingFields))
+	for i := range paddingFields {
+		field := paddingFields[(start+i)%len(paddingFields)]
+		mark := len(buffer)
+		buffer = appendPaddingField(buffer, style, field.key, field.value, f.rng, mark == 0)
+		if len(buffer)+paddingOverhead(style, paddingFillKey, false)+1 > n {
+			buffer = buffer[:mark]
+			break
+		}
+	}
+
+	width := n - len(buffer) - paddingOverhead(style, paddingFillKey, len(buffer) == 0)
+	return appendPaddingField(buffer, style, paddingFillKey, hexPart(width), f.rng, len(buffer) == 0)
+}
+
+// paddingOverhead - 값을 제외한 필드 하나의 인코딩 길이
+func paddingOverhead(style paddingStyle, key string, first bool) int {
+	switch style {
+	case paddingJSON:
+		if first {
+			return len(key) + 5 // "key":""
+		}
+		return len(key) + 6
+	case paddingXML:
+		return len(key) + len(`<Data Name=''></Data>`)
+	}
+	return len(key) + 2 // " key="
+}
+
+// appendPaddingField - 방식에 맞춰 필드 하나 추가 (공백이 든 key=value 값은 따옴표로 감쌈)
+func appendPaddingField(buffer []byte, style paddingStyle, key string, value templatePart, rng *rand.Rand, first bool) []byte {
+	switch style {
+	case paddingJSON:
+		if !first {
+			buffer = append(buffer, ',')
+		}
+		buffer = append(buffer, '"')
+		buffer = append(buffer, key...)
+		buffer = append(buffer, `":"`...)
+		buffer = value(buffer, rng)
+		return append(buffer, '"')
+	case paddingXML:
+		buffer = append(buffer, "<Data Name='"...)
+		buffer = append(buffer, key...)
+		buffer = append(buffer, "'>"...)
+		buffer = value(buffer, rng)
+		return append(buffer, "</Data>"...)
+	}
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, key...)
+	buffer = append(buffer, '=')
+	mark := len(buffer)
+	buffer = value(buffer, rng)
+	if bytes.IndexByte(buffer[mark:], ' ') >= 0 {
+		buffer = append(buffer[:mark+1], buffer[mark:]...)
+		buffer[mark] = '"'
+		buffer = append(buffer, '"')
+	}
+	return buffer
+}
+
+// EstimateMessageSize - 형식별 평균 메시지 크기의 평균 (바이트, 워커에 형식을 고르게 배정한다고 가정)
+//
+// 크기 분포와 퍼징을 포함한 실제 포맷터로 samples개씩 생성해 잰다.
+// 바이트 목표를 EPS로 환산할 때 사용한다. 공격 시나리오는 드물고 공유 간격 일정을
+// 건드리면 안 되므로 빼고 잰다.
+func EstimateMessageSize(names []string, options GeneratorOptions, samples int) (float64, error) {
+	if err := ValidateFormats(names); err != nil {
+		return 0, err
+	}
+	options.Scenarios = nil
+	total := 0.0
+	for _, name := range names {
+		formatter, err := NewFormatter(name, options)
+		if err != nil {
+			return 0, err
+		}
+		size := 0
+		for i := 0; i < samples; i++ {
+			size += len(formatter.Generate())
+		}
+		total += float64(size) / float64(samples)
+	}
+	return total / float64(len(names)), nil
+}
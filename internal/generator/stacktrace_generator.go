@@ -0,0 +1,546 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 스택 트레이스 언어
+const (
+	StackTraceJava   = "java"
+	StackTracePython = "python"
+	StackTraceMixed  = "mixed"
+)
+
+// 스택 트레이스 깊이 (최상위 예외의 프레임 수)
+const (
+	defaultStackTraceDepth = 12
+	maxStackTraceDepth     = 256
+)
+
+// StackTraceOptions - 멀티라인 예외 로그 옵션
+type StackTraceOptions struct {
+	// 언어 (java, python, mixed / 빈 값 = mixed)
+	Language string `json:"language,omitempty"`
+	// 최상위 예외의 프레임 수 (0 = 12, 최대 256)
+	Depth int `json:"depth,omitempty"`
+}
+
+// Validate - 스택 트레이스 옵션 검증
+func (o StackTraceOptions) Validate() error {
+	switch o.Language {
+	case "", StackTraceJava, StackTracePython, StackTraceMixed:
+	default:
+		return fmt.Errorf("지원하지 않는 스택 트레이스 언어: %s (java, python, mixed)", o.Language)
+	}
+	if o.Depth < 0 || o.Depth > maxStackTraceDepth {
+		return fmt.Errorf("스택 트레이스 깊이는 1~%d 사이여야 합니다: %d", maxStackTraceDepth, o.Depth)
+	}
+	return nil
+}
+
+// stackException - 예외 한 줄 ("%d"가 있으면 생성 시점에 임의 숫자로 채움)
+type stackException struct {
+	prefix string
+	suffix string
+	max    int // 0이면 숫자 없음
+}
+
+// newStackException - "%d" 위치로 예외 줄 분리
+func newStackException(text string, max int) stackException {
+	prefix, suffix, found := strings.Cut(text, "%d")
+	if !found {
+		return stackException{prefix: text}
+	}
+	return stackException{prefix: prefix, suffix: suffix, max: max}
+}
+
+// stackCause - 원인 예외 (Java "Caused by:", Python "direct cause")
+type stackCause struct {
+	exception stackException
+	frames    []string // 라이브러리 프레임 (렌더링 순서)
+}
+
+// stackTraceApp - 예외를 남기는 애플리케이션 (프레임은 언어별 출력 순서로 사전 조립)
+//
+// Java는 안쪽 프레임부터, Python은 바깥 프레임부터 출력한다. 깊이가 프레임 수를
+// 넘으면 outer의 fillerAt 위치에 filler(서블릿 필터 체인, Django 미들웨어)를 반복해 채운다.
+type stackTraceApp struct {
+	name       string
+	language   string
+	summaries  []stackException // 예외 앞의 로거 메시지 줄
+	frames     []string         // 애플리케이션 프레임
+	filler     []string         // 반복 가능한 프레임워크 프레임
+	outer      []string         // 스레드/워커 진입점 쪽 프레임워크 프레임
+	fillerAt   int              // outer 안에서 filler가 끼어드는 위치
+	exceptions []stackException
+	causes     []stackCause
+}
+
+// javaFrame - "\tat 클래스.메서드(파일:줄)" 조립 (줄 번호가 음수면 네이티브/생성 코드)
+func javaFrame(class, method string, line int) string {
+	location := "Native Method"
+	if strings.Contains(class, "$$") {
+		location = "<generated>"
+	}
+	if line >= 0 {
+		file := class[strings.LastIndexByte(class, '.')+1:]
+		if i := strings.IndexByte(file, '$'); i >= 0 {
+			file = file[:i]
+		}
+		location = file + ".java:" + strconv.Itoa(line)
+	}
+	return "\tat " + class + "." + method + "(" + location + ")"
+}
+
+// pythonFrame - "  File ..., line N, in 함수" + 소스 줄 조립
+func pythonFrame(path string, line int, function, source string) string {
+	return `  File "` + path + `", line ` + strconv.Itoa(line) + ", in " + function + "\n    " + source
+}
+
+// Spring MVC/Tomcat 요청 처리 프레임 (안쪽 → 스레드 진입점)
+var javaServletFrames = []string{
+	javaFrame("jdk.internal.reflect.DirectMethodHandleAccessor", "invoke", 103),
+	javaFrame("java.lang.reflect.Method", "invoke", 580),
+	javaFrame("org.springframework.web.method.support.InvocableHandlerMethod", "doInvoke", 205),
+	javaFrame("org.springframework.web.method.support.InvocableHandlerMethod", "invokeForRequest", 150),
+	javaFrame("org.springframework.web.servlet.mvc.method.annotation.ServletInvocableHandlerMethod", "invokeAndHandle", 118),
+	javaFrame("org.springframework.web.servlet.mvc.method.annotation.RequestMappingHandlerAdapter", "invokeHandlerMethod", 884),
+	javaFrame("org.springframework.web.servlet.mvc.method.annotation.RequestMappingHandlerAdapter", "handleInternal", 797),
+	javaFrame("org.springframework.web.servlet.mvc.method.AbstractHandlerMethodAdapter", "handle", 87),
+	javaFrame("org.springframework.web.servlet.DispatcherServlet", "doDispatch", 1081),
+	javaFrame("org.springframework.web.servlet.DispatcherServlet", "doService", 974),
+	javaFrame("org.springframework.web.servlet.FrameworkServlet", "processRequest", 1011),
+	javaFrame("org.springframework.web.servlet.FrameworkServlet", "doPost", 914),
+	javaFrame("jakarta.servlet.http.HttpServlet", "service", 590),
+	javaFrame("org.springframework.web.servlet.FrameworkServlet", "service", 885),
+	javaFrame("jakarta.servlet.http.HttpServlet", "service", 658),
+	javaFrame("org.apache.catalina.core.ApplicationFilterChain", "internalDoFilter", 205),
+	javaFrame("org.apache.catalina.core.ApplicationFilterChain", "doFilter", 149),
+	javaFrame("org.apache.catalina.core.StandardWrapperValve", "invoke", 166),
+	javaFrame("org.apache.catalina.core.StandardContextValve", "invoke", 90),
+	javaFrame("org.apache.catalina.core.StandardHostValve", "invoke", 115),
+	javaFrame("org.apache.catalina.valves.ErrorReportValve", "invoke", 93),
+	javaFrame("org.apache.catalina.core.StandardEngineValve", "invoke", 74),
+	javaFrame("org.apache.catalina.connector.CoyoteAdapter", "service", 341),
+	javaFrame("org.apache.coyote.http11.Http11Processor", "service", 391),
+	javaFrame("org.apache.coyote.AbstractProcessorLight", "process", 63),
+	javaFrame("org.apache.coyote.AbstractProtocol$ConnectionHandler", "process", 894),
+	javaFrame("org.apache.tomcat.util.net.NioEndpoint$SocketProcessor", "doRun", 1740),
+	javaFrame("org.apache.tomcat.util.net.SocketProcessorBase", "run", 52),
+	javaFrame("org.apache.tomcat.util.threads.ThreadPoolExecutor", "runWorker", 1191),
+	javaFrame("org.apache.tomcat.util.threads.ThreadPoolExecutor$Worker", "run", 659),
+	javaFrame("org.apache.tomcat.util.threads.TaskThread$WrappingRunnable", "run", 61),
+	javaFrame("java.lang.Thread", "run", 1583),
+}
+
+// 서블릿 필터 체인 (깊은 트레이스를 채우는 반복 프레임)
+var javaFilterFrames = []string{
+	javaFrame("org.springframework.web.filter.OncePerRequestFilter", "doFilter", 116),
+	javaFrame("org.apache.catalina.core.ApplicationFilterChain", "internalDoFilter", 174),
+	javaFrame("org.apache.catalina.core.ApplicationFilterChain", "doFilter", 149),
+}
+
+// Kafka 리스너 컨테이너 프레임
+var javaKafkaFrames = []string{
+	javaFrame("org.springframework.kafka.listener.adapter.MessagingMessageListenerAdapter", "invokeHandler", 380),
+	javaFrame("org.springframework.kafka.listener.adapter.RecordMessagingMessageListenerAdapter", "onMessage", 92),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "doInvokeOnMessage", 2800),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "invokeOnMessage", 2778),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "doInvokeRecordListener", 2690),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "doInvokeWithRecords", 2541),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "invokeRecordListener", 2430),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "invokeListener", 2085),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "pollAndInvoke", 1461),
+	javaFrame("org.springframework.kafka.listener.KafkaMessageListenerContainer$ListenerConsumer", "run", 1426),
+	javaFrame("java.util.concurrent.CompletableFuture$AsyncRun", "run", 1804),
+	javaFrame("java.lang.Thread", "run", 1583),
+}
+
+// Spring AOP 프록시 (Kafka 리스너 깊이 채우기)
+var javaProxyFrames = []string{
+	javaFrame("org.springframework.aop.framework.ReflectiveMethodInvocation", "proceed", 184),
+	javaFrame("org.springframework.transaction.interceptor.TransactionInterceptor", "invoke", 119),
+}
+
+// Java 원인 예외 (라이브러리 프레임 포함)
+var javaCauses = []stackCause{
+	{newStackException("java.sql.SQLTransientConnectionException: HikariPool-1 - Connection is not available, request timed out after %dms.", 30000), []string{
+		javaFrame("com.zaxxer.hikari.pool.HikariPool", "createTimeoutException", 696),
+		javaFrame("com.zaxxer.hikari.pool.HikariPool", "getConnection", 181),
+		javaFrame("com.zaxxer.hikari.pool.HikariPool", "getConnection", 146),
+		javaFrame("com.zaxxer.hikari.HikariDataSource", "getConnection", 128),
+		javaFrame("org.hibernate.engine.jdbc.connections.internal.DatasourceConnectionProviderImpl", "getConnection", 122),
+	}},
+	{newStackException("java.net.SocketTimeoutException: Read timed out", 0), []string{
+		javaFrame("sun.nio.ch.NioSocketImpl", "timedRead", 288),
+		javaFrame("sun.nio.ch.NioSocketImpl", "implRead", 314),
+		javaFrame("sun.nio.ch.NioSocketImpl", "read", 355),
+		javaFrame("java.net.Socket$SocketInputStream", "read", 1099),
+		javaFrame("java.io.BufferedInputStream", "fill", 291),
+		javaFrame("org.apache.http.impl.io.SessionInputBufferImpl", "streamRead", 137),
+	}},
+	{newStackException("org.postgresql.util.PSQLException: ERROR: duplicate key value violates unique constraint \"uk_order_number\"", 0), []string{
+		javaFrame("org.postgresql.core.v3.QueryExecutorImpl", "receiveErrorResponse", 2713),
+		javaFrame("org.postgresql.core.v3.QueryExecutorImpl", "processResults", 2401),
+		javaFrame("org.postgresql.core.v3.QueryExecutorImpl", "execute", 368),
+		javaFrame("org.postgresql.jdbc.PgStatement", "executeInternal", 498),
+		javaFrame("org.postgresql.jdbc.PgPreparedStatement", "executeUpdate", 152),
+	}},
+	{newStackException("java.io.IOException: Broken pipe", 0), []string{
+		javaFrame("sun.nio.ch.SocketDispatcher", "write0", -1),
+		javaFrame("sun.nio.ch.SocketDispatcher", "write", 62),
+		javaFrame("sun.nio.ch.IOUtil", "writeFromNativeBuffer", 137),
+		javaFrame("sun.nio.ch.NioSocketImpl", "implWrite", 425),
+	}},
+}
+
+// Django 요청 처리 프레임 (바깥 → 안쪽)
+var pythonDjangoOuter = []string{
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/core/handlers/exception.py", 55, "inner", "response = get_response(request)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/core/handlers/base.py", 197, "_get_response", "response = wrapped_callback(request, *callback_args, **callback_kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/views/decorators/csrf.py", 56, "wrapper_view", "return view_func(*args, **kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/views/generic/base.py", 104, "view", "return self.dispatch(request, *args, **kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/rest_framework/views.py", 509, "dispatch", "response = self.handle_exception(exc)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/rest_framework/views.py", 469, "handle_exception", "self.raise_uncaught_exception(exc)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/rest_framework/views.py", 480, "raise_uncaught_exception", "raise exc"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/rest_framework/views.py", 506, "dispatch", "response = handler(request, *args, **kwargs)"),
+}
+
+// Django 미들웨어 체인 (깊은 트레이스를 채우는 반복 프레임)
+var pythonDjangoFiller = []string{
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/core/handlers/exception.py", 55, "inner", "response = get_response(request)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/django/utils/deprecation.py", 134, "__call__", "response = response or self.get_response(request)"),
+}
+
+// Celery 작업 실행 프레임 (바깥 → 안쪽)
+var pythonCeleryOuter = []string{
+	pythonFrame("/usr/local/lib/python3.11/site-packages/celery/app/trace.py", 477, "trace_task", "R = retval = fun(*args, **kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/celery/app/trace.py", 760, "__protected_call__", "return self.run(*args, **kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/celery/app/autoretry.py", 38, "run", "return task._orig_run(*args, **kwargs)"),
+}
+
+// 데코레이터 래퍼 (Celery 작업 깊이 채우기)
+var pythonCeleryFiller = []string{
+	pythonFrame("/srv/app/common/decorators.py", 41, "wrapper", "return func(*args, **kwargs)"),
+	pythonFrame("/usr/local/lib/python3.11/site-packages/sentry_sdk/integrations/celery.py", 312, "_inner", "return f(*args, **kwargs)"),
+}
+
+// Python 원인 예외
+var pythonCauses = []stackCause{
+	{newStackException("psycopg2.OperationalError: could not connect to server: Connection refused", 0), []string{
+		pythonFrame("/usr/local/lib/python3.11/site-packages/django/db/backends/base/base.py", 289, "ensure_connection", "self.connect()"),
+		pythonFrame("/usr/local/lib/python3.11/site-packages/psycopg2/__init__.py", 122, "connect", "conn = _connect(dsn, connection_factory=connection_factory, **kwasync)"),
+	}},
+	{newStackException("ConnectionResetError: [Errno 104] Connection reset by peer", 0), []string{
+		pythonFrame("/usr/local/lib/python3.11/site-packages/urllib3/connectionpool.py", 790, "urlopen", "response = self._make_request("),
+		pythonFrame("/usr/local/lib/python3.11/ssl.py", 1263, "recv_into", "return self.read(nbytes, buffer)"),
+	}},
+	{newStackException("KeyError: 'customer_id'", 0), []string{
+		pythonFrame("/srv/app/billing/serializers.py", 73, "to_internal_value", "customer = data[\"customer_id\"]"),
+	}},
+}
+
+// stackTraceApps - 내장 애플리케이션
+var stackTraceApps = []stackTraceApp{
+	{
+		name:     "order-service",
+		language: StackTraceJava,
+		summaries: []stackException{
+			newStackException("ERROR [http-nio-8080-exec-7] c.a.order.web.OrderController : Request processing failed", 0),
+			newStackException("ERROR [http-nio-8080-exec-2] o.a.c.c.C.[.[.[/].[dispatcherServlet] : Servlet.service() for servlet [dispatcherServlet] threw exception", 0),
+		},
+		frames: []string{
+			javaFrame("com.acme.order.service.OrderService", "validateCustomer", 214),
+			javaFrame("com.acme.order.service.OrderService", "placeOrder", 142),
+			javaFrame("com.acme.order.service.OrderService$$SpringCGLIB$$0", "placeOrder", -1),
+			javaFrame("com.acme.order.web.OrderController", "create", 58),
+		},
+		filler:   javaFilterFrames,
+		outer:    javaServletFrames,
+		fillerAt: 17,
+		exceptions: []stackException{
+			newStackException(`java.lang.NullPointerException: Cannot invoke "com.acme.order.model.Customer.getId()" because "customer" is null`, 0),
+			newStackException("java.lang.IllegalStateException: Order %d is already closed", 9999999),
+			newStackException("org.springframework.dao.DataIntegrityViolationException: could not execute statement; SQL [n/a]; constraint [uk_order_number]", 0),
+			newStackException("org.springframework.transaction.CannotCreateTransactionException: Could not open JPA EntityManager for transaction", 0),
+		},
+		causes: javaCauses,
+	},
+	{
+		name:     "payment-api",
+		language: StackTraceJava,
+		summaries: []stackException{
+			newStackException("ERROR [http-nio-8443-exec-11] c.a.payment.web.PaymentController : Payment authorization failed", 0),
+			newStackException("WARN  [http-nio-8443-exec-4] c.a.payment.client.GatewayClient : Gateway call failed, giving up after 3 attempts", 0),
+		},
+		frames: []string{
+			javaFrame("com.acme.payment.client.GatewayClient", "authorize", 97),
+			javaFrame("com.acme.payment.service.PaymentService", "authorize", 163),
+			javaFrame("com.acme.payment.web.PaymentController", "authorize", 71),
+		},
+		filler:   javaFilterFrames,
+		outer:    javaServletFrames,
+		fillerAt: 17,
+		exceptions: []stackException{
+			newStackException("org.springframework.web.client.ResourceAccessException: I/O error on POST request for \"https://gateway.example.com/v2/authorize\": Read timed out", 0),
+			newStackException("java.lang.IllegalArgumentException: Invalid amount: -%d", 50000),
+			newStackException("java.util.concurrent.TimeoutException: Did not observe any item or terminal signal within 5000ms", 0),
+		},
+		causes: javaCauses,
+	},
+	{
+		name:     "inventory-consumer",
+		language: StackTraceJava,
+		summaries: []stackException{
+			newStackException("ERROR [org.springframework.kafka.KafkaListenerEndpointContainer#0-0-C-1] c.a.inventory.StockListener : Failed to process record", 0),
+		},
+		frames: []string{
+			javaFrame("com.acme.inventory.domain.Stock", "reserve", 88),
+			javaFrame("com.acme.inventory.service.StockService", "reserve", 126),
+			javaFrame("com.acme.inventory.messaging.StockListener", "onOrderCreated", 44),
+		},
+		filler: javaProxyFrames,
+		outer:  javaKafkaFrames,
+		exceptions: []stackException{
+			newStackException("java.lang.ArrayIndexOutOfBoundsException: Index %d out of bounds for length 16", 64),
+			newStackException("com.acme.inventory.domain.InsufficientStockException: SKU-%d has 0 units available", 99999),
+			newStackException("org.springframework.orm.ObjectOptimisticLockingFailureException: Row was updated or deleted by another transaction", 0),
+		},
+		causes: javaCauses,
+	},
+	{
+		name:     "django-web",
+		language: StackTracePython,
+		summaries: []stackException{
+			newStackException("ERROR django.request: Internal Server Error: /api/v1/orders/", 0),
+			newStackException("ERROR django.request: Internal Server Error: /api/v1/invoices/%d/", 99999),
+		},
+		frames: []string{
+			pythonFrame("/srv/app/orders/views.py", 87, "create", "order = services.place_order(request.user, serializer.validated_data)"),
+			pythonFrame("/srv/app/orders/services.py", 142, "place_order", "customer = Customer.objects.get(pk=data[\"customer\"])"),
+			pythonFrame("/usr/local/lib/python3.11/site-packages/django/db/models/manager.py", 87, "manager_method", "return getattr(self.get_queryset(), name)(*args, **kwargs)"),
+			pythonFrame("/usr/local/lib/python3.11/site-packages/django/db/models/query.py", 637, "get", "raise self.model.DoesNotExist("),
+		},
+		filler:   pythonDjangoFiller,
+		outer:    pythonDjangoOuter,
+		fillerAt: 1,
+		exceptions: []stackException{
+			newStackException("orders.models.Customer.DoesNotExist: Customer matching query does not exist.", 0),
+			newStackException("AttributeError: 'NoneType' object has no attribute 'id'", 0),
+			newStackException("django.db.utils.OperationalError: could not connect to server: Connection refused", 0),
+			newStackException("ValueError: invalid literal for int() with base 10: 'undefined'", 0),
+		},
+		causes: pythonCauses,
+	},
+	{
+		name:     "billing-worker",
+		language: StackTracePython,
+		summaries: []stackException{
+			newStackException("ERROR celery.app.trace: Task billing.tasks.charge_invoice raised unexpected exception", 0),
+		},
+		frames: []string{
+			pythonFrame("/srv/app/billing/tasks.py", 88, "charge_invoice", "result = gateway.charge(invoice.total, invoice.customer.payment_token)"),
+			pythonFrame("/srv/app/billing/gateway.py", 51, "charge", "response = self.session.post(self.url, json=payload, timeout=10)"),
+			pythonFrame("/usr/local/lib/python3.11/site-packages/requests/sessions.py", 637, "post", "return self.request(\"POST\", url, data=data, json=json, **kwargs)"),
+			pythonFrame("/usr/local/lib/python3.11/site-packages/requests/adapters.py", 501, "send", "raise ConnectionError(err, request=request)"),
+		},
+		filler:   pythonCeleryFiller,
+		outer:    pythonCeleryOuter,
+		fillerAt: len(pythonCeleryOuter),
+		exceptions: []stackException{
+			newStackException("requests.exceptions.ConnectionError: ('Connection aborted.', ConnectionResetError(104, 'Connection reset by peer'))", 0),
+			newStackException("ZeroDivisionError: division by zero", 0),
+			newStackException("TimeoutError: [Errno 110] Connection timed out", 0),
+			newStackException("billing.exceptions.PaymentDeclined: card declined (code %d)", 99),
+		},
+		causes: pythonCauses,
+	},
+}
+
+// StackTraceGenerator - Java/Python 예외 스택 트레이스 멀티라인 로그 생성기
+//
+// 로그 한 건이 여러 줄이므로 수신 측이 다시 조립할 수 있는 프레이밍
+// (TCP 옥텟 카운팅, UDP 이스케이프/연속 줄)으로 전송해야 한다.
+type StackTraceGenerator struct {
+	header *syslogWrapper
+	apps   []*stackTraceApp
+	depth  int
+	pids   []string // 호스트별 PID
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newStackTraceGenerator - 레지스트리용 팩토리 ("stacktrace")
+func newStackTraceGenerator(options GeneratorOptions) (LogFormatter, error) {
+	if err := options.StackTrace.Validate(); err != nil {
+		return nil, err
+	}
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &StackTraceGenerator{
+		header: header,
+		depth:  options.StackTrace.Depth,
+		pids:   make([]string, len(header.hostnames)),
+		rng:    rng,
+	}
+	if gen.depth == 0 {
+		gen.depth = defaultStackTraceDepth
+	}
+	for i := range stackTraceApps {
+		app := &stackTraceApps[i]
+		if language := options.StackTrace.Language; language == "" || language == StackTraceMixed || language == app.language {
+			gen.apps = append(gen.apps, app)
+		}
+	}
+	for i := range gen.pids {
+		gen.pids[i] = strconv.Itoa(1000 + rng.Intn(60000))
+	}
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("stacktrace", newStackTraceGenerator)
+}
+
+// Name - LogFormatter 구현
+func (g *StackTraceGenerator) Name() string {
+	return "stacktrace"
+}
+
+// Generate - LogFormatter 구현 (줄바꿈을 포함한 로그 한 건)
+func (g *StackTraceGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	app := g.apps[g.rng.Intn(len(g.apps))]
+	hostIdx := g.header.pickHost(g.rng)
+	pid := g.header.procID(g.rng, hostIdx, app.name, g.pids[hostIdx])
+	buffer = g.header.appendHeader(buffer, g.header.pickFacility(g.rng), severityErr, hostIdx, app.name, pid, "")
+	buffer = g.appendStackException(buffer, app.summaries[g.rng.Intn(len(app.summaries))])
+	buffer = append(buffer, '\n')
+
+	if app.language == StackTracePython {
+		buffer = g.appendPython(buffer, app)
+	} else {
+		buffer = g.appendJava(buffer, app)
+	}
+	return finishBuffer(buffer)
+}
+
+// appendJava - 예외 줄, 프레임(안쪽부터), 선택적 "Caused by:" 블록
+func (g *StackTraceGenerator) appendJava(buffer []byte, app *stackTraceApp) []byte {
+	exception := app.exceptions[g.rng.Intn(len(app.exceptions))]
+	buffer = g.appendStackException(buffer, exception)
+
+	appFrames, filler, outer := g.split(app)
+	for _, frame := range app.frames[:appFrames] {
+		buffer = append(buffer, '\n')
+		buffer = append(buffer, frame...)
+	}
+	buffer = appendOuterFrames(buffer, app, app.outer[len(app.outer)-outer:], filler)
+
+	if g.rng.Intn(100) < 35 {
+		// 원인 예외는 라이브러리 프레임 뒤에 감싼 예외와 겹치는 프레임 수만 "... N more"로 표기
+		cause := &app.causes[g.rng.Intn(len(app.causes))]
+		buffer = append(buffer, "\nCaused by: "...)
+		buffer = g.appendStackException(buffer, cause.exception)
+		for _, frame := range cause.frames {
+			buffer = append(buffer, '\n')
+			buffer = append(buffer, frame...)
+		}
+		if common := g.depth - 1; common > 0 {
+			buffer = append(buffer, "\n\t... "...)
+			buffer = strconv.AppendInt(buffer, int64(common), 10)
+			buffer = append(buffer, " more"...)
+		}
+	}
+	return buffer
+}
+
+// appendPython - 선택적 원인 트레이스백, "Traceback" 줄, 프레임(바깥부터), 예외 줄
+func (g *StackTraceGenerator) appendPython(buffer []byte, app *stackTraceApp) []byte {
+	if g.rng.Intn(100) < 30 {
+		cause := &app.causes[g.rng.Intn(len(app.causes))]
+		buffer = append(buffer, "Traceback (most recent call last):"...)
+		for _, frame := range cause.frames {
+			buffer = append(buffer, '\n')
+			buffer = append(buffer, frame...)
+		}
+		buffer = append(buffer, '\n')
+		buffer = g.appendStackException(buffer, cause.exception)
+		buffer = append(buffer, "\n\nThe above exception was the direct cause of the following exception:\n\n"...)
+	}
+
+	buffer = append(buffer, "Traceback (most recent call last):"...)
+	appFrames, filler, outer := g.split(app)
+	buffer = appendOuterFrames(buffer, app, app.outer[:outer], filler)
+	for _, frame := range app.frames[len(app.frames)-appFrames:] {
+		buffer = append(buffer, '\n')
+		buffer = append(buffer, frame...)
+	}
+	buffer = append(buffer, '\n')
+	return g.appendStackException(buffer, app.exceptions[g.rng.Intn(len(app.exceptions))])
+}
+
+// split - 깊이를 애플리케이션/반복/진입점 프레임 수로 나눔
+//
+// 애플리케이션 프레임은 예외 발생 지점에 가까운 2~4개, 진입점 프레임은 스레드
+// 루트 쪽부터 채우고 남은 깊이는 반복 프레임으로 채운다.
+func (g *StackTraceGenerator) split(app *stackTraceApp) (appFrames, filler, outer int) {
+	appFrames = 2 + g.rng.Intn(3)
+	if appFrames > len(app.frames) {
+		appFrames = len(app.frames)
+	}
+	if appFrames > g.depth {
+		appFrames = g.depth
+	}
+	rest := g.depth - appFrames
+	outer = rest
+	if outer > len(app.outer) {
+		outer = len(app.outer)
+	}
+	return appFrames, rest - outer, outer
+}
+
+// appendOuterFrames - 프레임워크 프레임 추가 (filler는 outer 전체를 쓸 때만 fillerAt 위치에 반복)
+func appendOuterFrames(buffer []byte, app *stackTraceApp, outer []string, filler int) []byte {
+	at := len(outer)
+	if filler > 0 {
+		at = app.fillerAt
+	}
+	for _, frame := range outer[:at] {
+		buffer = append(buffer, '\n')
+		buffer = append(buffer, frame...)
+	}
+	for i := 0; i < filler; i++ {
+		buffer = append(buffer, '\n')
+		buffer = append(buffer, app.filler[i%len(app.filler)]...)
+	}
+	for _, frame := range outer[at:] {
+		buffer = append(buffer, '\n')
+		buffer = append(buffer, frame...)
+	}
+	return buffer
+}
+
+// appendStackException - 예외 줄 추가 (숫자 자리 채움)
+func (g *StackTraceGenerator) appendStackException(buffer []byte, exception stackException) []byte {
+	buffer = append(buffer, exception.prefix...)
+	if exception.max > 0 {
+		buffer = strconv.AppendInt(buffer, int64(1+g.rng.Intn(exception.max)), 10)
+		buffer = append(buffer, exception.suffix...)
+	}
+	return buffer
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -26,19 +27,39 @@ var (
 	}
 )
 
-// SystemLogGenerator - PRD 명세에 따른 RFC 3164 시스템 로그 생성기
+// RFC 5424 상수 필드
+const (
+	rfc5424Version = "1"
+	utf8BOM        = "\xEF\xBB\xBF"
+)
+
+// SystemLogGenerator - PRD 명세에 따른 RFC 3164/5424 시스템 로그 생성기
 type SystemLogGenerator struct {
 	// 사전 생성된 컴포넌트 풀 (할당 최소화)
-	priorities   []string
+	priority     *prioritySampler
 	hostnames    []string
 	services     []string
 	pids         []string
 	messages     []string
+	templates    *MessageTemplates // 사용자 정의 메시지 템플릿 (nil이면 messages 사용)
+	events       *systemEventSampler // 서비스/PRI/메시지 일관 선택 (nil이면 독립 선택)
+	baseMessages int                 // 인벤토리 전용 메시지를 제외한 messages 길이
+	
+	// 인벤토리 호스트 (hostnames와 1:1, nil이면 인벤토리 없음)
+	hosts        []*InventoryHost
+	roleEvents   []*systemEventSampler // 역할별 이벤트 선택기 (inventoryRoles 인덱스)
+	roleServices [][]int               // 역할별 services 인덱스 (nil이면 전체 서비스)
 	
-	// 타임스탬프 캐시 (1초마다 갱신)
-	timestampCache   string
-	timestampMutex   sync.RWMutex
-	lastTimestamp    time.Time
+	// RFC 5424 전용 컴포넌트 (서비스별 MSGID, 호스트별 origin SD)
+	msgIDs       []string
+	originSD     []string
+	
+	// 출력 옵션
+	options      GeneratorOptions
+	sequenceID   uint64
+	
+	// 공유 타임스탬프 캐시 (1초마다 갱신)
+	clock        *logClock
 	
 	// 고속 랜덤 생성기
 	rng          *rand.Rand
@@ -47,46 +68,52 @@ type SystemLogGenerator struct {
 
 // NewSystemLogGenerator - 400만 EPS를 위한 최적화된 생성기 초기화
 func NewSystemLogGenerator() *SystemLogGenerator {
-	gen := &SystemLogGenerator{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+	gen, err := NewSystemLogGeneratorWithOptions(DefaultGeneratorOptions())
+	if err != nil {
+		// 기본 옵션은 항상 유효하므로 여기서의 오류는 프로그래밍 오류
+		panic(err)
 	}
-	
-	// PRD 명세에 따른 실제 시스템 로그 패턴 사전 생성
-	gen.initializeLogComponents()
-	gen.startTimestampUpdater()
-	
 	return gen
 }
 
-func (g *SystemLogGenerator) initializeLogComponents() {
-	// RFC 3164 Priority 값들 (Facility * 8 + Severity)
-	g.priorities = []string{
-		"<0>", "<1>", "<2>", "<3>", "<4>", "<5>", "<6>", "<7>",    // kernel messages
-		"<16>", "<17>", "<18>", "<19>", "<20>", "<21>", "<22>", "<23>", // mail
-		"<32>", "<33>", "<34>", "<35>", "<36>", "<37>", "<38>", "<39>", // daemon
+// NewSystemLogGeneratorWithOptions - 출력 옵션을 지정하여 생성기 초기화
+func NewSystemLogGeneratorWithOptions(options GeneratorOptions) (*SystemLogGenerator, error) {
+	gen := &SystemLogGenerator{
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		options: options,
+		clock:   getClock(),
 	}
 	
-	// 서버 호스트명 풀 (실제 환경과 유사)
-	g.hostnames = []string{
-		"server01", "server02", "server03", "server04", "server05",
-		"web01", "web02", "web03", "db01", "db02", "cache01", "cache02",
-		"app01", "app02", "app03", "proxy01", "proxy02", "lb01", "lb02",
+	// PRD 명세에 따른 실제 시스템 로그 패턴 사전 생성
+	gen.initializeLogComponents()
+	if err := gen.SetOptions(options); err != nil {
+		return nil, err
 	}
 	
-	// 시스템 서비스명 풀 (PRD 명세 반영)
-	g.services = []string{
-		"systemd", "kernel", "sshd", "nginx", "apache2", "mysqld",
-		"redis-server", "cron", "rsyslog", "NetworkManager", "docker",
-		"kubelet", "containerd", "etcd", "prometheus", "grafana",
+	return gen, nil
+}
+
+// newSyslogFormatter - 레지스트리용 팩토리 ("syslog")
+func newSyslogFormatter(options GeneratorOptions) (LogFormatter, error) {
+	gen, err := NewSystemLogGeneratorWithOptions(options)
+	if err != nil {
+		return nil, err
 	}
-	
+	return gen, nil
+}
+
+func init() {
+	RegisterFormatter("syslog", newSyslogFormatter)
+}
+
+func (g *SystemLogGenerator) initializeLogComponents() {
 	// PID 풀 사전 생성 (문자열 변환 오버헤드 제거)
 	g.pids = make([]string, 10000)
 	for i := 0; i < 10000; i++ {
 		g.pids[i] = strconv.Itoa(1000 + i)
 	}
 	
-	// 실제 시스템 로그 메시지 템플릿 (가중치 고려)
+	// 실제 시스템 로그 메시지 템플릿 (카테고리/가중치는 system_events.go)
 	g.messages = []string{
 		// systemd 관련 (40%)
 		"Starting nginx.service",
@@ -116,30 +143,199 @@ func (g *SystemLogGenerator) initializeLogComponents() {
 		"Certificate will expire",
 		"Disk space warning: /var partition at 85%",
 	}
+	
+	// 인벤토리 역할 전용 메시지는 뒤에 추가 (독립 선택에서는 제외)
+	g.baseMessages = len(g.messages)
+	g.messages = append(g.messages, inventoryEventMessages()...)
 }
 
-// 타임스탬프 캐시 업데이터 (1초마다 갱신하여 CPU 절약)
-func (g *SystemLogGenerator) startTimestampUpdater() {
-	g.updateTimestamp()
+// msgIDForService - 서비스명에 대응하는 RFC 5424 MSGID
+func msgIDForService(service string) string {
+	switch service {
+	case "sshd":
+		return "SSH"
+	case "kernel":
+		return "KERN"
+	case "cron":
+		return "CRON"
+	case "systemd":
+		return "UNIT"
+	case "nginx", "apache2":
+		return "HTTP"
+	case "mysqld", "redis-server", "etcd":
+		return "DB"
+	}
+	return "-"
+}
+
+// SetOptions - 출력 옵션 변경 (생성 시작 전에 호출)
+func (g *SystemLogGenerator) SetOptions(options GeneratorOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
 	
-	go func() {
-		ticker := time.NewTicker(time.Second)
-		defer ticker.Stop()
-		
-		for range ticker.C {
-			g.updateTimestamp()
+	// 호스트명/서비스 풀 (접두사, 서비스 목록 반영)
+	hostnames := options.hostnames()
+	services := options.services()
+	
+	// 서비스별 PRI 테이블 재계산 (Facility * 8 + Severity)
+	sampler, err := newPrioritySampler(services, options.Priority)
+	if err != nil {
+		return err
+	}
+	
+	// RFC 5424 MSGID (서비스 인덱스와 1:1 대응)
+	msgIDs := make([]string, len(services))
+	for i, service := range services {
+		msgIDs[i] = msgIDForService(service)
+	}
+	
+	// 카테고리 가중치 이벤트 테이블 (사용자 템플릿은 서비스와 무관하므로 독립 선택)
+	var events *systemEventSampler
+	if options.Templates == nil {
+		events = newSystemEventSampler(services, g.messages, nil)
+	}
+	
+	// 인벤토리 역할별 서비스/이벤트 (서비스가 없는 역할은 전체 풀 사용)
+	hosts := options.inventoryHosts()
+	var roleEvents []*systemEventSampler
+	var roleServices [][]int
+	if hosts != nil {
+		roleEvents = make([]*systemEventSampler, len(inventoryRoles))
+		roleServices = make([][]int, len(inventoryRoles))
+		for r := range inventoryRoles {
+			role := &inventoryRoles[r]
+			if len(role.services) == 0 {
+				roleEvents[r] = events
+				continue
+			}
+			for i, service := range services {
+				for _, roleService := range role.services {
+					if service == roleService {
+						roleServices[r] = append(roleServices[r], i)
+						break
+					}
+				}
+			}
+			if options.Templates == nil {
+				roleEvents[r] = newSystemEventSampler(services, g.messages, role)
+			}
+		}
+	}
+	
+	// 호스트별 origin SD 요소 사전 생성 (호스트 인덱스와 1:1 대응)
+	originSD := make([]string, len(hostnames))
+	for i := range hostnames {
+		ip := fmt.Sprintf("10.0.%d.%d", i/250, i%250+1)
+		if hosts != nil {
+			ip = hosts[i].IP
 		}
-	}()
+		originSD[i] = `[origin ip="` + ip + `" software="log-generator" swVersion="1.0"]`
+	}
+	
+	g.rngMutex.Lock()
+	g.options = options
+	g.hostnames = hostnames
+	g.services = services
+	g.priority = sampler
+	g.msgIDs = msgIDs
+	g.originSD = originSD
+	g.templates = options.Templates
+	g.events = events
+	g.hosts = hosts
+	g.roleEvents = roleEvents
+	g.roleServices = roleServices
+	g.rngMutex.Unlock()
+	return nil
+}
+
+// Options - 현재 출력 옵션 반환
+func (g *SystemLogGenerator) Options() GeneratorOptions {
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+	return g.options
 }
 
-func (g *SystemLogGenerator) updateTimestamp() {
-	now := time.Now()
-	timestamp := now.UTC().Format("2006-01-02T15:04:05.000Z")
+// Name - LogFormatter 구현
+func (g *SystemLogGenerator) Name() string {
+	return "syslog"
+}
+
+// Generate - LogFormatter 구현
+func (g *SystemLogGenerator) Generate() []byte {
+	return g.GenerateSystemLog()
+}
+
+// systemEvent - 생성기가 선택한 로그 한 건의 의미 단위 (출력 형식과 무관)
+//
+// syslog 한 줄과 ECS 문서 등 여러 출력 형식이 같은 선택 결과를 렌더링한다.
+type systemEvent struct {
+	hostnameIdx int
+	serviceIdx  int
+	pid         string
+	messageIdx  int
+	facility    int
+	severity    int
+	sequenceID  uint64
+}
+
+// pickEvent - 이벤트 선택 (빠른 인덱스 계산, 락 최소화)
+func (g *SystemLogGenerator) pickEvent() (systemEvent, GeneratorOptions) {
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+	
+	event := systemEvent{hostnameIdx: g.rng.Intn(len(g.hostnames))}
+	
+	// 인벤토리 호스트는 역할이 실행하는 서비스 안에서 선택
+	events := g.events
+	var services []int
+	if g.hosts != nil {
+		role := g.hosts[event.hostnameIdx].roleIdx
+		events = g.roleEvents[role]
+		services = g.roleServices[role]
+	}
+	
+	if events != nil {
+		// 서비스, 심각도, 메시지를 가중치 테이블에서 함께 선택
+		entry := events.pick(g.rng)
+		event.facility = g.priority.facility(g.rng, entry.serviceIdx)
+		event.severity = entry.severity
+		if g.priority.severityWeighted {
+			// 지정한 심각도 분포를 따르고, 메시지는 뽑은 심각도의 이벤트 중에서 다시 선택
+			// (그 심각도의 이벤트가 없으면 원래 이벤트에 심각도만 적용)
+			event.severity = g.priority.severity(g.rng, event.facility)
+			if event.severity != entry.severity {
+				if matched := events.pickSeverity(g.rng, event.severity); matched != nil {
+					entry = matched
+					event.facility = g.priority.facility(g.rng, entry.serviceIdx)
+				}
+			}
+		}
+		event.serviceIdx = entry.serviceIdx
+		event.messageIdx = entry.messageIdx
+	} else {
+		if len(services) > 0 {
+			event.serviceIdx = services[g.rng.Intn(len(services))]
+		} else {
+			event.serviceIdx = g.rng.Intn(len(g.services))
+		}
+		event.facility, event.severity = g.priority.pick(g.rng, event.serviceIdx)
+		if g.templates != nil {
+			event.messageIdx = g.templates.pick(g.rng)
+		} else {
+			event.messageIdx = g.rng.Intn(g.baseMessages)
+		}
+	}
 	
-	g.timestampMutex.Lock()
-	g.timestampCache = timestamp
-	g.lastTimestamp = now
-	g.timestampMutex.Unlock()
+	// 인벤토리 호스트는 서비스별 PID 고정 (재시작 시 변경), 아니면 매번 임의 PID
+	if g.hosts != nil {
+		event.pid = g.hosts[event.hostnameIdx].pid(g.services[event.serviceIdx], g.clock.Now(), g.rng)
+	} else {
+		event.pid = g.pids[g.rng.Intn(len(g.pids))]
+	}
+	g.sequenceID++
+	event.sequenceID = g.sequenceID
+	return event, g.options
 }
 
 // GenerateSystemLog - Zero-allocation 로그 생성 (핵심 성능 함수)
@@ -148,26 +344,25 @@ func (g *SystemLogGenerator) GenerateSystemLog() []byte {
 	buffer := logBufferPool.Get().([]byte)
 	buffer = buffer[:0] // 길이만 0으로 리셋
 	
-	// 빠른 인덱스 계산 (락 최소화)
-	g.rngMutex.Lock()
-	priorityIdx := g.rng.Intn(len(g.priorities))
-	hostnameIdx := g.rng.Intn(len(g.hostnames))
-	serviceIdx := g.rng.Intn(len(g.services))
-	pidIdx := g.rng.Intn(len(g.pids))
-	messageIdx := g.rng.Intn(len(g.messages))
-	g.rngMutex.Unlock()
+	event, options := g.pickEvent()
+	
+	// RFC 5424 형식은 별도 조립
+	if options.SyslogFormat == SyslogRFC5424 {
+		buffer = g.appendRFC5424(buffer, options, event)
+		result := make([]byte, len(buffer))
+		copy(result, buffer)
+		logBufferPool.Put(buffer)
+		return result
+	}
 	
 	// 타임스탬프 읽기
-	g.timestampMutex.RLock()
-	timestamp := g.timestampCache
-	g.timestampMutex.RUnlock()
+	timestamp := g.clock.RFC3164()
 	
 	// Zero-allocation 문자열 조립 (unsafe 사용으로 최적화)
-	priority := g.priorities[priorityIdx]
-	hostname := g.hostnames[hostnameIdx]
-	service := g.services[serviceIdx]
-	pid := g.pids[pidIdx]
-	message := g.messages[messageIdx]
+	priority := g.priority.priority(event.facility, event.severity)
+	hostname := g.hostnames[event.hostnameIdx]
+	service := g.services[event.serviceIdx]
+	pid := event.pid
 	
 	// 고속 바이트 슬라이스 조립 (append 사용, 할당 최소화)
 	buffer = append(buffer, priority...)
@@ -179,7 +374,7 @@ func (g *SystemLogGenerator) GenerateSystemLog() []byte {
 	buffer = append(buffer, '[')
 	buffer = append(buffer, pid...)
 	buffer = append(buffer, ']', ':', ' ')
-	buffer = append(buffer, message...)
+	buffer = g.appendMessage(buffer, event)
 	
 	// 복사본 생성 (호출자가 안전하게 사용할 수 있도록)
 	result := make([]byte, len(buffer))
@@ -191,34 +386,87 @@ func (g *SystemLogGenerator) GenerateSystemLog() []byte {
 	return result
 }
 
+// appendRFC5424 - RFC 5424 형식 조립
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [meta ...][origin ...] [BOM]MSG
+func (g *SystemLogGenerator) appendRFC5424(buffer []byte, options GeneratorOptions, event systemEvent) []byte {
+	buffer = append(buffer, g.priority.priority(event.facility, event.severity)...)
+	buffer = append(buffer, rfc5424Version...)
+	buffer = append(buffer, ' ')
+	buffer = g.clock.AppendRFC5424(buffer)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, g.hostnames[event.hostnameIdx]...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, g.services[event.serviceIdx]...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, event.pid...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, g.msgIDs[event.serviceIdx]...)
+	buffer = append(buffer, ' ')
+	
+	// STRUCTURED-DATA: sequenceId는 생성기 단위로 단조 증가 (RFC 5424 §7.3.1)
+	buffer = append(buffer, `[meta sequenceId="`...)
+	buffer = strconv.AppendUint(buffer, (event.sequenceID-1)%2147483647+1, 10)
+	buffer = append(buffer, `"]`...)
+	buffer = append(buffer, g.originSD[event.hostnameIdx]...)
+	buffer = append(buffer, ' ')
+	
+	if options.UseBOM {
+		buffer = append(buffer, utf8BOM...)
+	}
+	buffer = g.appendMessage(buffer, event)
+	
+	return buffer
+}
+
+// appendMessage - 이벤트 메시지 추가 (템플릿은 자리표시자를 채워 렌더링)
+func (g *SystemLogGenerator) appendMessage(buffer []byte, event systemEvent) []byte {
+	if g.templates == nil {
+		return append(buffer, g.messages[event.messageIdx]...)
+	}
+	
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+	return g.templates.render(buffer, event.messageIdx, g.rng)
+}
+
+// appendMessageJSON - 이벤트 메시지를 JSON 문자열로 추가 (ECS/GELF 템플릿 메시지용)
+func (g *SystemLogGenerator) appendMessageJSON(buffer []byte, event systemEvent) []byte {
+	message := g.appendMessage(getBuffer(), event)
+	buffer = appendJSONString(buffer, string(message))
+	logBufferPool.Put(message[:0])
+	return buffer
+}
+
 // GenerateSystemLogUnsafe - 최고 성능을 위한 unsafe 버전 (고급 사용자용)
 func (g *SystemLogGenerator) GenerateSystemLogUnsafe() []byte {
+	// RFC 5424와 템플릿 메시지는 일반 경로에서만 지원
+	if options := g.Options(); options.SyslogFormat == SyslogRFC5424 || options.Templates != nil {
+		return g.GenerateSystemLog()
+	}
+	
 	builder := builderPool.Get().(*strings.Builder)
 	builder.Reset()
 	
 	// 인덱스 계산 (최소한의 락)
-	g.rngMutex.Lock()
-	priorityIdx := g.rng.Intn(len(g.priorities))
-	hostnameIdx := g.rng.Intn(len(g.hostnames))
-	serviceIdx := g.rng.Intn(len(g.services))
-	pidIdx := g.rng.Intn(len(g.pids))
-	messageIdx := g.rng.Intn(len(g.messages))
-	g.rngMutex.Unlock()
+	event, _ := g.pickEvent()
+	hostnameIdx := event.hostnameIdx
+	serviceIdx := event.serviceIdx
+	pid := event.pid
+	messageIdx := event.messageIdx
+	priority := g.priority.priority(event.facility, event.severity)
 	
 	// 타임스탬프 읽기
-	g.timestampMutex.RLock()
-	timestamp := g.timestampCache
-	g.timestampMutex.RUnlock()
+	timestamp := g.clock.RFC3164()
 	
 	// 고속 문자열 조립
-	builder.WriteString(g.priorities[priorityIdx])
+	builder.WriteString(priority)
 	builder.WriteString(timestamp)
 	builder.WriteByte(' ')
 	builder.WriteString(g.hostnames[hostnameIdx])
 	builder.WriteByte(' ')
 	builder.WriteString(g.services[serviceIdx])
 	builder.WriteByte('[')
-	builder.WriteString(g.pids[pidIdx])
+	builder.WriteString(pid)
 	builder.WriteString("]: ")
 	builder.WriteString(g.messages[messageIdx])
 	
@@ -234,16 +482,15 @@ func (g *SystemLogGenerator) GenerateSystemLogUnsafe() []byte {
 
 // GetStats - 생성기 통계 정보
 func (g *SystemLogGenerator) GetStats() map[string]interface{} {
-	g.timestampMutex.RLock()
-	lastUpdate := g.lastTimestamp
-	g.timestampMutex.RUnlock()
+	lastUpdate := g.clock.Now()
 	
 	return map[string]interface{}{
-		"priorities_count": len(g.priorities),
+		"priorities_count": len(g.priority.priorities),
 		"hostnames_count":  len(g.hostnames),
 		"services_count":   len(g.services),
 		"messages_count":   len(g.messages),
+		"syslog_format":    string(g.Options().SyslogFormat),
 		"last_timestamp_update": lastUpdate,
-		"timestamp_cache": g.timestampCache,
+		"timestamp_cache": g.clock.RFC3164(),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// newTestSyslogGenerator - 지정한 syslog 형식의 생성기 (BOM 선택)
+func newTestSyslogGenerator(t *testing.T, format SyslogFormat, bom bool) *SystemLogGenerator {
+	t.Helper()
+	options := DefaultGeneratorOptions()
+	options.SyslogFormat = format
+	options.UseBOM = bom
+	gen, err := NewSystemLogGeneratorWithOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen
+}
+
+func TestSyslogRFC3164Framing(t *testing.T) {
+	gen := newTestSyslogGenerator(t, SyslogRFC3164, false)
+	pattern := regexp.MustCompile(`^<(\d{1,3})>\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z [\w.\-]+ [\w.\-]+\[\d+\]: \S.*$`)
+	for i := 0; i < 500; i++ {
+		message := gen.Generate()
+		m := pattern.FindSubmatch(message)
+		if m == nil {
+			t.Fatalf("RFC 3164 형식이 아님: %q", message)
+		}
+		if pri := atoiBytes(m[1]); pri > 191 {
+			t.Fatalf("PRI 범위 초과 %d: %q", pri, message)
+		}
+	}
+}
+
+func TestSyslogRFC5424Framing(t *testing.T) {
+	gen := newTestSyslogGenerator(t, SyslogRFC5424, true)
+	pattern := regexp.MustCompile(`^<(\d{1,3})>1 (\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.(\d{6})[+-]\d{2}:\d{2}) [\w.\-]+ [\w.\-]+ \d+ \S+ \[meta sequenceId="(\d+)"\]\[origin ip="[\d.]+" software="log-generator" swVersion="1\.0"\] \x{FEFF}\S`)
+
+	var last time.Time
+	fractions := map[string]bool{}
+	for i := 0; i < 500; i++ {
+		message := gen.Generate()
+		m := pattern.FindSubmatch(message)
+		if m == nil {
+			t.Fatalf("RFC 5424 형식이 아님: %q", message)
+		}
+		if pri := atoiBytes(m[1]); pri > 191 {
+			t.Fatalf("PRI 범위 초과 %d: %q", pri, message)
+		}
+		if seq := atoiBytes(m[4]); seq != i+1 {
+			t.Fatalf("sequenceId %d, 기대값 %d", seq, i+1)
+		}
+		// 타임스탬프는 RFC 3339로 읽히고 뒤로 가지 않아야 함
+		timestamp, err := time.Parse(time.RFC3339Nano, string(m[2]))
+		if err != nil {
+			t.Fatalf("타임스탬프 %q: %v", m[2], err)
+		}
+		if timestamp.Before(last) {
+			t.Fatalf("타임스탬프가 뒤로 감: %s → %s", last.Format(time.RFC3339Nano), m[2])
+		}
+		last = timestamp
+		fractions[string(m[3])] = true
+		if i%100 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	// 마이크로초는 메시지마다 실제 값 (초 단위 캐시의 고정 소수부가 아님)
+	if len(fractions) < 2 {
+		t.Fatalf("마이크로초 값이 모두 같음: %v", fractions)
+	}
+}
+
+func TestAppendRFC5424(t *testing.T) {
+	clock := getClock()
+	for i := 0; i < 3; i++ {
+		before := time.Now().Truncate(time.Microsecond)
+		stamp := clock.AppendRFC5424([]byte("x"))
+		after := time.Now()
+		if !bytes.HasPrefix(stamp, []byte("x")) {
+			t.Fatalf("버퍼 앞부분이 바뀜: %q", stamp)
+		}
+		got, err := time.Parse("2006-01-02T15:04:05.000000-07:00", string(stamp[1:]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Before(before) || got.After(after) {
+			t.Fatalf("타임스탬프 %s가 호출 시각 %s~%s 밖", stamp[1:], before.Format(time.RFC3339Nano), after.Format(time.RFC3339Nano))
+		}
+		time.Sleep(600 * time.Millisecond) // 초 경계를 넘는 경우 포함
+	}
+}
+
+func TestNewSystemLogGeneratorWithOptionsError(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.SyslogFormat = "rfc9999"
+	if gen, err := NewSystemLogGeneratorWithOptions(options); err == nil || gen != nil {
+		t.Fatalf("잘못된 옵션에 (%v, %v), 오류 기대", gen, err)
+	}
+	if _, err := NewFormatter("syslog", options); err == nil {
+		t.Fatal("잘못된 옵션에 syslog 포맷터가 만들어짐")
+	}
+}
+
+// atoiBytes - 정규식으로 확인한 10진수 바이트열을 정수로 변환
+func atoiBytes(digits []byte) int {
+	n := 0
+	for _, c := range digits {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// syslogPriorities - 사전 계산된 PRI 문자열 ("<0>" ~ "<191>")
+var syslogPriorities = func() (priorities [192]string) {
+	for i := range priorities {
+		priorities[i] = "<" + strconv.Itoa(i) + ">"
+	}
+	return priorities
+}()
+
+// 보안 이벤트 심각도(0-10, CEF/LEEF 공통) → syslog 심각도 (Low/Medium/High/Very-High)
+var scaleSyslogSeverity = [11]int{6, 6, 6, 6, 5, 5, 4, 3, 3, 2, 2}
+
+// syslogWrapper - CEF/LEEF 등 다른 형식의 본문을 syslog 헤더로 감싸는 공용 헬퍼
+//
+// UDPWorker는 로그 한 건을 syslog 메시지 한 줄로 전송하므로 보안 이벤트 형식도
+// 같은 경로로 전달하려면 RFC 3164/5424 헤더가 필요하다.
+type syslogWrapper struct {
+	format     SyslogFormat
+	useBOM     bool
+	hostnames  []string
+	hosts      []*InventoryHost // hostnames와 1:1 (인벤토리가 없으면 nil)
+	facilities cumulativeTable  // 퍼실리티 가중치 (심각도는 본문 형식에서 결정)
+	clock      *logClock
+}
+
+// newSyslogWrapper - 출력 옵션으로 헤더 헬퍼 생성
+func newSyslogWrapper(options GeneratorOptions) (*syslogWrapper, error) {
+	format, err := ParseSyslogFormat(string(options.SyslogFormat))
+	if err != nil {
+		return nil, err
+	}
+	facilities, err := facilityTable(options.Priority.FacilityWeights)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogWrapper{
+		format:     format,
+		useBOM:     options.UseBOM,
+		hostnames:  options.hostnames(),
+		hosts:      options.inventoryHosts(),
+		facilities: facilities,
+		clock:      getClock(),
+	}, nil
+}
+
+// pickHost - 호스트 인덱스 선택 (호출자가 rng 락 보유)
+func (w *syslogWrapper) pickHost(rng *rand.Rand) int {
+	return rng.Intn(len(w.hostnames))
+}
+
+// procID - 호스트의 서비스 PID (인벤토리가 있으면 재시작 전까지 고정, 없으면 fallback, 호출자가 rng 락 보유)
+func (w *syslogWrapper) procID(rng *rand.Rand, hostIdx int, app, fallback string) string {
+	if w.hosts == nil {
+		return fallback
+	}
+	return w.hosts[hostIdx].pid(app, w.clock.Now(), rng)
+}
+
+// pickFacility - 퍼실리티 선택 (호출자가 rng 락 보유)
+func (w *syslogWrapper) pickFacility(rng *rand.Rand) int {
+	return w.facilities.pick(rng)
+}
+
+// appendHeader - syslog 헤더 추가
+//
+// RFC 3164: <PRI>TIMESTAMP HOST [APP[PROCID]: ] (app이 비어 있으면 TAG 생략 - 보안 장비 관례)
+// RFC 5424: <PRI>1 TIMESTAMP HOST APP PROCID MSGID - [BOM]
+func (w *syslogWrapper) appendHeader(buffer []byte, facility, severity, hostIdx int, app, procID, msgID string) []byte {
+	buffer = append(buffer, syslogPriorities[facility*8+severity]...)
+
+	if w.format == SyslogRFC5424 {
+		buffer = append(buffer, rfc5424Version...)
+		buffer = append(buffer, ' ')
+		buffer = w.clock.AppendRFC5424(buffer)
+		buffer = append(buffer, ' ')
+		buffer = append(buffer, w.hostnames[hostIdx]...)
+		buffer = append(buffer, ' ')
+		buffer = appendNilValue(buffer, app)
+		buffer = append(buffer, ' ')
+		buffer = appendNilValue(buffer, procID)
+		buffer = append(buffer, ' ')
+		buffer = appendNilValue(buffer, msgID)
+		buffer = append(buffer, " - "...)
+		if w.useBOM {
+			buffer = append(buffer, utf8BOM...)
+		}
+		return buffer
+	}
+
+	buffer = append(buffer, w.clock.RFC3164()...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, w.hostnames[hostIdx]...)
+	buffer = append(buffer, ' ')
+	if app != "" {
+		buffer = append(buffer, app...)
+		if procID != "" {
+			buffer = append(buffer, '[')
+			buffer = append(buffer, procID...)
+			buffer = append(buffer, ']')
+		}
+		buffer = append(buffer, ':', ' ')
+	}
+	return buffer
+}
+
+// appendNilValue - 빈 값은 RFC 5424 NILVALUE('-')로 기록
+func appendNilValue(buffer []byte, value string) []byte {
+	if value == "" {
+		return append(buffer, '-')
+	}
+	return append(buffer, value...)
+}
+
+// syslogAppName - RFC 5424 APP-NAME 규칙에 맞게 정리 (공백 제거, 48자 제한)
+func syslogAppName(name string) string {
+	name = strings.Join(strings.Fields(name), "_")
+	if len(name) > 48 {
+		name = name[:48]
+	}
+	return name
+}
@@ -0,0 +1,248 @@
+package generator
+
+import "math/rand"
+
+// systemEventCategory - PRD §3.2.1 시스템 로그 카테고리 (카테고리 가중치 = 전체 비율 %)
+type systemEventCategory struct {
+	name   string
+	weight float64
+	events []systemEventTemplate
+}
+
+// systemEventTemplate - 서비스, 심각도, 메시지를 함께 정한 이벤트 (weight는 카테고리 내 비율)
+type systemEventTemplate struct {
+	service  string
+	severity int
+	message  string // SystemLogGenerator.messages 항목
+	weight   float64
+}
+
+// 심각도 코드 (severityNames 인덱스)
+const (
+	severityErr     = 3
+	severityWarning = 4
+	severityNotice  = 5
+	severityInfo    = 6
+)
+
+// systemEventCategories - 카테고리별 일관된 서비스/PRI/메시지 가중치 테이블
+var systemEventCategories = []systemEventCategory{
+	{name: "systemd", weight: 40, events: []systemEventTemplate{
+		{"systemd", severityInfo, "Starting nginx.service", 20},
+		{"systemd", severityInfo, "Started nginx.service", 15},
+		{"systemd", severityInfo, "Stopping nginx.service", 5},
+		{"systemd", severityInfo, "Starting docker.service", 20},
+		{"systemd", severityInfo, "Started docker.service", 15},
+		{"systemd", severityErr, "Unit entered failed state", 5},
+	}},
+	{name: "kernel", weight: 25, events: []systemEventTemplate{
+		{"kernel", severityWarning, "CPU0: temperature above threshold", 15},
+		{"kernel", severityErr, "Out of memory: Kill process", 3},
+		{"kernel", severityErr, "oom-killer: Killed process", 2},
+		{"kernel", severityInfo, "device eth0: link up", 5},
+		{"kernel", severityWarning, "TCP: Possible SYN flooding on port 80", 2},
+	}},
+	{name: "ssh", weight: 20, events: []systemEventTemplate{
+		{"sshd", severityInfo, "Accepted password for admin from 192.168.1.100", 15},
+		{"sshd", severityWarning, "Failed password for admin from 192.168.1.200", 5},
+		{"sshd", severityInfo, "pam_unix(sshd:session): session opened for user admin", 12},
+		{"sshd", severityInfo, "Connection closed by 192.168.1.100", 8},
+	}},
+	{name: "other", weight: 15, events: []systemEventTemplate{
+		{"cron", severityNotice, "(root) CMD (/usr/bin/updatedb)", 8},
+		{"rsyslog", severityInfo, "action 'action 17' suspended", 4},
+		{"NetworkManager", severityInfo, "device (eth0): state change", 3},
+		{"kubelet", severityWarning, "Certificate will expire", 1},
+		{"kubelet", severityWarning, "Disk space warning: /var partition at 85%", 1},
+	}},
+}
+
+// inventoryEventCategories - 인벤토리 역할 호스트에서만 쓰는 추가 이벤트
+//
+// 같은 이름의 카테고리에는 이벤트가 합쳐지고 새 이름은 카테고리로 추가된다.
+// 인벤토리가 없으면 사용하지 않으므로 기존 분포는 그대로 유지된다.
+var inventoryEventCategories = []systemEventCategory{
+	{name: "systemd", events: []systemEventTemplate{
+		{"systemd", severityInfo, "Starting mysqld.service", 20},
+		{"systemd", severityInfo, "Started mysqld.service", 15},
+		{"systemd", severityInfo, "Starting redis-server.service", 20},
+		{"systemd", severityInfo, "Started redis-server.service", 15},
+	}},
+	{name: "application", weight: 25, events: []systemEventTemplate{
+		{"nginx", severityErr, "upstream timed out (110: Connection timed out) while reading response header from upstream", 6},
+		{"nginx", severityWarning, "an upstream response is buffered to a temporary file", 4},
+		{"mysqld", severityWarning, "Aborted connection to db: 'app' user: 'app' (Got timeout reading communication packets)", 5},
+		{"mysqld", severityNotice, "InnoDB: Buffer pool(s) load completed", 3},
+		{"mysqld", severityWarning, "Slow query: Query_time 12.4 Lock_time 0.0 Rows_examined 1842211", 2},
+		{"redis-server", severityNotice, "Background saving started", 5},
+		{"redis-server", severityNotice, "DB saved on disk", 4},
+		{"redis-server", severityWarning, "Asynchronous AOF fsync is taking too long (disk is busy?)", 1},
+	}},
+}
+
+// systemEventRoles - 특정 역할 호스트에서만 나오는 이벤트 (메시지 → 역할)
+//
+// 서비스만으로 역할이 갈리지 않는 이벤트(systemd 유닛, 웹 포트 커널 경고)에 사용한다.
+var systemEventRoles = map[string]string{
+	"Starting nginx.service":                RoleWeb,
+	"Started nginx.service":                 RoleWeb,
+	"Stopping nginx.service":                RoleWeb,
+	"Starting docker.service":               RoleWeb,
+	"Started docker.service":                RoleWeb,
+	"TCP: Possible SYN flooding on port 80": RoleWeb,
+	"Starting mysqld.service":               RoleDB,
+	"Started mysqld.service":                RoleDB,
+	"Starting redis-server.service":         RoleCache,
+	"Started redis-server.service":          RoleCache,
+}
+
+// inventoryEventMessages - 인벤토리 추가 이벤트 메시지 (SystemLogGenerator.messages 뒤에 추가)
+func inventoryEventMessages() []string {
+	var messages []string
+	for _, category := range inventoryEventCategories {
+		for _, event := range category.events {
+			messages = append(messages, event.message)
+		}
+	}
+	return messages
+}
+
+// roleEventCategories - 역할 호스트에 적용할 카테고리 (기본 카테고리 + 인벤토리 추가 이벤트)
+func roleEventCategories() []systemEventCategory {
+	categories := make([]systemEventCategory, 0, len(systemEventCategories)+len(inventoryEventCategories))
+	for _, category := range systemEventCategories {
+		category.events = append([]systemEventTemplate(nil), category.events...)
+		categories = append(categories, category)
+	}
+	for _, extra := range inventoryEventCategories {
+		merged := false
+		for i := range categories {
+			if categories[i].name == extra.name {
+				categories[i].events = append(categories[i].events, extra.events...)
+				merged = true
+			}
+		}
+		if !merged {
+			categories = append(categories, extra)
+		}
+	}
+	return categories
+}
+
+// systemEventEntry - 생성기 풀 인덱스로 해석된 이벤트
+type systemEventEntry struct {
+	serviceIdx int
+	messageIdx int
+	severity   int
+}
+
+// systemEventSampler - 일관된 이벤트 선택기 (별칭 테이블)
+type systemEventSampler struct {
+	entries []systemEventEntry
+	table   aliasTable
+
+	// 심각도별 이벤트 선택기 (심각도 가중치를 지정했을 때 사용, 해당 이벤트가 없으면 빈 목록)
+	severityEntries [8][]int
+	severityTables  [8]aliasTable
+}
+
+// newSystemEventSampler - 서비스 풀에 있는 이벤트만으로 선택기 생성
+//
+// 사용자 서비스 목록에 없는 서비스의 이벤트는 제외하고 남은 카테고리끼리 비율을
+// 다시 맞춘다. 남는 이벤트가 없으면 nil을 반환하고 생성기는 독립 선택으로 돌아간다.
+// role이 주어지면 그 역할이 실행하는 서비스와 역할 전용 이벤트로 한정한다.
+func newSystemEventSampler(services, messages []string, role *inventoryRole) *systemEventSampler {
+	serviceIdx := make(map[string]int, len(services))
+	for i, service := range services {
+		if _, exists := serviceIdx[service]; !exists {
+			serviceIdx[service] = i
+		}
+	}
+	categories := systemEventCategories
+	if role != nil {
+		categories = roleEventCategories()
+		roleServices := make(map[string]bool, len(role.services))
+		for _, service := range role.services {
+			roleServices[service] = true
+		}
+		for service := range serviceIdx {
+			if !roleServices[service] {
+				delete(serviceIdx, service)
+			}
+		}
+	}
+	messageIdx := make(map[string]int, len(messages))
+	for i, message := range messages {
+		messageIdx[message] = i
+	}
+
+	sampler := &systemEventSampler{}
+	var weights []float64
+	for _, category := range categories {
+		total := 0.0
+		start := len(sampler.entries)
+		for _, event := range category.events {
+			sIdx, ok := serviceIdx[event.service]
+			if !ok {
+				continue
+			}
+			if eventRole, bound := systemEventRoles[event.message]; bound && role != nil && eventRole != role.name {
+				continue
+			}
+			sampler.entries = append(sampler.entries, systemEventEntry{
+				serviceIdx: sIdx,
+				messageIdx: messageIdx[event.message],
+				severity:   event.severity,
+			})
+			weights = append(weights, event.weight)
+			total += event.weight
+		}
+		// 카테고리 내 가중치를 카테고리 비율로 정규화
+		for i := start; i < len(weights); i++ {
+			weights[i] = category.weight * weights[i] / total
+		}
+	}
+	if len(sampler.entries) == 0 {
+		return nil
+	}
+
+	table, err := newAliasTable(weights, "시스템 이벤트")
+	if err != nil {
+		return nil
+	}
+	sampler.table = table
+
+	// 같은 심각도의 이벤트끼리 원래 비율을 유지하는 선택기
+	for severity := range sampler.severityTables {
+		var indexes []int
+		var severityWeights []float64
+		for i, entry := range sampler.entries {
+			if entry.severity == severity && weights[i] > 0 {
+				indexes = append(indexes, i)
+				severityWeights = append(severityWeights, weights[i])
+			}
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+		if sampler.severityTables[severity], err = newAliasTable(severityWeights, "시스템 이벤트"); err != nil {
+			return nil
+		}
+		sampler.severityEntries[severity] = indexes
+	}
+	return sampler
+}
+
+// pick - 이벤트 선택 (호출자가 rng 락 보유)
+func (s *systemEventSampler) pick(rng *rand.Rand) *systemEventEntry {
+	return &s.entries[s.table.pick(rng)]
+}
+
+// pickSeverity - 주어진 심각도의 이벤트 중에서 선택 (그런 이벤트가 없으면 nil, 호출자가 rng 락 보유)
+func (s *systemEventSampler) pickSeverity(rng *rand.Rand, severity int) *systemEventEntry {
+	indexes := s.severityEntries[severity]
+	if len(indexes) == 0 {
+		return nil
+	}
+	return &s.entries[indexes[s.severityTables[severity].pick(rng)]]
+}
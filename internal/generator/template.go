@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 템플릿 자리표시자 {{user}}가 고르는 사용자명 풀
+var templateUsers = []string{
+	"root", "admin", "ubuntu", "ec2-user", "deploy", "jenkins", "ansible",
+	"postgres", "mysql", "www-data", "backup", "git", "oracle", "test",
+	"kim.minsu", "lee.jiyoung", "park.junho", "choi.yuna", "svc-monitor",
+}
+
+// templatePart - 컴파일된 템플릿 조각 (리터럴 또는 자리표시자 함수)
+type templatePart func(buffer []byte, rng *rand.Rand) []byte
+
+// MessageTemplates - 파일에서 읽어 미리 컴파일한 가중치 메시지 템플릿 모음
+//
+// 한 줄이 템플릿 하나이며 "{{함수 인자...}}" 자리표시자를 생성 시점에 채운다.
+// 줄 맨 앞의 {{weight N}}은 선택 가중치(기본 1)이고 '#'으로 시작하는 줄은 주석이다.
+// 컴파일 결과는 읽기 전용이므로 여러 워커의 생성기가 공유한다.
+type MessageTemplates struct {
+	source  string
+	texts   []string // 원문 (가중치 지시자 제외)
+	parts   [][]templatePart
+	weights aliasTable
+}
+
+// LoadTemplateFile - 템플릿 파일 읽기 및 컴파일
+func LoadTemplateFile(path string) (*MessageTemplates, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+	return ParseTemplates(path, file)
+}
+
+// ParseTemplates - 템플릿 텍스트 컴파일 (source는 오류 메시지용 이름)
+func ParseTemplates(source string, reader io.Reader) (*MessageTemplates, error) {
+	templates := &MessageTemplates{source: source}
+	var weights []float64
+
+	scanner := bufio.NewScanner(reader)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		weight, text, err := parseTemplateWeight(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", source, lineNo, err)
+		}
+		parts, err := compileTemplate(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", source, lineNo, err)
+		}
+
+		weights = append(weights, weight)
+		templates.texts = append(templates.texts, text)
+		templates.parts = append(templates.parts, parts)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("템플릿 읽기 실패 (%s): %v", source, err)
+	}
+	if len(templates.parts) == 0 {
+		return nil, fmt.Errorf("템플릿이 없습니다: %s", source)
+	}
+
+	table, err := newAliasTable(weights, "템플릿")
+	if err != nil {
+		return nil, err
+	}
+	templates.weights = table
+	return templates, nil
+}
+
+// Source - 템플릿을 읽은 파일 경로
+func (t *MessageTemplates) Source() string {
+	return t.source
+}
+
+// Len - 템플릿 수
+func (t *MessageTemplates) Len() int {
+	return len(t.parts)
+}
+
+// pick - 가중치에 따라 템플릿 인덱스 선택
+func (t *MessageTemplates) pick(rng *rand.Rand) int {
+	return t.weights.pick(rng)
+}
+
+// render - 템플릿 하나를 채워 버퍼에 추가
+func (t *MessageTemplates) render(buffer []byte, idx int, rng *rand.Rand) []byte {
+	for _, part := range t.parts[idx] {
+		buffer = part(buffer, rng)
+	}
+	return buffer
+}
+
+// parseTemplateWeight - 줄 맨 앞의 {{weight N}} 지시자 분리
+func parseTemplateWeight(line string) (float64, string, error) {
+	if !strings.HasPrefix(line, "{{") {
+		return 1, line, nil
+	}
+	end := strings.Index(line, "}}")
+	if end < 0 {
+		return 0, "", fmt.Errorf("닫히지 않은 자리표시자")
+	}
+	args, err := splitTemplateArgs(line[2:end])
+	if err != nil {
+		return 0, "", err
+	}
+	if len(args) == 0 || args[0] != "weight" {
+		return 1, line, nil
+	}
+	if len(args) != 2 {
+		return 0, "", fmt.Errorf("weight는 인자 하나가 필요합니다")
+	}
+	weight, err := strconv.ParseFloat(args[1], 64)
+	if err != nil || weight <= 0 {
+		return 0, "", fmt.Errorf("잘못된 가중치: %s", args[1])
+	}
+	return weight, strings.TrimLeft(line[end+2:], " \t"), nil
+}
+
+// compileTemplate - 템플릿 한 줄을 리터럴/자리표시자 조각으로 컴파일
+func compileTemplate(text string) ([]templatePart, error) {
+	var parts []templatePart
+	rest := text
+	for rest != "" {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			parts = append(parts, literalPart(rest))
+			break
+		}
+		if start > 0 {
+			parts = append(parts, literalPart(rest[:start]))
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("닫히지 않은 자리표시자: %s", rest[start:])
+		}
+		args, err := splitTemplateArgs(rest[start+2 : start+end])
+		if err != nil {
+			return nil, err
+		}
+		part, err := compilePlaceholder(args)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		rest = rest[start+end+2:]
+	}
+	return parts, nil
+}
+
+// literalPart - 고정 문자열 조각
+func literalPart(text string) templatePart {
+	return func(buffer []byte, _ *rand.Rand) []byte {
+		return append(buffer, text...)
+	}
+}
+
+// splitTemplateArgs - 자리표시자 내용을 공백 기준으로 분리 (큰따옴표 인자는 Go 문자열 규칙)
+func splitTemplateArgs(text string) ([]string, error) {
+	var args []string
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("잘못된 문자열 인자: %s", rest)
+			}
+			value, _ := strconv.Unquote(quoted)
+			args = append(args, value)
+			rest = strings.TrimLeft(rest[len(quoted):], " \t")
+			continue
+		}
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		args = append(args, rest[:end])
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+	return args, nil
+}
+
+// compilePlaceholder - 자리표시자 함수 컴파일 (인자는 컴파일 시점에 검증)
+func compilePlaceholder(args []string) (templatePart, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("빈 자리표시자")
+	}
+	name, params := args[0], args[1:]
+
+	switch name {
+	case "user":
+		return func(buffer []byte, rng *rand.Rand) []byte {
+			return append(buffer, templateUsers[rng.Intn(len(templateUsers))]...)
+		}, nil
+
+	case "ipv4":
+		return compileIPv4(params)
+
+	case "int":
+		if len(params) != 2 {
+			return nil, fmt.Errorf("int는 최소/최대 인자가 필요합니다")
+		}
+		min, err1 := strconv.ParseInt(params[0], 10, 64)
+		max, err2 := strconv.ParseInt(params[1], 10, 64)
+		if err1 != nil || err2 != nil || max < min {
+			return nil, fmt.Errorf("잘못된 int 범위: %s %s", params[0], params[1])
+		}
+		// 범위 크기가 int64를 넘으면 뺄셈이 넘쳐 0 이하가 됨 (Int63n이 패닉)
+		span := max - min + 1
+		if span <= 0 {
+			return nil, fmt.Errorf("int 범위가 너무 큽니다: %s %s", params[0], params[1])
+		}
+		return func(buffer []byte, rng *rand.Rand) []byte {
+			return strconv.AppendInt(buffer, min+rng.Int63n(span), 10)
+		}, nil
+
+	case "choice":
+		if len(params) == 0 {
+			return nil, fmt.Errorf("choice는 선택지가 하나 이상 필요합니다")
+		}
+		return func(buffer []byte, rng *rand.Rand) []byte {
+			return append(buffer, params[rng.Intn(len(params))]...)
+		}, nil
+
+	case "uuid":
+		return appendUUID, nil
+
+	case "hex":
+		n := 16
+		if len(params) > 0 {
+			var err error
+			if n, err = strconv.Atoi(params[0]); err != nil || n <= 0 {
+				return nil, fmt.Errorf("잘못된 hex 길이: %s", params[0])
+			}
+		}
+		return func(buffer []byte, rng *rand.Rand) []byte {
+			return appendLowerHex(buffer, rng, n)
+		}, nil
+
+	case "timestamp":
+		layout := "rfc3339"
+		if len(params) > 0 {
+			layout = params[0]
+		}
+		return compileTimestamp(layout), nil
+
+	case "weight":
+		return nil, fmt.Errorf("weight는 줄 맨 앞에만 올 수 있습니다")
+	}
+	return nil, fmt.Errorf("알 수 없는 자리표시자 함수: %s (user, ipv4, int, choice, uuid, hex, timestamp)", name)
+}
+
+// ipv4Range - CIDR 대역의 호스트 주소 범위
+type ipv4Range struct {
+	base uint32
+	size int64
+}
+
+// compileIPv4 - {{ipv4 "CIDR" ...}} (인자가 없으면 사설 대역)
+func compileIPv4(params []string) (templatePart, error) {
+	if len(params) == 0 {
+		return func(buffer []byte, rng *rand.Rand) []byte {
+			return appendRandomIPv4(buffer, rng, 10)
+		}, nil
+	}
+
+	ranges := make([]ipv4Range, len(params))
+	for i, cidr := range params {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil || network.IP.To4() == nil {
+			return nil, fmt.Errorf("잘못된 IPv4 CIDR: %s", cidr)
+		}
+		ones, _ := network.Mask.Size()
+		ip := network.IP.To4()
+		r := ipv4Range{
+			base: uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3]),
+			size: int64(1) << (32 - ones),
+		}
+		// /30 이하 대역은 네트워크/브로드캐스트 주소 제외
+		if r.size > 2 {
+			r.base++
+			r.size -= 2
+		}
+		ranges[i] = r
+	}
+
+	return func(buffer []byte, rng *rand.Rand) []byte {
+		r := ranges[rng.Intn(len(ranges))]
+		addr := r.base + uint32(rng.Int63n(r.size))
+		buffer = strconv.AppendUint(buffer, uint64(addr>>24), 10)
+		buffer = append(buffer, '.')
+		buffer = strconv.AppendUint(buffer, uint64(addr>>16&0xff), 10)
+		buffer = append(buffer, '.')
+		buffer = strconv.AppendUint(buffer, uint64(addr>>8&0xff), 10)
+		buffer = append(buffer, '.')
+		return strconv.AppendUint(buffer, uint64(addr&0xff), 10)
+	}, nil
+}
+
+// compileTimestamp - {{timestamp 형식}} (rfc3339, rfc3164, clf, unix, unixms 또는 Go 레이아웃)
+func compileTimestamp(layout string) templatePart {
+	clock := getClock()
+	switch layout {
+	case "rfc3339":
+		return func(buffer []byte, _ *rand.Rand) []byte {
+			return clock.AppendRFC5424(buffer)
+		}
+	case "rfc3164":
+		return func(buffer []byte, _ *rand.Rand) []byte {
+			return clock.Now().AppendFormat(buffer, time.Stamp)
+		}
+	case "clf":
+		return func(buffer []byte, _ *rand.Rand) []byte {
+			return append(buffer, clock.CLF()...)
+		}
+	case "unix":
+		return func(buffer []byte, _ *rand.Rand) []byte {
+			return strconv.AppendInt(buffer, time.Now().Unix(), 10)
+		}
+	case "unixms":
+		return func(buffer []byte, _ *rand.Rand) []byte {
+			return strconv.AppendInt(buffer, time.Now().UnixMilli(), 10)
+		}
+	}
+	return func(buffer []byte, _ *rand.Rand) []byte {
+		return time.Now().AppendFormat(buffer, layout)
+	}
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"math/rand"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplatesRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		pattern  string // 렌더링 결과 전체가 맞아야 하는 정규식
+	}{
+		{"리터럴", "plain message", `plain message`},
+		{"user", "login {{user}} ok", `login [a-z0-9.\-]+ ok`},
+		{"int", "port={{int 1024 1030}}", `port=10(2[4-9]|30)`},
+		{"int 한 값", "{{int -5 -5}}", `-5`},
+		{"int 최대 범위", "{{int 1 9223372036854775807}}", `[1-9]\d*`},
+		{"choice", "{{choice GET POST \"PUT X\"}} /", `(GET|POST|PUT X) /`},
+		{"uuid", "id={{uuid}}", `id=[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`},
+		{"hex 기본", "{{hex}}", `[0-9a-f]{16}`},
+		{"hex 길이", "{{hex 6}}", `[0-9a-f]{6}`},
+		{"ipv4 기본", "{{ipv4}}", `\d+\.\d+\.\d+\.\d+`},
+		{"timestamp unix", "t={{timestamp unix}}", `t=\d{10}`},
+		{"timestamp Go 레이아웃", `{{timestamp "2006-01-02"}}`, `\d{4}-\d{2}-\d{2}`},
+		{"weight 지시자", "{{weight 3}} after weight", `after weight`},
+		{"연속 자리표시자", "{{int 1 1}}{{int 2 2}}x", `12x`},
+		{"닫는 괄호만", "a }} b", `a \}\} b`},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := ParseTemplates("test", strings.NewReader(tt.template))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if templates.Len() != 1 {
+				t.Fatalf("템플릿 %d개, 기대값 1개", templates.Len())
+			}
+			re := regexp.MustCompile("^" + tt.pattern + "$")
+			for i := 0; i < 50; i++ {
+				got := string(templates.render(nil, 0, rng))
+				if !re.MatchString(got) {
+					t.Fatalf("렌더링 결과 %q가 %s와 맞지 않음", got, tt.pattern)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTemplatesIPv4Range(t *testing.T) {
+	templates, err := ParseTemplates("test", strings.NewReader(`{{ipv4 "192.0.2.0/30" "198.51.100.7/32"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, network, _ := net.ParseCIDR("192.0.2.0/30")
+	seen := map[string]bool{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		got := string(templates.render(nil, 0, rng))
+		seen[got] = true
+		if got == "198.51.100.7" {
+			continue
+		}
+		if !network.Contains(net.ParseIP(got)) {
+			t.Fatalf("대역 밖 주소: %s", got)
+		}
+		// 네트워크/브로드캐스트 주소 제외
+		if got == "192.0.2.0" || got == "192.0.2.3" {
+			t.Fatalf("네트워크/브로드캐스트 주소가 선택됨: %s", got)
+		}
+	}
+	for _, want := range []string{"192.0.2.1", "192.0.2.2", "198.51.100.7"} {
+		if !seen[want] {
+			t.Fatalf("%s가 한 번도 선택되지 않음", want)
+		}
+	}
+}
+
+func TestParseTemplatesWeights(t *testing.T) {
+	source := strings.Join([]string{
+		"# 주석",
+		"",
+		"{{weight 3}} heavy",
+		"   ",
+		"light\r",
+		"  # 들여쓴 주석",
+	}, "\n")
+	templates, err := ParseTemplates("test", strings.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if templates.Len() != 2 {
+		t.Fatalf("템플릿 %d개, 기대값 2개", templates.Len())
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	const draws = 40000
+	for i := 0; i < draws; i++ {
+		counts[string(templates.render(nil, templates.pick(rng), rng))]++
+	}
+	if len(counts) != 2 || counts["heavy"]+counts["light"] != draws {
+		t.Fatalf("예상하지 못한 렌더링 결과: %v", counts)
+	}
+	if got := float64(counts["heavy"]) / draws; got < 0.73 || got > 0.77 {
+		t.Fatalf("heavy 빈도 %.3f, 기대값 0.75", got)
+	}
+}
+
+func TestParseTemplatesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string // 오류 메시지에 포함되어야 하는 문자열
+	}{
+		{"빈 파일", "# 주석만\n\n", "템플릿이 없습니다"},
+		{"닫히지 않음", "a {{user", "test:1: 닫히지 않은 자리표시자"},
+		{"빈 자리표시자", "{{ }}", "빈 자리표시자"},
+		{"알 수 없는 함수", "ok\n{{nope}}", "test:2: 알 수 없는 자리표시자 함수: nope"},
+		{"int 인자 부족", "{{int 1}}", "int는 최소/최대 인자가 필요합니다"},
+		{"int 역순", "{{int 5 1}}", "잘못된 int 범위"},
+		{"int 숫자 아님", "{{int a 1}}", "잘못된 int 범위"},
+		{"int 범위 넘침", "{{int 0 9223372036854775807}}", "int 범위가 너무 큽니다"},
+		{"int 전체 범위", "{{int -9223372036854775808 9223372036854775807}}", "int 범위가 너무 큽니다"},
+		{"int 음수에서 넘침", "{{int -2 9223372036854775806}}", "int 범위가 너무 큽니다"},
+		{"choice 비어 있음", "{{choice}}", "choice는 선택지가 하나 이상 필요합니다"},
+		{"hex 0", "{{hex 0}}", "잘못된 hex 길이"},
+		{"ipv4 CIDR 아님", `{{ipv4 "10.0.0.1"}}`, "잘못된 IPv4 CIDR"},
+		{"ipv4 IPv6", `{{ipv4 "2001:db8::/32"}}`, "잘못된 IPv4 CIDR"},
+		{"weight 0", "{{weight 0}} x", "잘못된 가중치"},
+		{"weight 인자 없음", "{{weight}} x", "weight는 인자 하나가 필요합니다"},
+		{"weight 줄 중간", "x {{weight 2}}", "weight는 줄 맨 앞에만 올 수 있습니다"},
+		{"닫히지 않은 문자열", `{{choice "a}}`, "잘못된 문자열 인자"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplates("test", strings.NewReader(tt.template))
+			if err == nil {
+				t.Fatal("오류를 기대함")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("오류 %q에 %q가 없음", err, tt.want)
+			}
+		})
+	}
+}
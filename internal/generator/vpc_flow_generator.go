@@ -0,0 +1,473 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VPC 인벤토리 시드 (워커별 생성기가 같은 ENI/인스턴스 ID를 사용하도록 고정)
+const vpcInventorySeed = 20240102
+
+// VPC 흐름 로그 집계 간격 (초)
+const vpcFlowAggregation = 60
+
+// vpcFlowField - 흐름 로그 필드와 도입 버전
+type vpcFlowField struct {
+	name    string
+	version int
+}
+
+// 지원 필드 (앞의 14개가 v2 기본 형식)
+var vpcFlowFields = []vpcFlowField{
+	{"version", 2}, {"account-id", 2}, {"interface-id", 2}, {"srcaddr", 2}, {"dstaddr", 2},
+	{"srcport", 2}, {"dstport", 2}, {"protocol", 2}, {"packets", 2}, {"bytes", 2},
+	{"start", 2}, {"end", 2}, {"action", 2}, {"log-status", 2},
+	{"vpc-id", 3}, {"subnet-id", 3}, {"instance-id", 3}, {"tcp-flags", 3}, {"type", 3},
+	{"pkt-srcaddr", 3}, {"pkt-dstaddr", 3},
+	{"region", 4}, {"az-id", 4}, {"sublocation-type", 4}, {"sublocation-id", 4},
+	{"pkt-src-aws-service", 5}, {"pkt-dst-aws-service", 5}, {"flow-direction", 5}, {"traffic-path", 5},
+}
+
+const vpcFlowDefaultFieldCount = 14
+
+// VPCFlowOptions - VPC 흐름 로그 출력 옵션
+type VPCFlowOptions struct {
+	// vpcflow_custom 형식의 필드 목록 (비어 있으면 v2~v5 전체 필드).
+	// version 필드 값은 AWS와 같이 지정한 필드 중 가장 높은 버전이 된다.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Validate - 필드 이름 검증
+func (o VPCFlowOptions) Validate() error {
+	_, err := vpcFlowFieldIndexes(o.Fields)
+	return err
+}
+
+// vpcFlowFieldIndexes - 필드 이름 → vpcFlowFields 인덱스
+func vpcFlowFieldIndexes(names []string) ([]int, error) {
+	indexes := make([]int, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("VPC 흐름 로그 필드가 중복되었습니다: %s", name)
+		}
+		seen[name] = true
+
+		index := -1
+		for i, field := range vpcFlowFields {
+			if field.name == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("지원하지 않는 VPC 흐름 로그 필드: %s", name)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// vpcRole - ENI 역할
+type vpcRole int
+
+const (
+	vpcRoleWeb vpcRole = iota // 퍼블릭 서브넷, 443 수신
+	vpcRoleApp                // 프라이빗 서브넷, 8080 수신
+	vpcRoleDB                 // 프라이빗 서브넷 RDS (instance-id 없음)
+)
+
+// vpcENI - 네트워크 인터페이스
+type vpcENI struct {
+	account   string
+	vpc       string
+	subnet    string
+	az        string
+	instance  string // RDS는 "-"
+	eni       string
+	ip        string
+	role      vpcRole
+	natEgress bool // 프라이빗 서브넷 (인터넷 송신은 NAT 게이트웨이 경유)
+}
+
+// 계정당 역할별 ENI 수
+var vpcRoleCounts = []struct {
+	role  vpcRole
+	count int
+}{
+	{vpcRoleWeb, 4}, {vpcRoleApp, 6}, {vpcRoleDB, 2},
+}
+
+// 서울 리전 가용 영역 ID
+var vpcAZIDs = []string{"apne2-az1", "apne2-az3"}
+
+// 흐름 종류
+const (
+	vpcFlowWebIngress = iota // 인터넷 → web:443
+	vpcFlowEgress            // 인스턴스 → 인터넷/S3 :443
+	vpcFlowEastWest          // web → app:8080, app → db:5432
+	vpcFlowOnPrem            // app → 사내 DNS (VPN 경유 UDP 53)
+	vpcFlowScan              // 인터넷 포트 스캔 (REJECT)
+	vpcFlowNoData            // 집계 구간 동안 트래픽 없음
+)
+
+var vpcFlowKindWeights = map[int]float64{
+	vpcFlowWebIngress: 35, vpcFlowEgress: 20, vpcFlowEastWest: 28,
+	vpcFlowOnPrem: 6, vpcFlowScan: 10, vpcFlowNoData: 1,
+}
+
+// 스캔 대상 포트
+var vpcScanPorts = []int{22, 23, 80, 445, 1433, 3306, 3389, 5900, 6379, 8080, 8443, 9200}
+
+// 수락된 TCP 흐름의 tcp-flags (OR 누적: 19 = SYN|ACK|FIN, 3 = SYN|FIN, 18 = SYN-ACK, 1 = FIN, 4 = RST)
+var vpcAcceptedTCPFlags = []int{19, 19, 19, 19, 3, 3, 18, 1, 4}
+
+// vpcFlowRecord - 렌더링할 흐름 레코드
+type vpcFlowRecord struct {
+	eni              *vpcENI
+	src, dst         string
+	srcPort, dstPort int
+	protocol         int
+	packets, bytes   int
+	start, end       int64
+	accepted         bool
+	tcpFlags         int
+	egress           bool
+	path             string // traffic-path (egress만)
+	srcService       string
+	dstService       string
+	noData           bool
+}
+
+// VPCFlowGenerator - AWS VPC 흐름 로그 생성기
+//
+// vpcflow는 v2 기본 형식(공백 구분 14개 필드)을, vpcflow_custom은 지정한 필드 목록을
+// 출력한다. ENI 인벤토리는 고정 시드로 합성되며, 계정 ID는 CloudTrail 생성기와 같다.
+type VPCFlowGenerator struct {
+	name    string
+	fields  []int
+	version string
+	enis    []vpcENI
+	perENIs int // 계정당 ENI 수 (enis는 계정 → 역할 순서)
+	kinds   cumulativeTable
+	clients []string
+	scanner []string
+	clock   *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newVPCFlowGenerator - VPC 흐름 로그 생성기 초기화 (custom = 사용자 지정 필드)
+func newVPCFlowGenerator(name string, custom bool, options GeneratorOptions) (*VPCFlowGenerator, error) {
+	var fields []int
+	switch {
+	case !custom:
+		for i := 0; i < vpcFlowDefaultFieldCount; i++ {
+			fields = append(fields, i)
+		}
+	case len(options.VPCFlow.Fields) == 0:
+		for i := range vpcFlowFields {
+			fields = append(fields, i)
+		}
+	default:
+		var err error
+		if fields, err = vpcFlowFieldIndexes(options.VPCFlow.Fields); err != nil {
+			return nil, err
+		}
+	}
+	version := 2
+	for _, index := range fields {
+		if vpcFlowFields[index].version > version {
+			version = vpcFlowFields[index].version
+		}
+	}
+
+	kinds, err := newCumulativeTable(vpcFlowKindWeights, "VPC 흐름 종류")
+	if err != nil {
+		return nil, err
+	}
+	perAccount := 0
+	for _, count := range vpcRoleCounts {
+		perAccount += count.count
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &VPCFlowGenerator{
+		name:    name,
+		fields:  fields,
+		version: strconv.Itoa(version),
+		enis:    newVPCInventory(),
+		perENIs: perAccount,
+		kinds:   kinds,
+		clients: newIPPool(rng, []string{"211.36", "121.134", "175.223", "58.120"}, 256),
+		scanner: newIPPool(rng, []string{"45.155", "185.220", "167.94"}, 16),
+		clock:   getClock(),
+		rng:     rng,
+	}, nil
+}
+
+// newVPCInventory - 계정별 VPC/서브넷/ENI 합성 (고정 시드)
+func newVPCInventory() []vpcENI {
+	rng := rand.New(rand.NewSource(vpcInventorySeed))
+	var enis []vpcENI
+	for a, account := range awsAccountIDs {
+		vpc := "vpc-" + string(appendLowerHex(nil, rng, 17))
+		// 서브넷: [퍼블릭, 프라이빗] × 가용 영역
+		var subnets [2][]string
+		for private := range subnets {
+			for range vpcAZIDs {
+				subnets[private] = append(subnets[private], "subnet-"+string(appendLowerHex(nil, rng, 17)))
+			}
+		}
+
+		host := 10
+		for _, count := range vpcRoleCounts {
+			for i := 0; i < count.count; i++ {
+				az := i % len(vpcAZIDs)
+				private := 0
+				if count.role != vpcRoleWeb {
+					private = 1
+				}
+				eni := vpcENI{
+					account:   account,
+					vpc:       vpc,
+					subnet:    subnets[private][az],
+					az:        vpcAZIDs[az],
+					instance:  "i-" + string(appendLowerHex(nil, rng, 17)),
+					eni:       "eni-" + string(appendLowerHex(nil, rng, 17)),
+					ip:        "10." + strconv.Itoa(10+a) + "." + strconv.Itoa(private*10+az) + "." + strconv.Itoa(host),
+					role:      count.role,
+					natEgress: private == 1,
+				}
+				if count.role == vpcRoleDB {
+					eni.instance = "-"
+				}
+				enis = append(enis, eni)
+				host++
+			}
+		}
+	}
+	return enis
+}
+
+func init() {
+	RegisterFormatter("vpcflow", func(options GeneratorOptions) (LogFormatter, error) {
+		return newVPCFlowGenerator("vpcflow", false, options)
+	})
+	RegisterFormatter("vpcflow_custom", func(options GeneratorOptions) (LogFormatter, error) {
+		return newVPCFlowGenerator("vpcflow_custom", true, options)
+	})
+}
+
+// Name - LogFormatter 구현
+func (g *VPCFlowGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+func (g *VPCFlowGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	record := g.nextRecord()
+	for i, field := range g.fields {
+		if i > 0 {
+			buffer = append(buffer, ' ')
+		}
+		buffer = g.appendField(buffer, field, &record)
+	}
+	return finishBuffer(buffer)
+}
+
+// pickENI - 계정 내 역할별 ENI 선택
+func (g *VPCFlowGenerator) pickENI(account int, role vpcRole) *vpcENI {
+	index := account * g.perENIs
+	for _, count := range vpcRoleCounts {
+		if count.role == role {
+			return &g.enis[index+g.rng.Intn(count.count)]
+		}
+		index += count.count
+	}
+	return &g.enis[index]
+}
+
+// nextRecord - 흐름 하나 선택 (ENI 관점의 방향 포함, 호출자가 락 보유)
+func (g *VPCFlowGenerator) nextRecord() vpcFlowRecord {
+	account := skewedIndex(g.rng, len(awsAccountIDs))
+	end := g.clock.Now().Unix() - int64(g.rng.Intn(5))
+	record := vpcFlowRecord{
+		protocol: 6,
+		srcPort:  32768 + g.rng.Intn(28232), // 리눅스 임시 포트 범위
+		start:    end - int64(1+g.rng.Intn(vpcFlowAggregation-1)),
+		end:      end,
+		accepted: true,
+		packets:  logUniform(g.rng, 1, 5000),
+	}
+	record.bytes = record.packets * logUniform(g.rng, 60, 1400)
+	record.tcpFlags = vpcAcceptedTCPFlags[g.rng.Intn(len(vpcAcceptedTCPFlags))]
+
+	switch g.kinds.pick(g.rng) {
+	case vpcFlowWebIngress:
+		record.eni = g.pickENI(account, vpcRoleWeb)
+		record.src, record.dst = g.clients[skewedIndex(g.rng, len(g.clients))], record.eni.ip
+		record.dstPort = 443
+
+	case vpcFlowEgress:
+		record.eni = g.pickENI(account, vpcRoleApp)
+		if g.rng.Intn(2) == 0 {
+			record.eni = g.pickENI(account, vpcRoleWeb)
+		}
+		record.src, record.egress, record.dstPort = record.eni.ip, true, 443
+		switch {
+		case g.rng.Intn(3) == 0:
+			// S3 게이트웨이 엔드포인트
+			record.dst, record.dstService, record.path = "52.219.60."+strconv.Itoa(1+g.rng.Intn(254)), "S3", "7"
+		case record.eni.natEgress:
+			record.dst, record.path = g.clients[g.rng.Intn(len(g.clients))], "1"
+		default:
+			record.dst, record.path = g.clients[g.rng.Intn(len(g.clients))], "8"
+		}
+
+	case vpcFlowEastWest:
+		source, target, port := g.pickENI(account, vpcRoleWeb), g.pickENI(account, vpcRoleApp), 8080
+		if g.rng.Intn(3) == 0 {
+			source, target, port = target, g.pickENI(account, vpcRoleDB), 5432
+		}
+		record.src, record.dst, record.dstPort = source.ip, target.ip, port
+		// 같은 흐름이 양쪽 ENI에 기록되므로 한쪽을 선택
+		record.eni = target
+		if g.rng.Intn(2) == 0 {
+			record.eni, record.egress, record.path = source, true, "1"
+		}
+
+	case vpcFlowOnPrem:
+		record.eni = g.pickENI(account, vpcRoleApp)
+		record.src, record.dst = record.eni.ip, "10.100.0.53"
+		record.dstPort, record.protocol = 53, 17
+		record.egress, record.path = true, "3"
+		record.packets = 1 + g.rng.Intn(4)
+		record.bytes = record.packets * (60 + g.rng.Intn(90))
+
+	case vpcFlowScan:
+		record.eni = g.pickENI(account, vpcRoleWeb)
+		record.src, record.dst = g.scanner[g.rng.Intn(len(g.scanner))], record.eni.ip
+		record.dstPort = vpcScanPorts[g.rng.Intn(len(vpcScanPorts))]
+		record.accepted, record.tcpFlags = false, 2
+		record.packets = 1 + g.rng.Intn(2)
+		record.bytes = record.packets * 44
+		return record
+
+	default:
+		record.eni = &g.enis[g.rng.Intn(len(g.enis))]
+		record.noData = true
+		return record
+	}
+
+	if record.protocol != 6 {
+		record.tcpFlags = 0
+	}
+	// 응답 방향 레코드 (같은 ENI에서 반대 방향으로 집계)
+	if g.rng.Intn(10) < 4 {
+		record.src, record.dst = record.dst, record.src
+		record.srcPort, record.dstPort = record.dstPort, record.srcPort
+		record.srcService, record.dstService = record.dstService, record.srcService
+		record.egress = !record.egress
+		switch {
+		case !record.egress:
+			record.path = ""
+		case record.eni.natEgress || strings.HasPrefix(record.dst, "10."):
+			record.path = "1"
+		default:
+			record.path = "8"
+		}
+	}
+	return record
+}
+
+// appendField - 필드 하나 (값이 없으면 "-")
+func (g *VPCFlowGenerator) appendField(buffer []byte, field int, record *vpcFlowRecord) []byte {
+	eni := record.eni
+	switch vpcFlowFields[field].name {
+	case "version":
+		return append(buffer, g.version...)
+	case "account-id":
+		return append(buffer, eni.account...)
+	case "interface-id":
+		return append(buffer, eni.eni...)
+	case "vpc-id":
+		return append(buffer, eni.vpc...)
+	case "subnet-id":
+		return append(buffer, eni.subnet...)
+	case "instance-id":
+		return append(buffer, eni.instance...)
+	case "start":
+		return strconv.AppendInt(buffer, record.start, 10)
+	case "end":
+		return strconv.AppendInt(buffer, record.end, 10)
+	case "region":
+		return append(buffer, cloudTrailHomeRegion...)
+	case "az-id":
+		return append(buffer, eni.az...)
+	case "log-status":
+		if record.noData {
+			return append(buffer, "NODATA"...)
+		}
+		return append(buffer, "OK"...)
+	}
+
+	if record.noData {
+		return append(buffer, '-')
+	}
+	switch vpcFlowFields[field].name {
+	case "srcaddr", "pkt-srcaddr":
+		return append(buffer, record.src...)
+	case "dstaddr", "pkt-dstaddr":
+		return append(buffer, record.dst...)
+	case "srcport":
+		return strconv.AppendInt(buffer, int64(record.srcPort), 10)
+	case "dstport":
+		return strconv.AppendInt(buffer, int64(record.dstPort), 10)
+	case "protocol":
+		return strconv.AppendInt(buffer, int64(record.protocol), 10)
+	case "packets":
+		return strconv.AppendInt(buffer, int64(record.packets), 10)
+	case "bytes":
+		return strconv.AppendInt(buffer, int64(record.bytes), 10)
+	case "action":
+		if record.accepted {
+			return append(buffer, "ACCEPT"...)
+		}
+		return append(buffer, "REJECT"...)
+	case "tcp-flags":
+		return strconv.AppendInt(buffer, int64(record.tcpFlags), 10)
+	case "type":
+		return append(buffer, "IPv4"...)
+	case "pkt-src-aws-service":
+		return appendOrDash(buffer, record.srcService)
+	case "pkt-dst-aws-service":
+		return appendOrDash(buffer, record.dstService)
+	case "flow-direction":
+		if record.egress {
+			return append(buffer, "egress"...)
+		}
+		return append(buffer, "ingress"...)
+	case "traffic-path":
+		return appendOrDash(buffer, record.path)
+	}
+	// sublocation-type, sublocation-id (Local Zone/Outposts 아님)
+	return append(buffer, '-')
+}
+
+// appendOrDash - 값 또는 "-"
+func appendOrDash(buffer []byte, value string) []byte {
+	if value == "" {
+		return append(buffer, '-')
+	}
+	return append(buffer, value...)
+}
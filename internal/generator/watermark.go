@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+// Watermark - 종단 간 손실 집계용 메시지 워터마크
+//
+// 수신 측이나 SIEM 검색에서 (Run, Worker)별로 Seq를 모으면 유실(빈 번호)과
+// 중복(같은 번호)을 정확히 찾을 수 있다.
+type Watermark struct {
+	Run    string // 실행 ID
+	Worker int    // 워커 ID
+	Seq    uint64 // 워커별 일련번호 (1부터 단조 증가)
+}
+
+// WatermarkSDID - RFC 5424 워터마크 SD-ID (32473은 문서용 예약 PEN, RFC 5612)
+const WatermarkSDID = "lg@32473"
+
+// 워터마크 키 (SD 밖에서는 lg_ 접두어를 붙임)
+const (
+	watermarkKeyRun    = "run"
+	watermarkKeyWorker = "worker"
+	watermarkKeySeq    = "seq"
+	watermarkKeyPrefix = "lg_"
+)
+
+// RFC 5424 PRI 뒤의 "VERSION SP"
+var rfc5424VersionPrefix = []byte(rfc5424Version + " ")
+
+// watermarkStyleSamples - 워터마크 위치 규칙별 대표 메시지 (RFC 5424 NILVALUE SD, JSON, Windows XML, 텍스트)
+var watermarkStyleSamples = [][]byte{
+	[]byte("<13>1 - - - - - -"),
+	[]byte(`{"a":1}`),
+	append([]byte("<Event><EventData>"), xmlEventDataEnd...),
+	[]byte("x"),
+}
+
+// MaxWatermarkSize - 실행 ID가 run이고 워커 ID가 maxWorker 이하일 때 워터마크가 메시지에 더하는 최대 바이트 수
+//
+// 워커는 생성기가 크기를 맞춘 메시지에 워터마크를 붙이므로, 생성기에 넘기는 메시지 한도에서 이 값을 뺀다.
+func MaxWatermarkSize(run string, maxWorker int) int {
+	mark := Watermark{Run: run, Worker: maxWorker, Seq: math.MaxUint64}
+	size := 0
+	for _, sample := range watermarkStyleSamples {
+		size = max(size, len(AppendWatermark(nil, sample, mark))-len(sample))
+	}
+	return size
+}
+
+// AppendWatermark - message에 워터마크를 넣어 buffer 뒤에 추가
+//
+// RFC 5424 메시지는 STRUCTURED-DATA의 첫 요소로 [lg@32473 run="…" worker="…" seq="…"]를
+// 넣는다. 그 외에는 크기 패딩과 같은 위치에 넣는다. JSON은 마지막 '}' 앞에
+// "lg_run","lg_worker","lg_seq" 필드, Windows XML은 </EventData> 앞에 <Data> 요소,
+// 텍스트는 첫 줄 끝에 " lg_run=… lg_worker=… lg_seq=…"를 붙인다.
+func AppendWatermark(buffer, message []byte, mark Watermark) []byte {
+	if pos := rfc5424SDOffset(message); pos >= 0 {
+		buffer = append(buffer, message[:pos]...)
+		buffer = appendWatermarkSD(buffer, mark)
+		if message[pos] == '-' {
+			pos++ // NILVALUE는 워터마크 요소로 대체
+		}
+		return append(buffer, message[pos:]...)
+	}
+
+	style, pos := paddingPosition(message)
+	buffer = append(buffer, message[:pos]...)
+	switch style {
+	case paddingJSON:
+		buffer = append(buffer, `,"`+watermarkKeyPrefix+watermarkKeyRun+`":"`...)
+		buffer = append(buffer, mark.Run...)
+		buffer = append(buffer, `","`+watermarkKeyPrefix+watermarkKeyWorker+`":`...)
+		buffer = strconv.AppendInt(buffer, int64(mark.Worker), 10)
+		buffer = append(buffer, `,"`+watermarkKeyPrefix+watermarkKeySeq+`":`...)
+		buffer = strconv.AppendUint(buffer, mark.Seq, 10)
+	case paddingXML:
+		buffer = append(buffer, "<Data Name='"+watermarkKeyPrefix+watermarkKeyRun+"'>"...)
+		buffer = append(buffer, mark.Run...)
+		buffer = append(buffer, "</Data><Data Name='"+watermarkKeyPrefix+watermarkKeyWorker+"'>"...)
+		buffer = strconv.AppendInt(buffer, int64(mark.Worker), 10)
+		buffer = append(buffer, "</Data><Data Name='"+watermarkKeyPrefix+watermarkKeySeq+"'>"...)
+		buffer = strconv.AppendUint(buffer, mark.Seq, 10)
+		buffer = append(buffer, "</Data>"...)
+	default:
+		buffer = append(buffer, " "+watermarkKeyPrefix+watermarkKeyRun+"="...)
+		buffer = append(buffer, mark.Run...)
+		buffer = append(buffer, " "+watermarkKeyPrefix+watermarkKeyWorker+"="...)
+		buffer = strconv.AppendInt(buffer, int64(mark.Worker), 10)
+		buffer = append(buffer, " "+watermarkKeyPrefix+watermarkKeySeq+"="...)
+		buffer = strconv.AppendUint(buffer, mark.Seq, 10)
+	}
+	return append(buffer, message[pos:]...)
+}
+
+// appendWatermarkSD - RFC 5424 SD-ELEMENT 형태의 워터마크 추가
+func appendWatermarkSD(buffer []byte, mark Watermark) []byte {
+	buffer = append(buffer, "["+WatermarkSDID+" "+watermarkKeyRun+`="`...)
+	buffer = append(buffer, mark.Run...)
+	buffer = append(buffer, `" `+watermarkKeyWorker+`="`...)
+	buffer = strconv.AppendInt(buffer, int64(mark.Worker), 10)
+	buffer = append(buffer, `" `+watermarkKeySeq+`="`...)
+	buffer = strconv.AppendUint(buffer, mark.Seq, 10)
+	return append(buffer, `"]`...)
+}
+
+// rfc5424SDOffset - RFC 5424 메시지의 STRUCTURED-DATA 시작 위치 (RFC 5424가 아니면 -1)
+//
+// "<PRI>1 TIMESTAMP HOST APP PROCID MSGID " 뒤가 '-' 또는 '['인지 확인한다.
+func rfc5424SDOffset(message []byte) int {
+	if len(message) == 0 || message[0] != '<' {
+		return -1
+	}
+	end := bytes.IndexByte(message[:min(len(message), 5)], '>') // PRI는 최대 3자리
+	if end < 2 {
+		return -1
+	}
+	pos := end + 1
+	if !bytes.HasPrefix(message[pos:], rfc5424VersionPrefix) {
+		return -1
+	}
+	pos += len(rfc5424VersionPrefix)
+	for field := 0; field < 5; field++ {
+		i := bytes.IndexByte(message[pos:], ' ')
+		if i <= 0 {
+			return -1
+		}
+		pos += i + 1
+	}
+	if pos >= len(message) {
+		return -1
+	}
+	switch message[pos] {
+	case '-':
+		if pos+1 == len(message) || message[pos+1] == ' ' {
+			return pos
+		}
+	case '[':
+		return pos
+	}
+	return -1
+}
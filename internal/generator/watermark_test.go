@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAppendWatermark(t *testing.T) {
+	mark := Watermark{Run: "0badc0de", Worker: 3, Seq: 42}
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			"RFC 5424 NILVALUE",
+			"<13>1 2026-10-17T00:00:00Z host app 1 - - hello",
+			`<13>1 2026-10-17T00:00:00Z host app 1 - [lg@32473 run="0badc0de" worker="3" seq="42"] hello`,
+		},
+		{
+			"RFC 5424 SD 앞",
+			`<13>1 2026-10-17T00:00:00Z host app 1 ID1 [origin ip="10.0.0.1"] hello`,
+			`<13>1 2026-10-17T00:00:00Z host app 1 ID1 [lg@32473 run="0badc0de" worker="3" seq="42"][origin ip="10.0.0.1"] hello`,
+		},
+		{
+			"RFC 5424 메시지 없음",
+			"<13>1 2026-10-17T00:00:00Z host app 1 - -",
+			`<13>1 2026-10-17T00:00:00Z host app 1 - [lg@32473 run="0badc0de" worker="3" seq="42"]`,
+		},
+		{
+			"RFC 3164",
+			"<13>Oct 17 00:00:00 host app[1]: hello",
+			"<13>Oct 17 00:00:00 host app[1]: hello lg_run=0badc0de lg_worker=3 lg_seq=42",
+		},
+		{
+			"JSON",
+			`{"message":"hello"}`,
+			`{"message":"hello","lg_run":"0badc0de","lg_worker":3,"lg_seq":42}`,
+		},
+		{
+			"Windows XML",
+			"<Event><EventData><Data Name='a'>1</Data></EventData></Event>",
+			"<Event><EventData><Data Name='a'>1</Data><Data Name='lg_run'>0badc0de</Data><Data Name='lg_worker'>3</Data><Data Name='lg_seq'>42</Data></EventData></Event>",
+		},
+		{
+			"멀티라인은 첫 줄 끝",
+			"summary\n\tat frame",
+			"summary lg_run=0badc0de lg_worker=3 lg_seq=42\n\tat frame",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(AppendWatermark([]byte("prefix:"), []byte(tt.message), mark))
+			if got != "prefix:"+tt.want {
+				t.Fatalf("AppendWatermark =\n%s\n기대값\n%s", got, "prefix:"+tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxWatermarkSize(t *testing.T) {
+	const run, maxWorker = "0badc0de", 999
+	limit := MaxWatermarkSize(run, maxWorker)
+	messages := []string{
+		"<13>1 2026-10-17T00:00:00Z host app 1 - - hello",
+		"<13>Oct 17 00:00:00 host app[1]: hello",
+		`{"message":"hello"}`,
+		"<Event><EventData></EventData></Event>",
+		"summary\n\tat frame",
+	}
+	for _, worker := range []int{1, maxWorker} {
+		for _, seq := range []uint64{1, math.MaxUint64} {
+			mark := Watermark{Run: run, Worker: worker, Seq: seq}
+			for _, message := range messages {
+				if grown := len(AppendWatermark(nil, []byte(message), mark)) - len(message); grown > limit {
+					t.Fatalf("워커 %d 번호 %d %q: %d바이트 증가, 최대값 %d", worker, seq, message, grown, limit)
+				}
+			}
+		}
+	}
+}
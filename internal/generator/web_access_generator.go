@@ -0,0 +1,427 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// webLogStyle - 웹 서버 접근 로그 레이아웃
+type webLogStyle int
+
+const (
+	webApacheCommon   webLogStyle = iota // %h %l %u %t "%r" %>s %b
+	webApacheCombined                    // common + "%{Referer}i" "%{User-agent}i"
+	webNginx                             // nginx 기본 log_format combined
+	webNginxTiming                       // combined + rt/uct/urt (upstream 타이밍)
+	webIIS                               // IIS W3C 확장 로그 기본 필드
+)
+
+// IIS W3C 헤더 지시문 (수신측 파서가 필드 순서를 알 수 있도록 주기적으로 전송)
+const (
+	iisFieldsDirective       = "#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs-username c-ip cs(User-Agent) cs(Referer) sc-status sc-substatus sc-win32-status time-taken"
+	defaultIISHeaderInterval = 1000
+)
+
+// WebAccessOptions - 웹 접근 로그 옵션
+type WebAccessOptions struct {
+	// 상태 코드 클래스 가중치 ("2xx", "3xx", "4xx", "5xx"), 비어 있으면 기본값
+	StatusWeights map[string]float64 `json:"status_weights,omitempty"`
+	// IIS #Fields 지시문 간격 (줄 수, 0이면 기본값 1000)
+	IISHeaderInterval int `json:"iis_header_interval,omitempty"`
+}
+
+// 기본 상태 코드 클래스 분포 (운영 웹 서버 평균)
+var defaultStatusWeights = map[string]float64{
+	"2xx": 80,
+	"3xx": 10,
+	"4xx": 8,
+	"5xx": 2,
+}
+
+// 클래스 내 상태 코드 분포
+var statusCodesByClass = map[int]map[int]float64{
+	2: {200: 90, 201: 3, 204: 5, 206: 2},
+	3: {301: 15, 302: 25, 304: 60},
+	4: {400: 10, 401: 15, 403: 15, 404: 55, 429: 5},
+	5: {500: 45, 502: 25, 503: 20, 504: 10},
+}
+
+// Validate - 웹 접근 로그 옵션 검증
+func (o WebAccessOptions) Validate() error {
+	if o.IISHeaderInterval < 0 {
+		return fmt.Errorf("IIS #Fields 지시문 간격은 0 이상이어야 합니다: %d", o.IISHeaderInterval)
+	}
+	_, err := statusClassTable(o.StatusWeights)
+	return err
+}
+
+// headerInterval - IIS #Fields 지시문 간격 (기본값 적용)
+func (o WebAccessOptions) headerInterval() int64 {
+	if o.IISHeaderInterval > 0 {
+		return int64(o.IISHeaderInterval)
+	}
+	return defaultIISHeaderInterval
+}
+
+// IsDirective - W3C 지시문 줄(#Fields 등)인지 확인
+//
+// 지시문은 로그 레코드가 아니므로 워터마크/패딩/퍼징 대상에서 제외한다.
+func IsDirective(message []byte) bool {
+	return len(message) > 0 && message[0] == '#'
+}
+
+func statusClassTable(weights map[string]float64) (cumulativeTable, error) {
+	if len(weights) == 0 {
+		weights = defaultStatusWeights
+	}
+	byClass := make(map[int]float64, len(weights))
+	for name, weight := range weights {
+		if len(name) != 3 || name[1:] != "xx" || name[0] < '2' || name[0] > '5' {
+			return cumulativeTable{}, fmt.Errorf("잘못된 상태 코드 클래스: %s (2xx, 3xx, 4xx, 5xx)", name)
+		}
+		if weight < 0 {
+			return cumulativeTable{}, fmt.Errorf("상태 코드 가중치는 0 이상이어야 합니다: %s", name)
+		}
+		byClass[int(name[0]-'0')] += weight
+	}
+	return newCumulativeTable(byClass, "상태 코드")
+}
+
+// webResource - 요청 대상 리소스 템플릿 ('#' = 숫자, '$' = 검색어)
+type webResource struct {
+	path    string
+	query   string
+	dynamic bool // POST/PUT/DELETE 등 변경 요청 허용 여부
+	minSize int
+	maxSize int
+	minMs   int
+	maxMs   int
+}
+
+var webResources = []webResource{
+	{"/", "", false, 4000, 30000, 2, 40},
+	{"/index.html", "", false, 4000, 30000, 1, 20},
+	{"/static/js/app.#.js", "", false, 40000, 900000, 1, 15},
+	{"/static/css/main.#.css", "", false, 8000, 120000, 1, 10},
+	{"/images/product-#.jpg", "", false, 20000, 2000000, 1, 30},
+	{"/favicon.ico", "", false, 1000, 16000, 1, 5},
+	{"/api/v1/users/#", "", true, 200, 3000, 5, 250},
+	{"/api/v1/orders", "page=#&size=20&sort=created_at", true, 500, 40000, 10, 800},
+	{"/api/v1/products/#/reviews", "limit=#", true, 300, 20000, 8, 400},
+	{"/search", "q=$&page=#", false, 2000, 60000, 20, 1500},
+	{"/login", "", true, 1500, 8000, 5, 200},
+	{"/logout", "next=/", true, 200, 1000, 2, 50},
+	{"/health", "", false, 2, 64, 1, 3},
+	{"/wp-login.php", "", true, 200, 4000, 1, 30},
+	{"/.env", "", false, 150, 500, 1, 5},
+}
+
+var webMethods = []struct {
+	method string
+	weight float64
+}{
+	{"GET", 80}, {"POST", 12}, {"HEAD", 3}, {"PUT", 2}, {"DELETE", 1}, {"OPTIONS", 1}, {"PATCH", 1},
+}
+
+var webSearchTerms = []string{
+	"laptop", "usb-c+cable", "monitor", "keyboard", "gift+card", "%EB%85%B8%ED%8A%B8%EB%B6%81", "sale",
+}
+
+var webReferrers = []string{
+	"-", "-", "-",
+	"https://www.google.com/",
+	"https://www.bing.com/search?q=shop",
+	"https://shop.example.com/",
+	"https://shop.example.com/search?q=monitor",
+	"https://m.facebook.com/",
+}
+
+// WebAccessGenerator - Apache/Nginx/IIS 접근 로그 생성기
+type WebAccessGenerator struct {
+	name  string
+	style webLogStyle
+
+	clientIPs []string
+	serverIPs []string
+	statuses  cumulativeTable         // 상태 코드 클래스 (2..5)
+	codes     map[int]cumulativeTable // 클래스별 상태 코드
+	methods   cumulativeTable
+
+	lineCount      int64
+	headerInterval int64 // IIS #Fields 지시문 간격 (줄 수)
+	clock          *logClock
+	rng            *rand.Rand
+	rngMutex       sync.Mutex
+}
+
+// newWebAccessGenerator - 레이아웃별 접근 로그 생성기 초기화
+func newWebAccessGenerator(name string, style webLogStyle, options GeneratorOptions) (*WebAccessGenerator, error) {
+	statuses, err := statusClassTable(options.Web.StatusWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[int]cumulativeTable, len(statusCodesByClass))
+	for class, weights := range statusCodesByClass {
+		if codes[class], err = newCumulativeTable(weights, "상태 코드"); err != nil {
+			return nil, err
+		}
+	}
+
+	methodWeights := make(map[int]float64, len(webMethods))
+	for i, m := range webMethods {
+		methodWeights[i] = m.weight
+	}
+	methods, err := newCumulativeTable(methodWeights, "HTTP 메서드")
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gen := &WebAccessGenerator{
+		name:      name,
+		style:     style,
+		clientIPs: newIPPool(rng, []string{"203.0", "198.51", "121.134", "211.36", "58.120", "175.223", "66.249"}, 4096),
+		serverIPs: newIPPool(rng, []string{"10.10"}, len(options.hostnames())),
+		statuses:  statuses,
+		codes:     codes,
+		methods:   methods,
+		clock:     getClock(),
+		rng:       rng,
+
+		headerInterval: options.Web.headerInterval(),
+	}
+	// 인벤토리가 있으면 웹 역할 호스트의 실제 주소를 서버 IP로 사용
+	if ips := options.inventoryIPs(); ips != nil {
+		gen.serverIPs = ips
+	}
+	return gen, nil
+}
+
+func init() {
+	register := func(name string, style webLogStyle) {
+		RegisterFormatter(name, func(options GeneratorOptions) (LogFormatter, error) {
+			return newWebAccessGenerator(name, style, options)
+		})
+	}
+	register("apache", webApacheCombined)
+	register("apache_common", webApacheCommon)
+	register("nginx", webNginx)
+	register("nginx_timing", webNginxTiming)
+	register("iis", webIIS)
+}
+
+// Name - LogFormatter 구현
+func (g *WebAccessGenerator) Name() string {
+	return g.name
+}
+
+// webRequest - 한 건의 요청에 대해 선택된 값
+type webRequest struct {
+	clientIP  string
+	user      string
+	method    string
+	resource  *webResource
+	pathNum   int
+	queryNum  int
+	term      string
+	status    int
+	size      int
+	durMs     int
+	referrer  string
+	userAgent string
+	serverIdx int
+}
+
+// pick - 요청 값 선택 (호출자가 rng 락 보유)
+func (g *WebAccessGenerator) pick() webRequest {
+	req := webRequest{
+		clientIP:  g.clientIPs[skewedIndex(g.rng, len(g.clientIPs))],
+		user:      "-",
+		method:    webMethods[g.methods.pick(g.rng)].method,
+		resource:  &webResources[g.rng.Intn(len(webResources))],
+		pathNum:   1 + g.rng.Intn(99999),
+		queryNum:  1 + g.rng.Intn(50),
+		term:      webSearchTerms[g.rng.Intn(len(webSearchTerms))],
+		referrer:  webReferrers[g.rng.Intn(len(webReferrers))],
+		userAgent: commonUserAgents[skewedIndex(g.rng, len(commonUserAgents))],
+		serverIdx: g.rng.Intn(len(g.serverIPs)),
+	}
+	if !req.resource.dynamic && req.method != "HEAD" {
+		// 정적 리소스는 조회 요청만 받음
+		req.method = "GET"
+	}
+	if g.rng.Intn(20) == 0 {
+		req.user = commonUsernames[g.rng.Intn(len(commonUsernames))]
+	}
+
+	// 상태 코드: 클래스 선택 후 클래스 내 코드 선택
+	req.status = g.codes[g.statuses.pick(g.rng)].pick(g.rng)
+
+	// 응답 크기와 처리 시간 (상태 코드에 따라 보정)
+	res := req.resource
+	switch {
+	case req.method == "HEAD" || req.status == 204 || req.status == 304:
+		req.size = 0
+		req.durMs = logUniform(g.rng, 1, 10)
+	case req.status >= 500:
+		req.size = logUniform(g.rng, 150, 600)
+		req.durMs = logUniform(g.rng, 200, 30000)
+	case req.status >= 300:
+		req.size = logUniform(g.rng, 150, 800)
+		req.durMs = logUniform(g.rng, 1, 40)
+	default:
+		req.size = logUniform(g.rng, res.minSize, res.maxSize)
+		req.durMs = logUniform(g.rng, res.minMs, res.maxMs)
+	}
+	return req
+}
+
+// appendTemplate - '#'과 '$' 자리표시자를 채워 추가
+func appendTemplate(buffer []byte, template string, num int, term string) []byte {
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '#':
+			buffer = strconv.AppendInt(buffer, int64(num), 10)
+		case '$':
+			buffer = append(buffer, term...)
+		default:
+			buffer = append(buffer, template[i])
+		}
+	}
+	return buffer
+}
+
+// appendRequestURI - 경로 + 쿼리 문자열
+func appendRequestURI(buffer []byte, req *webRequest) []byte {
+	buffer = appendTemplate(buffer, req.resource.path, req.pathNum, req.term)
+	if req.resource.query != "" {
+		buffer = append(buffer, '?')
+		buffer = appendTemplate(buffer, req.resource.query, req.queryNum, req.term)
+	}
+	return buffer
+}
+
+// Generate - LogFormatter 구현
+func (g *WebAccessGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	g.rngMutex.Lock()
+	req := g.pick()
+	g.lineCount++
+	lineCount := g.lineCount
+	g.rngMutex.Unlock()
+
+	if g.style == webIIS {
+		// 수신측 필드 매핑을 위해 첫 줄과 주기적으로 #Fields 지시문 전송
+		if (lineCount-1)%g.headerInterval == 0 {
+			buffer = append(buffer, iisFieldsDirective...)
+			return finishBuffer(buffer)
+		}
+		buffer = g.appendIIS(buffer, &req)
+		return finishBuffer(buffer)
+	}
+
+	// %h %l %u [%t] "%r" %>s %b
+	buffer = append(buffer, req.clientIP...)
+	buffer = append(buffer, " - "...)
+	buffer = append(buffer, req.user...)
+	buffer = append(buffer, " ["...)
+	buffer = append(buffer, g.clock.CLF()...)
+	buffer = append(buffer, "] \""...)
+	buffer = append(buffer, req.method...)
+	buffer = append(buffer, ' ')
+	buffer = appendRequestURI(buffer, &req)
+	buffer = append(buffer, " HTTP/1.1\" "...)
+	buffer = strconv.AppendInt(buffer, int64(req.status), 10)
+	buffer = append(buffer, ' ')
+	if req.size == 0 && (g.style == webApacheCommon || g.style == webApacheCombined) {
+		// Apache %b는 0바이트를 '-'로 기록
+		buffer = append(buffer, '-')
+	} else {
+		buffer = strconv.AppendInt(buffer, int64(req.size), 10)
+	}
+
+	if g.style == webApacheCommon {
+		return finishBuffer(buffer)
+	}
+
+	// combined: "%{Referer}i" "%{User-agent}i"
+	buffer = append(buffer, " \""...)
+	buffer = append(buffer, req.referrer...)
+	buffer = append(buffer, "\" \""...)
+	buffer = append(buffer, req.userAgent...)
+	buffer = append(buffer, '"')
+
+	if g.style == webNginxTiming {
+		// rt=$request_time uct="$upstream_connect_time" urt="$upstream_response_time"
+		buffer = append(buffer, " rt="...)
+		buffer = appendSeconds(buffer, req.durMs)
+		buffer = append(buffer, " uct=\"0.00"...)
+		buffer = strconv.AppendInt(buffer, int64(1+req.durMs%9), 10)
+		buffer = append(buffer, "\" urt=\""...)
+		buffer = appendSeconds(buffer, req.durMs*9/10)
+		buffer = append(buffer, '"')
+	}
+
+	return finishBuffer(buffer)
+}
+
+// appendIIS - W3C 확장 로그 한 줄 (공백 구분, 값 내 공백은 '+')
+func (g *WebAccessGenerator) appendIIS(buffer []byte, req *webRequest) []byte {
+	buffer = append(buffer, g.clock.W3C()...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, g.serverIPs[req.serverIdx]...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, req.method...)
+	buffer = append(buffer, ' ')
+	buffer = appendTemplate(buffer, req.resource.path, req.pathNum, req.term)
+	buffer = append(buffer, ' ')
+	if req.resource.query != "" {
+		buffer = appendTemplate(buffer, req.resource.query, req.queryNum, req.term)
+	} else {
+		buffer = append(buffer, '-')
+	}
+	buffer = append(buffer, " 443 "...)
+	buffer = append(buffer, req.user...)
+	buffer = append(buffer, ' ')
+	buffer = append(buffer, req.clientIP...)
+	buffer = append(buffer, ' ')
+	buffer = appendReplacingSpaces(buffer, req.userAgent)
+	buffer = append(buffer, ' ')
+	buffer = appendReplacingSpaces(buffer, req.referrer)
+	buffer = append(buffer, ' ')
+	buffer = strconv.AppendInt(buffer, int64(req.status), 10)
+	buffer = append(buffer, " 0 0 "...)
+	buffer = strconv.AppendInt(buffer, int64(req.durMs), 10)
+	return buffer
+}
+
+// appendReplacingSpaces - IIS 규칙대로 공백을 '+'로 치환
+func appendReplacingSpaces(buffer []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		if value[i] == ' ' {
+			buffer = append(buffer, '+')
+		} else {
+			buffer = append(buffer, value[i])
+		}
+	}
+	return buffer
+}
+
+// appendSeconds - 밀리초를 "초.밀리초" 형식으로 추가 (nginx $request_time)
+func appendSeconds(buffer []byte, ms int) []byte {
+	buffer = strconv.AppendInt(buffer, int64(ms/1000), 10)
+	buffer = append(buffer, '.')
+	frac := ms % 1000
+	if frac < 100 {
+		buffer = append(buffer, '0')
+	}
+	if frac < 10 {
+		buffer = append(buffer, '0')
+	}
+	return strconv.AppendInt(buffer, int64(frac), 10)
+}
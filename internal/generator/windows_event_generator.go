@@ -0,0 +1,688 @@
+package generator
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Windows 보안 감사 공통 상수
+const (
+	winProviderName = "Microsoft-Windows-Security-Auditing"
+	winProviderGUID = "{54849625-5478-4994-A5BA-3E3B0328C30D}"
+	winDomain       = "CORP"
+	winDNSSuffix    = ".corp.example.com"
+	winDomainSID    = "S-1-5-21-3623811015-3361044348-30300820"
+	winLSASSPid     = 636 // 보안 이벤트를 기록하는 lsass.exe PID
+	winSessionCap   = 1024
+)
+
+// winRendering - Windows 이벤트 출력 형식
+type winRendering int
+
+const (
+	winSnare winRendering = iota // Snare MSWinEventLog 탭 구분 (syslog 헤더 포함)
+	winXML                       // 이벤트 뷰어 XML (<Event>...<EventData>)
+	winJSON                      // Winlogbeat 스타일 JSON
+)
+
+// winEventType - 이벤트 ID별 메타데이터
+type winEventType struct {
+	id       int
+	version  int
+	task     string // 작업 범주 (Snare CategoryString, winlog.task)
+	taskCode int
+	success  bool // Audit Success / Audit Failure
+	message  string
+	action   string   // winlogbeat event.action
+	category []string // ECS event.category
+	kind     []string // ECS event.type
+	weight   float64
+	build    func(g *WindowsEventGenerator, ctx *winContext)
+}
+
+// winField - EventData 항목 (순서 유지)
+type winField struct {
+	name  string
+	value string
+}
+
+// winContext - 이벤트 한 건 생성 상태 (필드 슬라이스 재사용)
+type winContext struct {
+	hostIdx int
+	fields  []winField
+	user    string // Snare UserName 필드
+}
+
+func (c *winContext) add(name, value string) {
+	c.fields = append(c.fields, winField{name, value})
+}
+
+// winSession - 로그온 세션 (4624 이후 4634/4672/4688/5140이 같은 LogonId를 참조)
+type winSession struct {
+	userIdx   int
+	logonID   string
+	logonType int
+	ip        string
+	hostIdx   int
+}
+
+// winProcess - 프로세스 생성(4688) 후보
+type winProcess struct {
+	path    string
+	command string
+	parent  string
+}
+
+var winProcesses = []winProcess{
+	{`C:\Windows\System32\cmd.exe`, `cmd.exe /c whoami /all`, `C:\Windows\explorer.exe`},
+	{`C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe`, `powershell.exe -NoProfile -ExecutionPolicy Bypass -File C:\Scripts\backup.ps1`, `C:\Windows\System32\svchost.exe`},
+	{`C:\Windows\System32\svchost.exe`, `C:\Windows\system32\svchost.exe -k netsvcs -p -s Schedule`, `C:\Windows\System32\services.exe`},
+	{`C:\Program Files\Google\Chrome\Application\chrome.exe`, `"C:\Program Files\Google\Chrome\Application\chrome.exe" --type=renderer`, `C:\Windows\explorer.exe`},
+	{`C:\Windows\System32\net.exe`, `net user /domain`, `C:\Windows\System32\cmd.exe`},
+	{`C:\Windows\System32\rundll32.exe`, `rundll32.exe C:\Users\Public\update.dll,Start`, `C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe`},
+	{`C:\Windows\System32\wbem\WmiPrvSE.exe`, `C:\Windows\system32\wbem\wmiprvse.exe -Embedding`, `C:\Windows\System32\svchost.exe`},
+}
+
+var winShares = []struct{ name, path string }{
+	{`\\*\IPC$`, ``},
+	{`\\*\SYSVOL`, `C:\Windows\SYSVOL\sysvol`},
+	{`\\*\NETLOGON`, `C:\Windows\SYSVOL\sysvol\corp.example.com\SCRIPTS`},
+	{`\\*\Finance`, `D:\Shares\Finance`},
+	{`\\*\C$`, `C:\`},
+}
+
+// 4625 실패 사유 (Status/SubStatus 조합)
+var winLogonFailures = []struct{ reason, status, subStatus string }{
+	{"%%2313", "0xC000006D", "0xC000006A"}, // 잘못된 암호
+	{"%%2313", "0xC000006D", "0xC0000064"}, // 존재하지 않는 사용자
+	{"%%2307", "0xC0000234", "0x0"},        // 계정 잠김
+	{"%%2309", "0xC0000071", "0x0"},        // 암호 만료
+}
+
+// LogonType별 로그온 프로세스/인증 패키지
+var winLogonTypes = []struct {
+	logonType int
+	process   string
+	auth      string
+	weight    float64
+}{
+	{3, "NtLmSsp ", "NTLM", 45},
+	{3, "Kerberos", "Kerberos", 25},
+	{2, "User32 ", "Negotiate", 10},
+	{10, "User32 ", "Negotiate", 8},
+	{5, "Advapi  ", "Negotiate", 10},
+	{4, "Advapi  ", "Negotiate", 2},
+}
+
+var winPrivileges = "SeSecurityPrivilege\n\t\t\tSeBackupPrivilege\n\t\t\tSeRestorePrivilege\n\t\t\tSeTakeOwnershipPrivilege\n\t\t\tSeDebugPrivilege\n\t\t\tSeSystemEnvironmentPrivilege\n\t\t\tSeLoadDriverPrivilege\n\t\t\tSeImpersonatePrivilege"
+
+var winEventTypes = []winEventType{
+	{
+		id: 4624, version: 2, task: "Logon", taskCode: 12544, success: true,
+		message: "An account was successfully logged on.", action: "logged-in",
+		category: []string{"authentication"}, kind: []string{"start"}, weight: 30,
+		build: (*WindowsEventGenerator).buildLogon,
+	},
+	{
+		id: 4625, version: 0, task: "Logon", taskCode: 12544, success: false,
+		message: "An account failed to log on.", action: "logon-failed",
+		category: []string{"authentication"}, kind: []string{"start"}, weight: 8,
+		build: (*WindowsEventGenerator).buildLogonFailure,
+	},
+	{
+		id: 4634, version: 0, task: "Logoff", taskCode: 12545, success: true,
+		message: "An account was logged off.", action: "logged-out",
+		category: []string{"authentication"}, kind: []string{"end"}, weight: 25,
+		build: (*WindowsEventGenerator).buildLogoff,
+	},
+	{
+		id: 4648, version: 0, task: "Logon", taskCode: 12544, success: true,
+		message: "A logon was attempted using explicit credentials.", action: "logged-in-explicit",
+		category: []string{"authentication"}, kind: []string{"start"}, weight: 4,
+		build: (*WindowsEventGenerator).buildExplicitLogon,
+	},
+	{
+		id: 4672, version: 0, task: "Special Logon", taskCode: 12548, success: true,
+		message: "Special privileges assigned to new logon.", action: "logged-in-special",
+		category: []string{"iam"}, kind: []string{"admin"}, weight: 10,
+		build: (*WindowsEventGenerator).buildSpecialLogon,
+	},
+	{
+		id: 4688, version: 2, task: "Process Creation", taskCode: 13312, success: true,
+		message: "A new process has been created.", action: "created-process",
+		category: []string{"process"}, kind: []string{"start"}, weight: 15,
+		build: (*WindowsEventGenerator).buildProcessCreation,
+	},
+	{
+		id: 4720, version: 0, task: "User Account Management", taskCode: 13824, success: true,
+		message: "A user account was created.", action: "added-user-account",
+		category: []string{"iam"}, kind: []string{"user", "creation"}, weight: 1,
+		build: (*WindowsEventGenerator).buildUserCreated,
+	},
+	{
+		id: 4740, version: 0, task: "User Account Management", taskCode: 13824, success: true,
+		message: "A user account was locked out.", action: "locked-out-user-account",
+		category: []string{"iam"}, kind: []string{"user", "change"}, weight: 1,
+		build: (*WindowsEventGenerator).buildLockout,
+	},
+	{
+		id: 5140, version: 1, task: "File Share", taskCode: 12808, success: true,
+		message: "A network share object was accessed.", action: "network-share-object-accessed",
+		category: []string{"network", "file"}, kind: []string{"access"}, weight: 6,
+		build: (*WindowsEventGenerator).buildShareAccess,
+	},
+}
+
+// WindowsEventGenerator - Windows 보안 이벤트 생성기 (Snare/XML/Winlogbeat JSON)
+type WindowsEventGenerator struct {
+	name      string
+	rendering winRendering
+	header    *syslogWrapper
+
+	computers []string // NetBIOS 이름 (대문자)
+	fqdns     []string // DNS 이름
+	userSIDs  []string // commonUsernames와 1:1
+	clientIPs []string
+	events    cumulativeTable
+	logons    cumulativeTable
+
+	sessions   []winSession // 활성 세션 링 (최대 winSessionCap)
+	nextLogon  uint64
+	recordID   uint64
+	newUserSeq int
+	ctx        winContext
+	clock      *logClock
+
+	rng      *rand.Rand
+	rngMutex sync.Mutex
+}
+
+// newWindowsEventGenerator - 출력 형식별 Windows 이벤트 생성기 초기화
+func newWindowsEventGenerator(name string, rendering winRendering, options GeneratorOptions) (*WindowsEventGenerator, error) {
+	header, err := newSyslogWrapper(options)
+	if err != nil {
+		return nil, err
+	}
+
+	eventWeights := make(map[int]float64, len(winEventTypes))
+	for i, event := range winEventTypes {
+		eventWeights[i] = event.weight
+	}
+	events, err := newCumulativeTable(eventWeights, "Windows 이벤트")
+	if err != nil {
+		return nil, err
+	}
+	logonWeights := make(map[int]float64, len(winLogonTypes))
+	for i, logon := range winLogonTypes {
+		logonWeights[i] = logon.weight
+	}
+	logons, err := newCumulativeTable(logonWeights, "LogonType")
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := options.hostnames()
+	gen := &WindowsEventGenerator{
+		name:      name,
+		rendering: rendering,
+		header:    header,
+		computers: make([]string, len(hostnames)),
+		fqdns:     make([]string, len(hostnames)),
+		userSIDs:  make([]string, len(commonUsernames)),
+		events:    events,
+		logons:    logons,
+		sessions:  make([]winSession, 0, winSessionCap),
+		nextLogon: 0x3e7 + 0x1000,
+		clock:     getClock(),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for i, hostname := range hostnames {
+		gen.computers[i] = strings.ToUpper(hostname)
+		gen.fqdns[i] = strings.ToLower(hostname) + winDNSSuffix
+	}
+	for i := range commonUsernames {
+		gen.userSIDs[i] = winDomainSID + "-" + strconv.Itoa(1104+i)
+	}
+	gen.clientIPs = newIPPool(gen.rng, []string{"10.1", "10.2", "10.50"}, 256)
+	return gen, nil
+}
+
+func init() {
+	register := func(name string, rendering winRendering) {
+		RegisterFormatter(name, func(options GeneratorOptions) (LogFormatter, error) {
+			return newWindowsEventGenerator(name, rendering, options)
+		})
+	}
+	register("win_snare", winSnare)
+	register("win_xml", winXML)
+	register("win_json", winJSON)
+}
+
+// Name - LogFormatter 구현
+func (g *WindowsEventGenerator) Name() string {
+	return g.name
+}
+
+// Generate - LogFormatter 구현
+func (g *WindowsEventGenerator) Generate() []byte {
+	buffer := getBuffer()
+
+	// 세션 상태와 재사용 필드 슬라이스를 공유하므로 렌더링까지 락 보유
+	g.rngMutex.Lock()
+	defer g.rngMutex.Unlock()
+
+	event := &winEventTypes[g.events.pick(g.rng)]
+	g.recordID++
+	g.ctx = winContext{hostIdx: g.rng.Intn(len(g.computers)), fields: g.ctx.fields[:0]}
+	event.build(g, &g.ctx)
+
+	switch g.rendering {
+	case winXML:
+		buffer = g.appendXML(buffer, event)
+	case winJSON:
+		buffer = g.appendJSON(buffer, event)
+	default:
+		buffer = g.appendSnare(buffer, event)
+	}
+	return finishBuffer(buffer)
+}
+
+// --- 이벤트별 EventData 구성 (호출자가 락 보유) ---
+
+// newLogonID - 새 LogonId ("0x" + 16진수)
+func (g *WindowsEventGenerator) newLogonID() string {
+	g.nextLogon += uint64(1 + g.rng.Intn(0x400))
+	return "0x" + strconv.FormatUint(g.nextLogon, 16)
+}
+
+// session - 활성 세션 하나 선택 (없으면 nil)
+func (g *WindowsEventGenerator) session() *winSession {
+	if len(g.sessions) == 0 {
+		return nil
+	}
+	return &g.sessions[g.rng.Intn(len(g.sessions))]
+}
+
+// addSubject - Subject* 필드 (세션이 없으면 SYSTEM)
+func (g *WindowsEventGenerator) addSubject(ctx *winContext, session *winSession) {
+	if session == nil {
+		ctx.add("SubjectUserSid", "S-1-5-18")
+		ctx.add("SubjectUserName", g.computers[ctx.hostIdx]+"$")
+		ctx.add("SubjectDomainName", winDomain)
+		ctx.add("SubjectLogonId", "0x3e7")
+		ctx.user = "SYSTEM"
+		return
+	}
+	ctx.add("SubjectUserSid", g.userSIDs[session.userIdx])
+	ctx.add("SubjectUserName", commonUsernames[session.userIdx])
+	ctx.add("SubjectDomainName", winDomain)
+	ctx.add("SubjectLogonId", session.logonID)
+	ctx.user = commonUsernames[session.userIdx]
+}
+
+func (g *WindowsEventGenerator) buildLogon(ctx *winContext) {
+	logon := winLogonTypes[g.logons.pick(g.rng)]
+	userIdx := skewedIndex(g.rng, len(commonUsernames))
+	session := winSession{
+		userIdx:   userIdx,
+		logonID:   g.newLogonID(),
+		logonType: logon.logonType,
+		ip:        g.clientIPs[g.rng.Intn(len(g.clientIPs))],
+		hostIdx:   ctx.hostIdx,
+	}
+	if len(g.sessions) < winSessionCap {
+		g.sessions = append(g.sessions, session)
+	} else {
+		g.sessions[g.rng.Intn(winSessionCap)] = session
+	}
+
+	g.addSubject(ctx, nil)
+	ctx.add("TargetUserSid", g.userSIDs[userIdx])
+	ctx.add("TargetUserName", commonUsernames[userIdx])
+	ctx.add("TargetDomainName", winDomain)
+	ctx.add("TargetLogonId", session.logonID)
+	ctx.add("LogonType", strconv.Itoa(logon.logonType))
+	ctx.add("LogonProcessName", logon.process)
+	ctx.add("AuthenticationPackageName", logon.auth)
+	ctx.add("WorkstationName", "WS-"+strconv.Itoa(100+g.rng.Intn(400)))
+	ctx.add("LogonGuid", "{00000000-0000-0000-0000-000000000000}")
+	ctx.add("KeyLength", "0")
+	if logon.logonType == 3 {
+		ctx.add("ProcessId", "0x0")
+		ctx.add("ProcessName", "-")
+		ctx.add("IpAddress", session.ip)
+		ctx.add("IpPort", strconv.Itoa(49152+g.rng.Intn(16383)))
+	} else {
+		ctx.add("ProcessId", "0x27c")
+		ctx.add("ProcessName", `C:\Windows\System32\svchost.exe`)
+		ctx.add("IpAddress", "127.0.0.1")
+		ctx.add("IpPort", "0")
+	}
+	ctx.add("ImpersonationLevel", "%%1833")
+	ctx.add("ElevatedToken", "%%1842")
+	ctx.user = commonUsernames[userIdx]
+}
+
+func (g *WindowsEventGenerator) buildLogonFailure(ctx *winContext) {
+	logon := winLogonTypes[g.logons.pick(g.rng)]
+	failure := winLogonFailures[g.rng.Intn(len(winLogonFailures))]
+	userIdx := g.rng.Intn(len(commonUsernames))
+
+	g.addSubject(ctx, nil)
+	ctx.add("TargetUserSid", "S-1-0-0")
+	ctx.add("TargetUserName", commonUsernames[userIdx])
+	ctx.add("TargetDomainName", winDomain)
+	ctx.add("Status", failure.status)
+	ctx.add("FailureReason", failure.reason)
+	ctx.add("SubStatus", failure.subStatus)
+	ctx.add("LogonType", strconv.Itoa(logon.logonType))
+	ctx.add("LogonProcessName", logon.process)
+	ctx.add("AuthenticationPackageName", logon.auth)
+	ctx.add("WorkstationName", "WS-"+strconv.Itoa(100+g.rng.Intn(400)))
+	ctx.add("ProcessId", "0x0")
+	ctx.add("ProcessName", "-")
+	ctx.add("IpAddress", g.clientIPs[skewedIndex(g.rng, len(g.clientIPs))])
+	ctx.add("IpPort", strconv.Itoa(49152+g.rng.Intn(16383)))
+	ctx.user = commonUsernames[userIdx]
+}
+
+func (g *WindowsEventGenerator) buildLogoff(ctx *winContext) {
+	// 활성 세션을 종료 (없으면 SYSTEM 네트워크 세션)
+	if len(g.sessions) == 0 {
+		g.addSubject(ctx, nil)
+		return
+	}
+	i := g.rng.Intn(len(g.sessions))
+	session := g.sessions[i]
+	g.sessions[i] = g.sessions[len(g.sessions)-1]
+	g.sessions = g.sessions[:len(g.sessions)-1]
+
+	ctx.hostIdx = session.hostIdx
+	ctx.add("TargetUserSid", g.userSIDs[session.userIdx])
+	ctx.add("TargetUserName", commonUsernames[session.userIdx])
+	ctx.add("TargetDomainName", winDomain)
+	ctx.add("TargetLogonId", session.logonID)
+	ctx.add("LogonType", strconv.Itoa(session.logonType))
+	ctx.user = commonUsernames[session.userIdx]
+}
+
+func (g *WindowsEventGenerator) buildExplicitLogon(ctx *winContext) {
+	session := g.session()
+	if session != nil {
+		ctx.hostIdx = session.hostIdx
+	}
+	targetIdx := g.rng.Intn(len(commonUsernames))
+	server := g.fqdns[g.rng.Intn(len(g.fqdns))]
+
+	g.addSubject(ctx, session)
+	ctx.add("LogonGuid", "{00000000-0000-0000-0000-000000000000}")
+	ctx.add("TargetUserName", commonUsernames[targetIdx])
+	ctx.add("TargetDomainName", winDomain)
+	ctx.add("TargetLogonGuid", "{00000000-0000-0000-0000-000000000000}")
+	ctx.add("TargetServerName", server)
+	ctx.add("TargetInfo", server)
+	ctx.add("ProcessId", "0x"+strconv.FormatInt(int64(0x400+g.rng.Intn(0x4000)), 16))
+	ctx.add("ProcessName", `C:\Windows\System32\runas.exe`)
+	ctx.add("IpAddress", "-")
+	ctx.add("IpPort", "-")
+}
+
+func (g *WindowsEventGenerator) buildSpecialLogon(ctx *winContext) {
+	session := g.session()
+	if session != nil {
+		ctx.hostIdx = session.hostIdx
+	}
+	g.addSubject(ctx, session)
+	ctx.add("PrivilegeList", winPrivileges)
+}
+
+func (g *WindowsEventGenerator) buildProcessCreation(ctx *winContext) {
+	session := g.session()
+	if session != nil {
+		ctx.hostIdx = session.hostIdx
+	}
+	process := winProcesses[skewedIndex(g.rng, len(winProcesses))]
+
+	g.addSubject(ctx, session)
+	ctx.add("NewProcessId", "0x"+strconv.FormatInt(int64(0x400+g.rng.Intn(0x8000)), 16))
+	ctx.add("NewProcessName", process.path)
+	ctx.add("TokenElevationType", "%%1938")
+	ctx.add("ProcessId", "0x"+strconv.FormatInt(int64(0x400+g.rng.Intn(0x4000)), 16))
+	ctx.add("CommandLine", process.command)
+	ctx.add("TargetUserSid", "S-1-0-0")
+	ctx.add("TargetUserName", "-")
+	ctx.add("TargetDomainName", "-")
+	ctx.add("TargetLogonId", "0x0")
+	ctx.add("ParentProcessName", process.parent)
+	ctx.add("MandatoryLabel", "S-1-16-8192")
+}
+
+func (g *WindowsEventGenerator) buildUserCreated(ctx *winContext) {
+	session := g.session()
+	g.newUserSeq++
+	name := "user" + strconv.Itoa(g.newUserSeq)
+
+	ctx.add("TargetUserName", name)
+	ctx.add("TargetDomainName", winDomain)
+	ctx.add("TargetSid", winDomainSID+"-"+strconv.Itoa(5000+g.newUserSeq))
+	g.addSubject(ctx, session)
+	ctx.add("SamAccountName", name)
+	ctx.add("DisplayName", "%%1793")
+	ctx.add("UserPrincipalName", name+"@corp.example.com")
+	ctx.add("UserAccountControl", "\n\t\t%%2080\n\t\t%%2082\n\t\t%%2084")
+	ctx.add("PrimaryGroupId", "513")
+}
+
+func (g *WindowsEventGenerator) buildLockout(ctx *winContext) {
+	userIdx := g.rng.Intn(len(commonUsernames))
+	ctx.add("TargetUserName", commonUsernames[userIdx])
+	ctx.add("TargetDomainName", "WS-"+strconv.Itoa(100+g.rng.Intn(400)))
+	ctx.add("TargetSid", g.userSIDs[userIdx])
+	g.addSubject(ctx, nil)
+	ctx.user = commonUsernames[userIdx]
+}
+
+func (g *WindowsEventGenerator) buildShareAccess(ctx *winContext) {
+	session := g.session()
+	share := winShares[skewedIndex(g.rng, len(winShares))]
+	ip := g.clientIPs[g.rng.Intn(len(g.clientIPs))]
+	if session != nil {
+		ip = session.ip
+	}
+
+	g.addSubject(ctx, session)
+	ctx.add("ObjectType", "File")
+	ctx.add("IpAddress", ip)
+	ctx.add("IpPort", strconv.Itoa(49152+g.rng.Intn(16383)))
+	ctx.add("ShareName", share.name)
+	ctx.add("ShareLocalPath", share.path)
+	ctx.add("AccessMask", "0x1")
+	ctx.add("AccessList", "%%4416")
+}
+
+// --- 렌더링 ---
+
+// appendSnare - Snare MSWinEventLog 형식 (탭 구분)
+// MSWinEventLog	1	Security	Counter	Date	EventID	Source	User	SIDType	EventLogType	Computer	Category		Expanded	Counter
+func (g *WindowsEventGenerator) appendSnare(buffer []byte, event *winEventType) []byte {
+	severity := 5 // notice
+	auditType := "Success Audit"
+	if !event.success {
+		severity = 4 // warning
+		auditType = "Failure Audit"
+	}
+	facility := g.header.pickFacility(g.rng)
+	app := ""
+	if g.header.format == SyslogRFC5424 {
+		app = "MSWinEventLog"
+	}
+	buffer = g.header.appendHeader(buffer, facility, severity, g.ctx.hostIdx, app, "", "")
+
+	buffer = append(buffer, "MSWinEventLog\t1\tSecurity\t"...)
+	buffer = strconv.AppendUint(buffer, g.recordID, 10)
+	buffer = append(buffer, '\t')
+	buffer = append(buffer, g.clock.Snare()...)
+	buffer = append(buffer, '\t')
+	buffer = strconv.AppendInt(buffer, int64(event.id), 10)
+	buffer = append(buffer, "\t"+winProviderName+"\t"...)
+	buffer = appendSnareValue(buffer, g.ctx.user)
+	buffer = append(buffer, "\tN/A\t"...)
+	buffer = append(buffer, auditType...)
+	buffer = append(buffer, '\t')
+	buffer = append(buffer, g.fqdns[g.ctx.hostIdx]...)
+	buffer = append(buffer, '\t')
+	buffer = append(buffer, event.task...)
+	buffer = append(buffer, "\t\t"...)
+
+	// Expanded String: 설명 + "Name: Value" 목록 (탭/줄바꿈은 공백)
+	buffer = append(buffer, event.message...)
+	for _, field := range g.ctx.fields {
+		buffer = append(buffer, "  "...)
+		buffer = append(buffer, field.name...)
+		buffer = append(buffer, ": "...)
+		buffer = appendSnareValue(buffer, field.value)
+	}
+	buffer = append(buffer, '\t')
+	buffer = strconv.AppendUint(buffer, g.recordID, 10)
+	return buffer
+}
+
+// appendSnareValue - 탭 구분자를 깨지 않도록 공백 정규화
+func appendSnareValue(buffer []byte, value string) []byte {
+	space := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\t' || c == '\n' || c == '\r' {
+			if !space {
+				buffer = append(buffer, ' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		buffer = append(buffer, c)
+	}
+	return buffer
+}
+
+// winKeywords - 감사 성공/실패 키워드 마스크
+func winKeywords(success bool) string {
+	if success {
+		return "0x8020000000000000"
+	}
+	return "0x8010000000000000"
+}
+
+// appendXML - 이벤트 뷰어 XML (한 줄)
+func (g *WindowsEventGenerator) appendXML(buffer []byte, event *winEventType) []byte {
+	buffer = append(buffer, "<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System>"...)
+	buffer = append(buffer, "<Provider Name='"+winProviderName+"' Guid='"+winProviderGUID+"'/>"...)
+	buffer = append(buffer, "<EventID>"...)
+	buffer = strconv.AppendInt(buffer, int64(event.id), 10)
+	buffer = append(buffer, "</EventID><Version>"...)
+	buffer = strconv.AppendInt(buffer, int64(event.version), 10)
+	buffer = append(buffer, "</Version><Level>0</Level><Task>"...)
+	buffer = strconv.AppendInt(buffer, int64(event.taskCode), 10)
+	buffer = append(buffer, "</Task><Opcode>0</Opcode><Keywords>"...)
+	buffer = append(buffer, winKeywords(event.success)...)
+	buffer = append(buffer, "</Keywords><TimeCreated SystemTime='"...)
+	buffer = append(buffer, g.clock.RFC3164()...)
+	buffer = append(buffer, "'/><EventRecordID>"...)
+	buffer = strconv.AppendUint(buffer, g.recordID, 10)
+	buffer = append(buffer, "</EventRecordID><Correlation/><Execution ProcessID='"...)
+	buffer = strconv.AppendInt(buffer, winLSASSPid, 10)
+	buffer = append(buffer, "' ThreadID='"...)
+	buffer = strconv.AppendInt(buffer, int64(700+g.rng.Intn(9000)), 10)
+	buffer = append(buffer, "'/><Channel>Security</Channel><Computer>"...)
+	buffer = append(buffer, g.fqdns[g.ctx.hostIdx]...)
+	buffer = append(buffer, "</Computer><Security/></System><EventData>"...)
+	for _, field := range g.ctx.fields {
+		buffer = append(buffer, "<Data Name='"...)
+		buffer = append(buffer, field.name...)
+		buffer = append(buffer, "'>"...)
+		buffer = appendXMLEscaped(buffer, field.value)
+		buffer = append(buffer, "</Data>"...)
+	}
+	buffer = append(buffer, "</EventData></Event>"...)
+	return buffer
+}
+
+// appendXMLEscaped - XML 텍스트 이스케이프 (줄바꿈/탭은 문자 참조로 유지)
+func appendXMLEscaped(buffer []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '&':
+			buffer = append(buffer, "&amp;"...)
+		case '<':
+			buffer = append(buffer, "&lt;"...)
+		case '>':
+			buffer = append(buffer, "&gt;"...)
+		case '\'':
+			buffer = append(buffer, "&apos;"...)
+		case '"':
+			buffer = append(buffer, "&quot;"...)
+		case '\n':
+			buffer = append(buffer, "&#10;"...)
+		case '\r':
+			buffer = append(buffer, "&#13;"...)
+		case '\t':
+			buffer = append(buffer, "&#9;"...)
+		default:
+			buffer = append(buffer, c)
+		}
+	}
+	return buffer
+}
+
+// appendJSON - Winlogbeat 스타일 JSON
+func (g *WindowsEventGenerator) appendJSON(buffer []byte, event *winEventType) []byte {
+	outcome := "success"
+	keyword := "Audit Success"
+	if !event.success {
+		outcome = "failure"
+		keyword = "Audit Failure"
+	}
+
+	buffer = append(buffer, `{"@timestamp":"`...)
+	buffer = append(buffer, g.clock.RFC3164()...)
+	buffer = append(buffer, `","event":{"kind":"event",`...)
+	buffer = appendJSONField(buffer, "code", strconv.Itoa(event.id))
+	buffer = append(buffer, `,"provider":"`+winProviderName+`",`...)
+	buffer = appendJSONField(buffer, "action", event.action)
+	buffer = append(buffer, `,"category":`...)
+	buffer = appendJSONStrings(buffer, event.category)
+	buffer = append(buffer, `,"type":`...)
+	buffer = appendJSONStrings(buffer, event.kind)
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "outcome", outcome)
+	buffer = append(buffer, `},"host":{`...)
+	buffer = appendJSONField(buffer, "name", g.fqdns[g.ctx.hostIdx])
+	buffer = append(buffer, `},"log":{"level":"information"},`...)
+	buffer = appendJSONField(buffer, "message", event.message)
+	buffer = append(buffer, `,"winlog":{"channel":"Security","provider_name":"`+winProviderName+`","provider_guid":"`+winProviderGUID+`",`...)
+	buffer = appendJSONField(buffer, "event_id", strconv.Itoa(event.id))
+	buffer = append(buffer, ',')
+	buffer = appendJSONInt(buffer, "record_id", int64(g.recordID))
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "computer_name", g.fqdns[g.ctx.hostIdx])
+	buffer = append(buffer, ',')
+	buffer = appendJSONField(buffer, "task", event.task)
+	buffer = append(buffer, `,"opcode":"Info","keywords":[`...)
+	buffer = appendJSONString(buffer, keyword)
+	buffer = append(buffer, `],"process":{"pid":`...)
+	buffer = strconv.AppendInt(buffer, winLSASSPid, 10)
+	buffer = append(buffer, `,"thread":{"id":`...)
+	buffer = strconv.AppendInt(buffer, int64(700+g.rng.Intn(9000)), 10)
+	buffer = append(buffer, `}},"event_data":{`...)
+	for i, field := range g.ctx.fields {
+		if i > 0 {
+			buffer = append(buffer, ',')
+		}
+		buffer = appendJSONField(buffer, field.name, field.value)
+	}
+	buffer = append(buffer, `}}}`...)
+	return buffer
+}
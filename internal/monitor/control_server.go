@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log-generator/internal/config"
+	"log-generator/internal/generator"
 	"log-generator/internal/worker"
 	"log-generator/pkg/metrics"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 	
@@ -16,6 +21,7 @@ import (
 // ControlServer - 웹 UI 기반 로그 생성기 제어 서버
 type ControlServer struct {
 	port             int
+	dataDir          string // API로 지정하는 파일의 디렉터리 (비어 있으면 파일 지정 불가)
 	metricsCollector *metrics.MetricsCollector
 	
 	// 로그 생성기 상태
@@ -24,6 +30,10 @@ type ControlServer struct {
 	isRunning        bool
 	currentConfig    *GeneratorConfig
 	
+	// 주입 메시지 정답 기록 (실행 중이 아니면 기록기는 nil, 경로는 마지막 실행의 파일)
+	groundTruth      *worker.GroundTruthWriter
+	groundTruthPath  string
+	
 	// 제어 상태
 	mutex            sync.RWMutex
 	httpServer       *http.Server
@@ -55,6 +65,196 @@ type GeneratorConfig struct {
 	LogFormats       []string `json:"log_formats"`
 	HostnamePrefix   string   `json:"hostname_prefix"`
 	ServiceTypes     []string `json:"service_types"`
+	SyslogFormat     string   `json:"syslog_format"` // rfc3164, rfc5424
+	UseBOM           bool     `json:"use_bom"`       // RFC 5424 MSG 앞 BOM
+	
+	// PRI 분포 설정 (비어 있으면 기본 분포, 심각도 가중치는 syslog/ecs/gelf 형식에만 적용)
+	FacilityWeights         map[string]float64            `json:"facility_weights,omitempty"`
+	SeverityWeights         map[string]float64            `json:"severity_weights,omitempty"`
+	FacilitySeverityWeights map[string]map[string]float64 `json:"facility_severity_weights,omitempty"`
+	ServiceFacilities       map[string]string             `json:"service_facilities,omitempty"`
+	
+	// 웹 접근 로그 설정 ("2xx".."5xx" 가중치, 비어 있으면 기본 분포)
+	WebStatusWeights  map[string]float64 `json:"web_status_weights,omitempty"`
+	IISHeaderInterval int                `json:"iis_header_interval,omitempty"` // IIS #Fields 지시문 간격 (0 = 1000줄)
+	
+	// CEF 장비 식별자 (비어 있으면 기본값)
+	CEFVendor  string `json:"cef_vendor,omitempty"`
+	CEFProduct string `json:"cef_product,omitempty"`
+	CEFVersion string `json:"cef_version,omitempty"`
+	
+	// LEEF 설정 (LEEF 2.0 구분자: 단일 문자 또는 xHH)
+	LEEFVendor    string `json:"leef_vendor,omitempty"`
+	LEEFProduct   string `json:"leef_product,omitempty"`
+	LEEFDelimiter string `json:"leef_delimiter,omitempty"`
+	
+	// auditd 규칙 키 (비어 있으면 이벤트별 기본 키)
+	AuditKeys []string `json:"audit_keys,omitempty"`
+	
+	// DNS 로그 DGA/터널링 의심 도메인 비율 (생략 시 기본값 0.02)
+	DNSSuspiciousRatio *float64 `json:"dns_suspicious_ratio,omitempty"`
+	
+	// vpcflow_custom 필드 목록 (비어 있으면 v2~v5 전체 필드)
+	VPCFlowFields []string `json:"vpc_flow_fields,omitempty"`
+	
+	// NetFlow/IPFIX 수집기 포트와 템플릿 재전송 간격 (0 = 형식 기본값, 20 패킷)
+	FlowPort            int `json:"flow_port,omitempty"`
+	FlowTemplateRefresh int `json:"flow_template_refresh,omitempty"`
+	
+	// GELF 압축 방식, UDP 청크 크기, 입력 포트 (비어 있으면 none, 1420, 12201)
+	GELFCompression string `json:"gelf_compression,omitempty"`
+	GELFChunkSize   int    `json:"gelf_chunk_size,omitempty"`
+	GELFPort        int    `json:"gelf_port,omitempty"`
+	
+	// 메시지 템플릿 파일 이름 (데이터 디렉터리 안, 비어 있으면 내장 메시지)
+	TemplateFile string `json:"template_file,omitempty"`
+	
+	// stacktrace 형식 언어와 최상위 예외 프레임 수 (비어 있으면 mixed, 12)
+	StackTraceLanguage string `json:"stacktrace_language,omitempty"`
+	StackTraceDepth    int    `json:"stacktrace_depth,omitempty"`
+	
+	// session 형식의 워커당 동시 세션 수와 평균 지속 시간 (비어 있으면 1000, 300초)
+	SessionCount    int `json:"session_count,omitempty"`
+	SessionDuration int `json:"session_duration,omitempty"`
+	
+	// 전송 프로토콜과 프레이밍 (비어 있으면 udp, auto)
+	Transport string `json:"transport,omitempty"`
+	Framing   string `json:"framing,omitempty"`
+	
+	// UDP 데이터그램 최대 바이트 수 (비어 있으면 65507)
+	MaxDatagram int `json:"max_datagram,omitempty"`
+	
+	// 비정상 메시지 퍼징 비율과 사용할 변형 (비율이 0이면 끔, 변형이 비어 있으면 전체)
+	FuzzRatio     float64  `json:"fuzz_ratio,omitempty"`
+	FuzzMutations []string `json:"fuzz_mutations,omitempty"`
+	
+	// 메시지 크기 분포 (분포가 비어 있으면 원래 크기, 바이트 단위)
+	SizeDistribution string `json:"size_distribution,omitempty"`
+	SizeMean         int    `json:"size_mean,omitempty"`
+	SizeStdDev       int    `json:"size_stddev,omitempty"`
+	SizeMin          int    `json:"size_min,omitempty"`
+	SizeMax          int    `json:"size_max,omitempty"`
+	
+	// 초당 바이트 목표 (예: "500MB/s", 지정하면 프로파일/목표 EPS 대신 환산 EPS 사용)
+	TargetBytes string `json:"target_bytes,omitempty"`
+	
+	// 역할 기반 호스트 인벤토리 (nil이거나 hosts가 0이면 끔, 설정 시 hostname_prefix 무시)
+	Inventory *generator.InventoryOptions `json:"inventory,omitempty"`
+	
+	// 공격 시나리오 주입 (nil이거나 ratio/interval이 0이면 끔, file은 데이터 디렉터리 안의 파일 이름)
+	Scenario *generator.ScenarioOptions `json:"scenario,omitempty"`
+	
+	// 주입 메시지 정답 파일 이름 (데이터 디렉터리 안, 비어 있으면 임시 디렉터리, 시나리오/퍼징 주입 시에만 기록)
+	GroundTruthFile string `json:"ground_truth_file,omitempty"`
+	
+	// 메시지마다 실행 ID/워커 ID/일련번호 워터마크 추가 (실행 ID는 시작 응답과 워커 메트릭의 last_sequence로 확인)
+	Watermark bool `json:"watermark,omitempty"` // NetFlow/IPFIX 형식에는 적용하지 않음
+}
+
+// generatorOptions - 설정에서 로그 생성기 출력 옵션 구성 (dataFile은 파일 이름을 서버 경로로 바꿈)
+func (cfg *GeneratorConfig) generatorOptions(dataFile func(name string) (string, error)) (generator.GeneratorOptions, error) {
+	options := generator.DefaultGeneratorOptions()
+	
+	syslogFormat, err := generator.ParseSyslogFormat(cfg.SyslogFormat)
+	if err != nil {
+		return options, err
+	}
+	options.SyslogFormat = syslogFormat
+	options.UseBOM = cfg.UseBOM
+	options.HostnamePrefix = cfg.HostnamePrefix
+	options.Services = cfg.ServiceTypes
+	
+	if len(cfg.FacilityWeights) > 0 {
+		options.Priority.FacilityWeights = cfg.FacilityWeights
+	}
+	if len(cfg.SeverityWeights) > 0 {
+		options.Priority.SeverityWeights = cfg.SeverityWeights
+	}
+	options.Priority.FacilitySeverityWeights = cfg.FacilitySeverityWeights
+	options.Priority.ServiceFacilities = cfg.ServiceFacilities
+	options.Web.StatusWeights = cfg.WebStatusWeights
+	options.Web.IISHeaderInterval = cfg.IISHeaderInterval
+	options.CEF = generator.CEFOptions{
+		Vendor:  cfg.CEFVendor,
+		Product: cfg.CEFProduct,
+		Version: cfg.CEFVersion,
+	}
+	options.LEEF = generator.LEEFOptions{
+		Vendor:    cfg.LEEFVendor,
+		Product:   cfg.LEEFProduct,
+		Delimiter: cfg.LEEFDelimiter,
+	}
+	options.Audit.Keys = cfg.AuditKeys
+	if cfg.DNSSuspiciousRatio != nil {
+		options.DNS.SuspiciousRatio = *cfg.DNSSuspiciousRatio
+	}
+	options.VPCFlow.Fields = cfg.VPCFlowFields
+	options.Flow = generator.FlowOptions{
+		Port:            cfg.FlowPort,
+		TemplateRefresh: cfg.FlowTemplateRefresh,
+	}
+	options.GELF = generator.GELFOptions{
+		Compression: cfg.GELFCompression,
+		ChunkSize:   cfg.GELFChunkSize,
+		Port:        cfg.GELFPort,
+	}
+	if cfg.TemplateFile != "" {
+		path, err := dataFile(cfg.TemplateFile)
+		if err != nil {
+			return options, err
+		}
+		if options.Templates, err = generator.LoadTemplateFile(path); err != nil {
+			return options, err
+		}
+	}
+	options.StackTrace = generator.StackTraceOptions{
+		Language: cfg.StackTraceLanguage,
+		Depth:    cfg.StackTraceDepth,
+	}
+	options.Session = generator.SessionOptions{
+		Count:    cfg.SessionCount,
+		Duration: cfg.SessionDuration,
+	}
+	if cfg.SizeDistribution != "" {
+		options.Size = generator.SizeOptions{
+			Distribution: cfg.SizeDistribution,
+			Mean:         cfg.SizeMean,
+			StdDev:       cfg.SizeStdDev,
+			Min:          cfg.SizeMin,
+			Max:          cfg.SizeMax,
+		}
+	}
+	options.Fuzz = generator.FuzzOptions{
+		Ratio:     cfg.FuzzRatio,
+		Mutations: cfg.FuzzMutations,
+	}
+	if cfg.Inventory != nil && cfg.Inventory.Hosts > 0 {
+		if options.Inventory, err = generator.NewInventory(*cfg.Inventory); err != nil {
+			return options, err
+		}
+	}
+	if cfg.Scenario != nil && cfg.Scenario.Enabled() {
+		scenario := *cfg.Scenario
+		if scenario.File != "" {
+			if scenario.File, err = dataFile(scenario.File); err != nil {
+				return options, err
+			}
+		}
+		if options.Scenarios, err = generator.NewScenarioLibrary(scenario); err != nil {
+			return options, err
+		}
+	}
+	
+	return options, options.Validate()
+}
+
+// transportOptions - 설정에서 전송 옵션 구성
+func (cfg *GeneratorConfig) transportOptions() worker.TransportOptions {
+	return worker.TransportOptions{
+		Protocol:    cfg.Transport,
+		Framing:     cfg.Framing,
+		MaxDatagram: cfg.MaxDatagram,
+	}
 }
 
 // GeneratorStatus - 로그 생성기 현재 상태
@@ -92,6 +292,24 @@ func NewControlServer(port int) *ControlServer {
 	}
 }
 
+// SetDataDir - API 설정의 파일 이름을 찾을 데이터 디렉터리 지정 (Start 전에 호출)
+//
+// /api/config는 인증이 없으므로 파일은 경로가 아닌 이름으로만 받아 이 디렉터리 안으로 제한한다.
+func (cs *ControlServer) SetDataDir(dir string) {
+	cs.dataDir = dir
+}
+
+// dataFile - 데이터 디렉터리 안의 파일 경로 (경로 구분자나 상위 디렉터리가 든 이름은 거부)
+func (cs *ControlServer) dataFile(name string) (string, error) {
+	if cs.dataDir == "" {
+		return "", fmt.Errorf("서버에 데이터 디렉터리(-data-dir)가 설정되지 않아 파일을 지정할 수 없습니다: %s", name)
+	}
+	if name == "." || name == ".." || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("파일은 데이터 디렉터리 안의 이름으로만 지정할 수 있습니다: %s", name)
+	}
+	return filepath.Join(cs.dataDir, name), nil
+}
+
 // getDefaultConfig - 기본 설정 반환
 func getDefaultConfig() *GeneratorConfig {
 	return &GeneratorConfig{
@@ -109,6 +327,7 @@ func getDefaultConfig() *GeneratorConfig {
 		LogFormats:         []string{"syslog", "apache", "nginx"},
 		HostnamePrefix:     "server",
 		ServiceTypes:       []string{"systemd", "kernel", "sshd", "nginx", "apache"},
+		SyslogFormat:       string(generator.SyslogRFC3164),
 	}
 }
 
@@ -132,6 +351,9 @@ func (cs *ControlServer) Start() error {
 	mux.HandleFunc("/api/restart", cs.handleRestart)
 	mux.HandleFunc("/api/metrics", cs.handleMetrics)
 	mux.HandleFunc("/api/workers", cs.handleWorkers)
+	mux.HandleFunc("/api/formats", cs.handleFormats)
+	mux.HandleFunc("/api/inventory.csv", cs.handleInventoryCSV)
+	mux.HandleFunc("/api/ground-truth.ndjson", cs.handleGroundTruth)
 	mux.HandleFunc("/api/system-optimize", cs.handleSystemOptimize)
 	
 	// WebSocket (기존 모니터링)
@@ -336,10 +558,17 @@ func (cs *ControlServer) handleStart(w http.ResponseWriter, r *http.Request) {
 		profileName = "4m"
 	}
 	
+	message := fmt.Sprintf("로그 생성기 시작됨 (프로파일: %s, %d개 워커, 목표: %d EPS)", 
+		profileName, cs.currentConfig.WorkerCount, cs.currentConfig.TargetEPS)
+	if runID := cs.workerPool.GetWatermarkRunID(); runID != "" {
+		message += fmt.Sprintf(", 워터마크 실행 ID: %s", runID)
+	}
+	for _, warning := range generator.InjectionWarnings(cs.currentConfig.LogFormats, cs.workerPool.GetGeneratorOptions()) {
+		message += " / ⚠️ " + warning
+	}
 	cs.sendJSON(w, ControlResponse{
 		Success: true,
-		Message: fmt.Sprintf("로그 생성기 시작됨 (프로파일: %s, %d개 워커, 목표: %d EPS)", 
-			profileName, cs.currentConfig.WorkerCount, cs.currentConfig.TargetEPS),
+		Message: message,
 	})
 }
 
@@ -453,6 +682,86 @@ func (cs *ControlServer) handleWorkers(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleFormats - 사용 가능한 로그 형식 목록
+func (cs *ControlServer) handleFormats(w http.ResponseWriter, r *http.Request) {
+	cs.sendJSON(w, ControlResponse{
+		Success: true,
+		Data:    generator.ListFormats(),
+	})
+}
+
+// handleInventoryCSV - 현재 설정의 호스트 인벤토리를 CSV로 내려받기 (SIEM 자산 DB 적재용)
+//
+// 인벤토리는 설정과 시드로 결정되므로 생성 중인 로그와 같은 호스트/IP가 나온다.
+func (cs *ControlServer) handleInventoryCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	
+	cs.mutex.RLock()
+	currentConfig := cs.currentConfig
+	cs.mutex.RUnlock()
+	
+	if currentConfig == nil || currentConfig.Inventory == nil || currentConfig.Inventory.Hosts == 0 {
+		http.Error(w, "인벤토리가 설정되지 않았습니다 (inventory.hosts)", http.StatusNotFound)
+		return
+	}
+	inventory, err := generator.NewInventory(*currentConfig.Inventory)
+	if err != nil {
+		http.Error(w, "인벤토리 생성 실패: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="inventory.csv"`)
+	if err := inventory.WriteCSV(w); err != nil {
+		fmt.Printf("인벤토리 CSV 전송 실패: %v\n", err)
+	}
+}
+
+// handleGroundTruth - 현재(또는 마지막) 실행의 주입 메시지 정답 파일 내려받기 (NDJSON)
+//
+// 실행 중이면 기록기 버퍼를 비운 시점까지의 완전한 줄만 보낸다.
+func (cs *ControlServer) handleGroundTruth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	
+	cs.mutex.RLock()
+	writer := cs.groundTruth
+	path := cs.groundTruthPath
+	cs.mutex.RUnlock()
+	
+	if path == "" {
+		http.Error(w, "정답 파일이 없습니다 (scenario 또는 fuzz_ratio를 설정하고 실행)", http.StatusNotFound)
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		http.Error(w, "정답 파일 열기 실패: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+	
+	var reader io.Reader = file
+	if writer != nil {
+		size, err := writer.Checkpoint()
+		if err != nil {
+			http.Error(w, "정답 파일 저장 실패: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = io.LimitReader(file, size)
+	}
+	
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="ground-truth.ndjson"`)
+	if _, err := io.Copy(w, reader); err != nil {
+		fmt.Printf("정답 파일 전송 실패: %v\n", err)
+	}
+}
+
 // handleSystemOptimize - 시스템 최적화
 func (cs *ControlServer) handleSystemOptimize(w http.ResponseWriter, r *http.Request) {
 	// 실제로는 시스템 명령어 실행이 필요하지만 여기서는 시뮬레이션
@@ -556,8 +865,52 @@ func (cs *ControlServer) sendJSON(w http.ResponseWriter, response ControlRespons
 }
 
 func (cs *ControlServer) validateConfig(cfg *GeneratorConfig) error {
-	// 프로파일이 설정된 경우 프로파일 값 사용
-	if cfg.Profile != "" && cfg.Profile != "custom" {
+	// 로그 형식 검증 (프로파일과 무관)
+	if len(cfg.LogFormats) == 0 {
+		cfg.LogFormats = []string{"syslog"}
+	}
+	if err := generator.ValidateFormats(cfg.LogFormats); err != nil {
+		return err
+	}
+	options, err := cfg.generatorOptions(cs.dataFile)
+	if err != nil {
+		return err
+	}
+	if err := cfg.transportOptions().Validate(); err != nil {
+		return err
+	}
+	if err := options.Size.ValidateLimit(cfg.transportOptions().MessageLimit()); err != nil {
+		return err
+	}
+	if err := options.Priority.ValidateFormats(cfg.LogFormats); err != nil {
+		return err
+	}
+	if cfg.GroundTruthFile != "" {
+		if _, err := cs.dataFile(cfg.GroundTruthFile); err != nil {
+			return err
+		}
+	}
+	
+	// 바이트 목표가 있으면 평균 메시지 크기로 환산한 커스텀 프로파일 값을 아래에서 함께 검증
+	// (초기화할 때 실제 워커 풀 설정으로 다시 환산)
+	if cfg.TargetBytes != "" {
+		bytesPerSec, err := config.ParseByteRate(cfg.TargetBytes)
+		if err != nil {
+			return err
+		}
+		eps, _, err := worker.EstimateEPSForByteRate(bytesPerSec, cfg.LogFormats, options, cfg.transportOptions(), cfg.Watermark)
+		if err != nil {
+			return err
+		}
+		if err := validateByteRateEPS(cfg.TargetBytes, eps); err != nil {
+			return err
+		}
+		profile := config.CalculateCustomProfile(eps)
+		cfg.TargetEPS = int64(profile.TargetEPS)
+		cfg.WorkerCount = profile.WorkerCount
+		cfg.MemoryLimitGB = int(profile.MemoryLimit / (1024 * 1024 * 1024))
+	} else if cfg.Profile != "" && cfg.Profile != "custom" {
+		// 프로파일이 설정된 경우 프로파일 값 사용
 		profile, err := config.GetProfile(cfg.Profile)
 		if err == nil {
 			// 프로파일 값으로 덮어쓰기
@@ -567,7 +920,7 @@ func (cs *ControlServer) validateConfig(cfg *GeneratorConfig) error {
 		}
 	}
 	
-	// custom 프로파일이거나 프로파일이 없는 경우만 검증
+	// 바이트 목표, custom 프로파일이거나 프로파일이 없는 경우만 검증
 	if cfg.TargetEPS <= 0 || cfg.TargetEPS > 10000000 {
 		return fmt.Errorf("목표 EPS는 1-10,000,000 범위여야 합니다")
 	}
@@ -581,6 +934,14 @@ func (cs *ControlServer) validateConfig(cfg *GeneratorConfig) error {
 	return nil
 }
 
+// validateByteRateEPS - 바이트 목표를 환산한 EPS가 최대 EPS 이하인지 검증
+func validateByteRateEPS(targetBytes string, eps int) error {
+	if eps > 10000000 {
+		return fmt.Errorf("바이트 목표 %s는 %d EPS에 해당해 최대 10,000,000 EPS를 넘습니다", targetBytes, eps)
+	}
+	return nil
+}
+
 func (cs *ControlServer) initializeGenerator() error {
 	// 프로파일 기반 설정 처리
 	var profile *config.EPSProfile
@@ -601,6 +962,59 @@ func (cs *ControlServer) initializeGenerator() error {
 		}
 	}
 	
+	// 프로파일 기반 워커 풀 생성
+	cs.workerPool = worker.NewWorkerPoolWithProfile(cs.currentConfig.TargetHost, profile)
+	
+	// 로그 출력 형식 설정
+	generatorOptions, err := cs.currentConfig.generatorOptions(cs.dataFile)
+	if err != nil {
+		return err
+	}
+	if err := cs.workerPool.SetGeneratorOptions(generatorOptions); err != nil {
+		return err
+	}
+	if err := cs.workerPool.SetLogFormats(cs.currentConfig.LogFormats); err != nil {
+		return err
+	}
+	if err := cs.workerPool.SetTransportOptions(cs.currentConfig.transportOptions()); err != nil {
+		return err
+	}
+	if generatorOptions.Scenarios != nil || generatorOptions.Fuzz.Ratio > 0 {
+		if err := cs.openGroundTruth(); err != nil {
+			return err
+		}
+	}
+	if cs.currentConfig.Watermark {
+		// 정답 파일과 같은 실행 ID를 써서 두 기록을 함께 조회할 수 있게 함
+		runID := worker.NewRunID()
+		if cs.groundTruth != nil {
+			runID = cs.groundTruth.RunID()
+		}
+		if err := cs.workerPool.SetWatermark(runID); err != nil {
+			return err
+		}
+	}
+	
+	// 바이트 목표를 평균 메시지 크기로 나눠 목표 EPS 프로파일로 환산
+	if cs.currentConfig.TargetBytes != "" {
+		bytesPerSec, err := config.ParseByteRate(cs.currentConfig.TargetBytes)
+		if err != nil {
+			return err
+		}
+		eps, size, err := cs.workerPool.EPSForByteRate(bytesPerSec)
+		if err != nil {
+			return err
+		}
+		if err := validateByteRateEPS(cs.currentConfig.TargetBytes, eps); err != nil {
+			return err
+		}
+		profile = config.CalculateCustomProfile(eps)
+		profile.Description = fmt.Sprintf("%s (평균 %.0f바이트 × %d EPS)", config.FormatByteRate(bytesPerSec), size, eps)
+		if err := cs.workerPool.SetProfile(profile); err != nil {
+			return err
+		}
+	}
+	
 	// 프로파일 설정 적용
 	cs.currentConfig.TargetEPS = int64(profile.TargetEPS)
 	cs.currentConfig.WorkerCount = profile.WorkerCount // 프로파일에 정의된 워커 수 사용
@@ -620,16 +1034,13 @@ func (cs *ControlServer) initializeGenerator() error {
 		cs.memoryOptimizer.Start()
 	}
 	
-	// 프로파일 기반 워커 풀 생성
-	cs.workerPool = worker.NewWorkerPoolWithProfile(cs.currentConfig.TargetHost, profile)
-	
 	// 메트릭 수집기에 목표 EPS 설정
 	if cs.metricsCollector != nil {
 		cs.metricsCollector.SetTargetEPS(cs.currentConfig.TargetEPS)
 	}
 	
 	// 워커 풀 초기화
-	err := cs.workerPool.Initialize()
+	err = cs.workerPool.Initialize()
 	if err != nil {
 		return err
 	}
@@ -640,6 +1051,34 @@ func (cs *ControlServer) initializeGenerator() error {
 	return nil
 }
 
+// openGroundTruth - 이번 실행의 정답 파일 생성 후 워커 풀에 연결 (이전 실행 파일은 덮어씀)
+func (cs *ControlServer) openGroundTruth() error {
+	// 초기화에 실패한 이전 시도의 기록기가 남아 있으면 닫음
+	if cs.groundTruth != nil {
+		cs.groundTruth.Close()
+		cs.groundTruth = nil
+	}
+	
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("log-generator-ground-truth-%d.ndjson", cs.port))
+	if cs.currentConfig.GroundTruthFile != "" {
+		var err error
+		if path, err = cs.dataFile(cs.currentConfig.GroundTruthFile); err != nil {
+			return err
+		}
+	}
+	writer, err := worker.CreateGroundTruthFile(path)
+	if err != nil {
+		return err
+	}
+	if err := cs.workerPool.SetGroundTruth(writer); err != nil {
+		writer.Close()
+		return err
+	}
+	cs.groundTruth = writer
+	cs.groundTruthPath = path
+	return nil
+}
+
 func (cs *ControlServer) startGenerator() error {
 	if cs.workerPool == nil {
 		return fmt.Errorf("워커 풀이 초기화되지 않았습니다")
@@ -723,6 +1162,14 @@ func (cs *ControlServer) stopGenerator() error {
 		cs.workerPool = nil
 	}
 	
+	// 정답 파일 닫기 (경로는 다운로드용으로 유지)
+	if cs.groundTruth != nil {
+		if err := cs.groundTruth.Close(); err != nil {
+			errors = append(errors, err)
+		}
+		cs.groundTruth = nil
+	}
+	
 	if cs.metricsCollector != nil {
 		cs.metricsCollector.Stop()
 	}
@@ -461,6 +461,29 @@ func (cs *ControlServer) generateControlUI() string {
                     <label>호스트명 접두사</label>
                     <input type="text" id="hostnamePrefix" value="server" placeholder="server">
                 </div>
+                
+                <div class="form-group">
+                    <label>로그 형식 (쉼표 구분) <span id="availableFormats" style="color: #888; font-size: 0.8em;"></span></label>
+                    <input type="text" id="logFormats" value="syslog" placeholder="syslog">
+                </div>
+                
+                <div class="form-group">
+                    <label>서비스 목록 (쉼표 구분)</label>
+                    <input type="text" id="serviceTypes" value="systemd,kernel,sshd,nginx,apache" placeholder="systemd,kernel,sshd">
+                </div>
+                
+                <div class="form-group">
+                    <label>Syslog 형식</label>
+                    <select id="syslogFormat">
+                        <option value="rfc3164" selected>RFC 3164 (BSD)</option>
+                        <option value="rfc5424">RFC 5424 (IETF)</option>
+                    </select>
+                </div>
+                
+                <div class="checkbox-group">
+                    <input type="checkbox" id="useBOM">
+                    <label for="useBOM">RFC 5424 BOM 추가</label>
+                </div>
             </div>
             
             <button class="btn" onclick="saveConfig()" style="background: #00d4ff; color: #000; margin-top: 15px;">
@@ -600,6 +623,7 @@ func (cs *ControlServer) generateControlUI() string {
             }
             
             initializeUI() {
+                this.loadFormats();
                 this.loadConfig();
                 this.updateUI();
             }
@@ -890,11 +914,29 @@ func (cs *ControlServer) generateControlUI() string {
                     memory_limit_gb: parseInt(document.getElementById('memoryLimit').value),
                     gc_percent: parseInt(document.getElementById('gcPercent').value),
                     hostname_prefix: document.getElementById('hostnamePrefix').value,
-                    log_formats: ['syslog'],
-                    service_types: ['systemd', 'kernel', 'sshd', 'nginx']
+                    log_formats: this.splitList(document.getElementById('logFormats').value),
+                    service_types: this.splitList(document.getElementById('serviceTypes').value),
+                    syslog_format: document.getElementById('syslogFormat').value,
+                    use_bom: document.getElementById('useBOM').checked
                 };
             }
             
+            splitList(value) {
+                return value.split(',').map(v => v.trim()).filter(v => v.length > 0);
+            }
+            
+            async loadFormats() {
+                try {
+                    const response = await fetch('/api/formats');
+                    const result = await response.json();
+                    if (result.success && result.data) {
+                        document.getElementById('availableFormats').textContent = '(' + result.data.join(', ') + ')';
+                    }
+                } catch (error) {
+                    this.addLog('로그 형식 목록 로딩 실패: ' + error, 'error');
+                }
+            }
+            
             async saveConfig(config = null) {
                 if (!config) {
                     config = this.getConfigFromForm();
@@ -961,6 +1003,10 @@ func (cs *ControlServer) generateControlUI() string {
                         document.getElementById('memoryLimit').value = config.memory_limit_gb || 12;
                         document.getElementById('gcPercent').value = config.gc_percent || 200;
                         document.getElementById('hostnamePrefix').value = config.hostname_prefix || 'server';
+                        document.getElementById('logFormats').value = (config.log_formats || ['syslog']).join(',');
+                        document.getElementById('serviceTypes').value = (config.service_types || []).join(',');
+                        document.getElementById('syslogFormat').value = config.syslog_format || 'rfc3164';
+                        document.getElementById('useBOM').checked = config.use_bom === true;
                     }
                 } catch (error) {
                     this.addLog('설정 로딩 실패: ' + error, 'error');
@@ -0,0 +1,165 @@
+package worker
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log-generator/internal/generator"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GroundTruthRecord - 정답 파일(NDJSON)의 한 줄, 실제로 전송한 주입 메시지 하나
+type GroundTruthRecord struct {
+	ID          string    `json:"id"`                   // 실행 ID-일련번호 (파일 안에서 유일)
+	Kind        string    `json:"kind"`                 // scenario 또는 fuzz
+	Name        string    `json:"name"`                 // 시나리오 이름 또는 퍼징 변형 이름
+	Instance    string    `json:"instance,omitempty"`   // 시나리오 인스턴스 ID (같은 공격의 줄끼리 같음)
+	Step        int       `json:"step,omitempty"`       // 시나리오 단계 번호 (1부터)
+	Techniques  []string  `json:"techniques,omitempty"` // ATT&CK 기법 ID
+	SentAt      time.Time `json:"sent_at"`              // 전송 완료 시각
+	Destination string    `json:"destination"`          // 수집기 host:port
+	Transport   string    `json:"transport"`            // udp 또는 tcp
+	WorkerID    int       `json:"worker_id"`
+	Format      string    `json:"format"`
+	SHA256      string    `json:"sha256"` // 프레이밍 전 메시지 바이트(워터마크 포함)의 SHA-256 (16진수)
+	Size        int       `json:"size"`   // 프레이밍 전 메시지 바이트 수 (워터마크 포함)
+}
+
+// GroundTruthWriter - 주입 메시지 정답 기록기 (모든 워커가 공유, NDJSON)
+type GroundTruthWriter struct {
+	mutex   sync.Mutex
+	writer  *bufio.Writer
+	closer  io.Closer
+	runID   string
+	count   int64
+	written int64 // 기록한 바이트 수 (항상 줄 경계)
+}
+
+// NewGroundTruthWriter - w에 쓰는 정답 기록기 생성 (실행 ID는 NewRunID)
+func NewGroundTruthWriter(w io.Writer) *GroundTruthWriter {
+	writer := &GroundTruthWriter{
+		writer: bufio.NewWriter(w),
+		runID:  NewRunID(),
+	}
+	if closer, ok := w.(io.Closer); ok {
+		writer.closer = closer
+	}
+	return writer
+}
+
+// CreateGroundTruthFile - 정답 파일을 새로 만들어 기록기 생성 (기존 파일은 덮어씀)
+func CreateGroundTruthFile(path string) (*GroundTruthWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("정답 파일 생성 실패: %v", err)
+	}
+	return NewGroundTruthWriter(file), nil
+}
+
+// RunID - 레코드 ID 앞에 붙는 실행 ID
+func (g *GroundTruthWriter) RunID() string {
+	return g.runID
+}
+
+// Record - 레코드 한 줄 기록 (ID는 기록기가 부여)
+func (g *GroundTruthWriter) Record(record GroundTruthRecord) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.count++
+	record.ID = g.runID + "-" + strconv.FormatInt(g.count, 10)
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	n, err := g.writer.Write(line)
+	g.written += int64(n)
+	return err
+}
+
+// Count - 지금까지 기록한 레코드 수
+func (g *GroundTruthWriter) Count() int64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.count
+}
+
+// Flush - 버퍼에 남은 레코드를 파일에 씀
+func (g *GroundTruthWriter) Flush() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.writer.Flush()
+}
+
+// Checkpoint - 버퍼를 비우고 파일에 쓴 바이트 수 반환 (실행 중 파일을 읽을 때 이 길이까지만 읽으면 줄이 잘리지 않음)
+func (g *GroundTruthWriter) Checkpoint() (int64, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.written, g.writer.Flush()
+}
+
+// Close - 남은 레코드를 쓰고 파일 닫기
+func (g *GroundTruthWriter) Close() error {
+	err := g.Flush()
+	if g.closer != nil {
+		if closeErr := g.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// SetGroundTruth - 정답 기록기 설정 (SetFormatter 전후 무관, Start 전에 호출, nil이면 끔)
+func (w *UDPWorker) SetGroundTruth(writer *GroundTruthWriter) {
+	w.groundTruth = writer
+}
+
+// recordInjections - 전송을 시도한 메시지의 주입 레이블을 꺼내고 성공했으면 정답 기록
+//
+// 레이블은 포맷터가 메시지 버퍼 주소로 보관하므로 전송 실패 시에도 반드시 꺼내야 한다.
+// batch는 포맷터가 생성한 메시지, sent는 실제로 보낸 메시지(워터마크 포함, batch와 1:1)다.
+func (w *UDPWorker) recordInjections(batch, sent [][]byte, sendErr error) {
+	if w.groundTruth == nil || w.injections == nil {
+		return
+	}
+
+	var sentAt time.Time
+	for i, message := range batch {
+		label := w.injections.TakeInjection(message)
+		if label == nil || sendErr != nil {
+			continue
+		}
+		if sentAt.IsZero() {
+			sentAt = time.Now()
+		}
+		w.groundTruth.Record(w.groundTruthRecord(label, sent[i], sentAt))
+	}
+}
+
+// groundTruthRecord - 레이블과 메시지로 정답 레코드 구성
+func (w *UDPWorker) groundTruthRecord(label *generator.Injection, message []byte, sentAt time.Time) GroundTruthRecord {
+	protocol, port := w.endpoint()
+	sum := sha256.Sum256(message)
+	return GroundTruthRecord{
+		Kind:        label.Kind,
+		Name:        label.Name,
+		Instance:    label.Instance,
+		Step:        label.Step,
+		Techniques:  label.Techniques,
+		SentAt:      sentAt,
+		Destination: net.JoinHostPort(w.TargetHost, strconv.Itoa(port)),
+		Transport:   protocol,
+		WorkerID:    w.ID,
+		Format:      w.GetFormatName(),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Size:        len(message),
+	}
+}
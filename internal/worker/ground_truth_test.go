@@ -0,0 +1,239 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log-generator/internal/generator"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newTestWorker - port로 보내는 워커 (수집기 포트 514 대신 테스트 수신 포트 사용)
+func newTestWorker(t *testing.T, options TransportOptions, port int) *UDPWorker {
+	t.Helper()
+	w := &UDPWorker{
+		ID:             3,
+		TargetHost:     "127.0.0.1",
+		remotePort:     port,
+		transport:      options,
+		framing:        options.framing(),
+		sendBufferSize: 1 << 20,
+		recvBufferSize: 1 << 20,
+		stopChan:       make(chan struct{}),
+	}
+	var err error
+	if options.protocol() == TransportTCP {
+		err = w.setupTCPConnection()
+	} else {
+		err = w.setupUDPConnection()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		close(w.stopChan)
+		w.wg.Wait()
+		if w.conn != nil {
+			w.conn.Close()
+		}
+	})
+	return w
+}
+
+// listenUDP - 테스트 수신 소켓
+func listenUDP(t *testing.T) *net.UDPConn {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// receiveLines - 더 오지 않을 때까지 받은 데이터그램을 줄 단위로 나눔
+func receiveLines(t *testing.T, conn *net.UDPConn) [][]byte {
+	t.Helper()
+	var lines [][]byte
+	buffer := make([]byte, MaxUDPDatagram)
+	for {
+		conn.SetReadDeadline(time.Now().Add(300 * time.Millisecond))
+		n, err := conn.Read(buffer)
+		var timeout net.Error
+		if errors.As(err, &timeout) && timeout.Timeout() {
+			return lines
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range bytes.Split(buffer[:n], []byte("\n")) {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+}
+
+// setTestInjections - 모든 로그를 변형하고 정답 레이블을 보관하는 퍼징 포맷터와 정답 기록기 연결
+func setTestInjections(t *testing.T, w *UDPWorker, output *bytes.Buffer) generator.MutationCounter {
+	t.Helper()
+	options := generator.DefaultGeneratorOptions()
+	options.Fuzz = generator.FuzzOptions{Ratio: 1, Mutations: []string{generator.MutationNULBytes, generator.MutationEmpty, generator.MutationPRIOverflow}}
+	options.TrackInjections = true
+	formatter, err := generator.NewFormatter("syslog", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.generator = formatter
+	w.injections = formatter.(generator.InjectionSource)
+	w.groundTruth = NewGroundTruthWriter(output)
+	w.SetWatermark(w.groundTruth.RunID())
+	return formatter.(generator.MutationCounter)
+}
+
+// readGroundTruth - 정답 파일의 레코드 목록
+func readGroundTruth(t *testing.T, w *UDPWorker, output *bytes.Buffer) []GroundTruthRecord {
+	t.Helper()
+	if err := w.groundTruth.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	var records []GroundTruthRecord
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		var record GroundTruthRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("%v: %s", err, scanner.Bytes())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// 정답 레코드는 포맷터가 만든 버퍼 주소로 레이블을 찾되, 해시와 크기는 실제로 보낸(워터마크를 붙인) 바이트로 계산한다.
+func TestGroundTruthRecordsSentMessages(t *testing.T) {
+	receiver := listenUDP(t)
+	w := newTestWorker(t, TransportOptions{}, receiver.LocalAddr().(*net.UDPAddr).Port)
+	var output bytes.Buffer
+	counter := setTestInjections(t, w, &output)
+
+	batch := make([][]byte, 300)
+	for i := range batch {
+		batch[i] = w.generator.Generate()
+	}
+	if sent, err := w.sendMessages(batch); err != nil || sent != int64(len(batch)) {
+		t.Fatalf("sendMessages = (%d, %v)", sent, err)
+	}
+
+	received := map[string]int{}
+	for _, line := range receiveLines(t, receiver) {
+		sum := sha256.Sum256(line)
+		received[hex.EncodeToString(sum[:])] = len(line)
+	}
+
+	records := readGroundTruth(t, w, &output)
+	labelled := int64(0)
+	for _, count := range counter.MutationCounts() {
+		labelled += count
+	}
+	if int64(len(records)) != labelled || labelled != int64(len(batch)) {
+		t.Fatalf("레코드 %d개, 변형 %d건, 배치 %d건", len(records), labelled, len(batch))
+	}
+	for i, record := range records {
+		if want := w.groundTruth.RunID() + "-" + strconv.Itoa(i+1); record.ID != want {
+			t.Fatalf("레코드 %d ID %q, 기대값 %q", i, record.ID, want)
+		}
+		if record.Kind != generator.InjectionFuzz || record.WorkerID != w.ID || record.Transport != TransportUDP || record.Format != "syslog" {
+			t.Fatalf("레코드 %d: %+v", i, record)
+		}
+		size, ok := received[record.SHA256]
+		if !ok || size != record.Size {
+			t.Fatalf("레코드 %d(%s)의 해시가 받은 메시지와 맞지 않음", i, record.Name)
+		}
+	}
+	for i, message := range batch {
+		if w.injections.TakeInjection(message) != nil {
+			t.Fatalf("메시지 %d의 레이블이 남아 있음", i)
+		}
+	}
+}
+
+// 전송에 실패하거나 한도를 넘어 버린 메시지는 기록하지 않지만 레이블은 꺼내야 한다.
+func TestGroundTruthSkipsUnsentMessages(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport TransportOptions
+		fail      bool
+	}{
+		{"전송 실패", TransportOptions{}, true},
+		{"데이터그램 한도 초과", TransportOptions{MaxDatagram: 20}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := listenUDP(t)
+			w := newTestWorker(t, tt.transport, receiver.LocalAddr().(*net.UDPAddr).Port)
+			var output bytes.Buffer
+			counter := setTestInjections(t, w, &output)
+			if tt.fail {
+				w.conn.Close()
+			}
+
+			batch := make([][]byte, 100)
+			for i := range batch {
+				batch[i] = w.generator.Generate()
+			}
+			w.sendMessages(batch)
+
+			// 한도 초과 시에는 워터마크도 붙지 않는 빈 메시지만 전송됨
+			want := int64(0)
+			if !tt.fail {
+				want = counter.MutationCounts()[generator.MutationEmpty]
+				if w.oversized.Load() == 0 {
+					t.Fatal("한도를 넘은 메시지가 없음")
+				}
+			}
+			if records := readGroundTruth(t, w, &output); int64(len(records)) != want {
+				t.Fatalf("레코드 %d개, 기대값 %d개", len(records), want)
+			}
+			for i, message := range batch {
+				if w.injections.TakeInjection(message) != nil {
+					t.Fatalf("메시지 %d의 레이블이 남아 있음", i)
+				}
+			}
+		})
+	}
+}
+
+func TestGroundTruthCheckpoint(t *testing.T) {
+	path := t.TempDir() + "/truth.ndjson"
+	writer, err := CreateGroundTruthFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := writer.Record(GroundTruthRecord{Kind: generator.InjectionScenario, Name: "x"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	size, err := writer.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(data)) != size || !bytes.HasSuffix(data, []byte("\n")) || bytes.Count(data, []byte("\n")) != 3 {
+		t.Fatalf("체크포인트 %d바이트, 파일 %d바이트 %q", size, len(data), data)
+	}
+	if writer.Count() != 3 {
+		t.Fatalf("레코드 수 %d", writer.Count())
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,316 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// 전송 프로토콜
+const (
+	TransportUDP = "udp"
+	TransportTCP = "tcp"
+)
+
+// 프레이밍 방식 (로그 한 건의 경계를 수신 측에 전달하는 방법)
+const (
+	// FramingAuto - TCP는 옥텟 카운팅, UDP는 이스케이프
+	FramingAuto = "auto"
+	// FramingLF - 줄바꿈 구분 (기존 동작, 멀티라인 로그는 여러 건으로 쪼개짐)
+	FramingLF = "lf"
+	// FramingOctet - RFC 6587 옥텟 카운팅 "길이 SP 메시지" (TCP 전용)
+	FramingOctet = "octet"
+	// FramingEscape - 메시지 안의 줄바꿈을 rsyslog 제어 문자 표기 #012로 치환
+	FramingEscape = "escape"
+	// FramingContinuation - 메시지의 둘째 줄부터 공백으로 시작하게 해 연속 줄로 표시
+	FramingContinuation = "continuation"
+)
+
+// TCP 연결 타임아웃
+const tcpDialTimeout = 5 * time.Second
+
+// TCP 재연결 간격 (실패할 때마다 두 배, 최대 tcpReconnectMax)
+const (
+	tcpReconnectMin = 100 * time.Millisecond
+	tcpReconnectMax = 5 * time.Second
+)
+
+// MaxUDPDatagram - UDP 데이터그램 최대 페이로드 (IPv4: 65535 - IP 헤더 20 - UDP 헤더 8)
+const MaxUDPDatagram = 65507
+
+// errOversizedMessage - 로그 한 건이 최대 데이터그램 크기를 넘어 보내지 않고 버림
+var errOversizedMessage = errors.New("로그 한 건이 최대 데이터그램 크기를 넘어 전송하지 않음")
+
+// errTCPDisconnected - TCP 연결이 끊겨 백그라운드 재연결을 기다리는 중
+var errTCPDisconnected = errors.New("TCP 연결 끊김 (재연결 대기 중)")
+
+// TransportOptions - 전송 프로토콜/프레이밍 옵션
+//
+// NetFlow/IPFIX와 GELF는 자체 UDP 프로토콜이므로 이 설정과 무관하게 UDP로 전송한다.
+type TransportOptions struct {
+	// 전송 프로토콜 (udp, tcp / 빈 값 = udp)
+	Protocol string `json:"protocol,omitempty"`
+	// 프레이밍 방식 (auto, lf, octet, escape, continuation / 빈 값 = auto)
+	Framing string `json:"framing,omitempty"`
+	// UDP 데이터그램 최대 바이트 수 (0 = 65507, 배치가 넘으면 여러 데이터그램으로 나눠 보냄)
+	MaxDatagram int `json:"max_datagram,omitempty"`
+}
+
+// Validate - 전송 옵션 검증
+func (o TransportOptions) Validate() error {
+	switch o.Protocol {
+	case "", TransportUDP, TransportTCP:
+	default:
+		return fmt.Errorf("지원하지 않는 전송 프로토콜: %s (udp, tcp)", o.Protocol)
+	}
+	switch o.Framing {
+	case "", FramingAuto, FramingLF, FramingEscape, FramingContinuation:
+	case FramingOctet:
+		if o.protocol() != TransportTCP {
+			return fmt.Errorf("옥텟 카운팅 프레이밍은 TCP 전송에서만 사용할 수 있습니다")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 프레이밍 방식: %s (auto, lf, octet, escape, continuation)", o.Framing)
+	}
+	if o.MaxDatagram < 0 || o.MaxDatagram > MaxUDPDatagram {
+		return fmt.Errorf("최대 데이터그램 크기는 0(기본값 %d) ~ %d 바이트여야 합니다: %d", MaxUDPDatagram, MaxUDPDatagram, o.MaxDatagram)
+	}
+	return nil
+}
+
+// protocol - 빈 값을 UDP로 해석
+func (o TransportOptions) protocol() string {
+	if o.Protocol == "" {
+		return TransportUDP
+	}
+	return o.Protocol
+}
+
+// maxDatagram - UDP 데이터그램 최대 바이트 수 (0을 65507로 해석)
+func (o TransportOptions) maxDatagram() int {
+	if o.MaxDatagram == 0 {
+		return MaxUDPDatagram
+	}
+	return o.MaxDatagram
+}
+
+// MessageLimit - 로그 한 건의 최대 바이트 수 (UDP는 최대 데이터그램 크기, TCP는 0 = 무제한)
+func (o TransportOptions) MessageLimit() int {
+	if o.protocol() == TransportTCP {
+		return 0
+	}
+	return o.maxDatagram()
+}
+
+// framing - auto를 프로토콜별 기본 프레이밍으로 해석
+func (o TransportOptions) framing() string {
+	if o.Framing != "" && o.Framing != FramingAuto {
+		return o.Framing
+	}
+	if o.protocol() == TransportTCP {
+		return FramingOctet
+	}
+	return FramingEscape
+}
+
+// SetTransport - 전송 프로토콜/프레이밍 설정 (Start 전에 호출)
+func (w *UDPWorker) SetTransport(options TransportOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	w.transport = options
+	w.framing = options.framing()
+	return w.connect()
+}
+
+// endpoint - 현재 형식과 전송 설정에 따른 프로토콜/수집기 포트
+func (w *UDPWorker) endpoint() (string, int) {
+	switch {
+	case w.packetFormatter != nil:
+		return TransportUDP, w.packetFormatter.DestinationPort()
+	case w.chunkedFormatter != nil:
+		return TransportUDP, w.chunkedFormatter.DestinationPort()
+	}
+	return w.transport.protocol(), 514
+}
+
+// connect - 프로토콜이나 포트가 바뀌었으면 수집기에 다시 연결
+func (w *UDPWorker) connect() error {
+	protocol, port := w.endpoint()
+	_, isTCP := w.conn.(*net.TCPConn)
+	if w.conn != nil && port == w.remotePort && isTCP == (protocol == TransportTCP) {
+		return nil
+	}
+
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+	w.remotePort = port
+	if protocol == TransportTCP {
+		if err := w.setupTCPConnection(); err != nil {
+			return fmt.Errorf("TCP 연결 설정 실패 (워커 %d): %v", w.ID, err)
+		}
+		return nil
+	}
+	if err := w.setupUDPConnection(); err != nil {
+		return fmt.Errorf("UDP 연결 설정 실패 (워커 %d): %v", w.ID, err)
+	}
+	return nil
+}
+
+// setupTCPConnection - 수집기 TCP 연결 (syslog over TCP, RFC 6587)
+func (w *UDPWorker) setupTCPConnection() error {
+	conn, err := w.dialTCP(context.Background())
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+
+	if err := w.optimizeSocketBuffers(); err != nil {
+		return fmt.Errorf("소켓 최적화 실패: %v", err)
+	}
+	return nil
+}
+
+// dialTCP - 수집기에 TCP 연결 (ctx가 취소되면 연결 시도 중단)
+func (w *UDPWorker) dialTCP(ctx context.Context) (net.Conn, error) {
+	address := net.JoinHostPort(w.TargetHost, strconv.Itoa(w.remotePort))
+	dialer := net.Dialer{Timeout: tcpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("TCP 연결 생성 실패: %v", err)
+	}
+	return conn, nil
+}
+
+// startReconnect - 끊어진 TCP 연결을 닫고 백그라운드에서 백오프를 두며 다시 연결
+//
+// 전송 고루틴은 재연결을 기다리지 않는다. 새 연결은 reconnect 채널로 넘겨받아
+// 다음 writeDatagram에서 교체하며, 워커가 정지하면 연결 시도를 멈춘다.
+func (w *UDPWorker) startReconnect(cause error) {
+	w.conn.Close()
+	result := make(chan net.Conn, 1)
+	w.reconnect = result
+	fmt.Printf("⚠️  워커 %d: TCP 연결 끊김 (%v), 백그라운드에서 재연결합니다\n", w.ID, cause)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-w.stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		backoff := tcpReconnectMin
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			conn, err := w.dialTCP(ctx)
+			if err == nil {
+				result <- conn
+				return
+			}
+			backoff = min(backoff*2, tcpReconnectMax)
+		}
+	}()
+}
+
+// takeReconnected - 백그라운드 재연결이 끝났으면 새 연결로 교체 (아직이면 false)
+func (w *UDPWorker) takeReconnected() bool {
+	select {
+	case conn := <-w.reconnect:
+		w.conn = conn
+		w.reconnect = nil
+		if err := w.optimizeSocketBuffers(); err != nil {
+			fmt.Printf("⚠️  워커 %d: 소켓 최적화 실패: %v\n", w.ID, err)
+		}
+		fmt.Printf("✅ 워커 %d: TCP 재연결 완료\n", w.ID)
+		return true
+	default:
+		return false
+	}
+}
+
+// appendDatagram - batch 앞에서부터 limit 바이트를 넘지 않을 만큼의 로그를 프레이밍 방식에 맞춰 결합
+//
+// 결합한 로그 수를 반환한다(limit 0은 무제한). UDP는 기존처럼 줄바꿈으로만 잇고,
+// TCP 스트림은 다음 배치와 섞이지 않도록 줄바꿈 계열 프레이밍에서도 마지막 로그 뒤에
+// 줄바꿈을 붙인다. 첫 로그 하나만으로 limit를 넘으면 oversized가 true이고 결합 수는 1이다
+// (버퍼는 비어 있음, 호출자가 그 로그를 버림).
+func (w *UDPWorker) appendDatagram(buffer []byte, batch [][]byte, limit int) (result []byte, count int, oversized bool) {
+	start := len(buffer)
+	for _, logData := range batch {
+		mark := len(buffer)
+		if w.framing == FramingOctet {
+			buffer = strconv.AppendInt(buffer, int64(len(logData)), 10)
+			buffer = append(buffer, ' ')
+			buffer = append(buffer, logData...)
+		} else {
+			if count > 0 {
+				buffer = append(buffer, '\n')
+			}
+			buffer = w.appendLine(buffer, logData)
+		}
+		if limit > 0 && len(buffer)-start > limit {
+			if count == 0 {
+				return buffer[:start], 1, true
+			}
+			buffer = buffer[:mark]
+			break
+		}
+		count++
+	}
+
+	if _, isTCP := w.conn.(*net.TCPConn); isTCP && count > 0 && w.framing != FramingOctet {
+		buffer = append(buffer, '\n')
+	}
+	return buffer, count, false
+}
+
+// datagramLimit - 데이터그램 하나에 담을 최대 바이트 수 (TCP 스트림은 0 = 무제한)
+func (w *UDPWorker) datagramLimit() int {
+	if _, isTCP := w.conn.(*net.TCPConn); isTCP {
+		return 0
+	}
+	return w.transport.maxDatagram()
+}
+
+// appendLine - 로그 한 건의 내부 줄바꿈을 프레이밍 방식에 맞게 변환해 추가
+func (w *UDPWorker) appendLine(buffer []byte, logData []byte) []byte {
+	if w.framing == FramingLF || bytes.IndexByte(logData, '\n') < 0 {
+		return append(buffer, logData...)
+	}
+
+	for {
+		i := bytes.IndexByte(logData, '\n')
+		if i < 0 {
+			return append(buffer, logData...)
+		}
+		buffer = append(buffer, logData[:i]...)
+		logData = logData[i+1:]
+
+		if w.framing == FramingEscape {
+			buffer = append(buffer, "#012"...)
+			continue
+		}
+		// 연속 줄: 공백/탭으로 시작하지 않는 줄은 탭을 붙여 앞 줄에 이어지게 함
+		buffer = append(buffer, '\n')
+		if len(logData) == 0 || (logData[0] != ' ' && logData[0] != '\t') {
+			buffer = append(buffer, '\t')
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package worker
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransportOptionsFraming(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TransportOptions
+		framing string
+		limit   int
+		ok      bool
+	}{
+		{"기본값", TransportOptions{}, FramingEscape, MaxUDPDatagram, true},
+		{"TCP 자동", TransportOptions{Protocol: TransportTCP}, FramingOctet, 0, true},
+		{"TCP 줄바꿈", TransportOptions{Protocol: TransportTCP, Framing: FramingLF}, FramingLF, 0, true},
+		{"UDP 연속 줄", TransportOptions{Framing: FramingContinuation, MaxDatagram: 1472}, FramingContinuation, 1472, true},
+		{"UDP 옥텟 카운팅", TransportOptions{Framing: FramingOctet}, "", 0, false},
+		{"알 수 없는 프레이밍", TransportOptions{Framing: "nul"}, "", 0, false},
+		{"알 수 없는 프로토콜", TransportOptions{Protocol: "sctp"}, "", 0, false},
+		{"데이터그램 한도 초과", TransportOptions{MaxDatagram: MaxUDPDatagram + 1}, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err == nil) != tt.ok {
+				t.Fatalf("Validate() = %v, 성공 기대 %v", err, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			if got := tt.options.framing(); got != tt.framing {
+				t.Fatalf("프레이밍 %s, 기대값 %s", got, tt.framing)
+			}
+			if got := tt.options.MessageLimit(); got != tt.limit {
+				t.Fatalf("메시지 한도 %d, 기대값 %d", got, tt.limit)
+			}
+		})
+	}
+}
+
+func TestAppendDatagramFraming(t *testing.T) {
+	batch := [][]byte{
+		[]byte("single"),
+		[]byte("summary\n\tat frame\nCaused by: x\n"),
+		[]byte(""),
+	}
+	tests := []struct {
+		framing string
+		want    string
+	}{
+		{FramingLF, "single\nsummary\n\tat frame\nCaused by: x\n\n"},
+		{FramingEscape, "single\nsummary#012\tat frame#012Caused by: x#012\n"},
+		{FramingContinuation, "single\nsummary\n\tat frame\n\tCaused by: x\n\t\n"}, // 끝 줄바꿈 뒤 빈 줄도 연속 줄
+		{FramingOctet, "6 single31 summary\n\tat frame\nCaused by: x\n0 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framing, func(t *testing.T) {
+			w := &UDPWorker{framing: tt.framing}
+			got, count, oversized := w.appendDatagram([]byte("keep"), batch, 0)
+			if string(got) != "keep"+tt.want || count != len(batch) || oversized {
+				t.Fatalf("appendDatagram = (%q, %d, %v), 기대값 %q", got, count, oversized, "keep"+tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendDatagramLimit(t *testing.T) {
+	ten := []byte("0123456789")
+	tests := []struct {
+		name      string
+		framing   string
+		batch     [][]byte
+		limit     int
+		want      string
+		count     int
+		oversized bool
+	}{
+		{"한도 안에서 끊음", FramingEscape, [][]byte{ten, ten, ten}, 25, "0123456789\n0123456789", 2, false},
+		{"한도와 정확히 같음", FramingEscape, [][]byte{ten, ten, ten}, 32, "0123456789\n0123456789\n0123456789", 3, false},
+		{"첫 로그가 한도 초과", FramingEscape, [][]byte{[]byte("0123456789abcdef"), ten}, 15, "", 1, true},
+		{"이스케이프 후 길이로 판단", FramingEscape, [][]byte{[]byte("a\nb"), ten}, 6, "a#012b", 1, false},
+		{"옥텟 길이 접두사 포함", FramingOctet, [][]byte{ten, ten}, 26, "10 012345678910 0123456789", 2, false},
+		{"옥텟 접두사 때문에 초과", FramingOctet, [][]byte{ten, ten}, 25, "10 0123456789", 1, false},
+		{"무제한", FramingLF, [][]byte{ten, ten, ten}, 0, "0123456789\n0123456789\n0123456789", 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &UDPWorker{framing: tt.framing}
+			got, count, oversized := w.appendDatagram(nil, tt.batch, tt.limit)
+			if string(got) != tt.want || count != tt.count || oversized != tt.oversized {
+				t.Fatalf("appendDatagram = (%q, %d, %v), 기대값 (%q, %d, %v)", got, count, oversized, tt.want, tt.count, tt.oversized)
+			}
+		})
+	}
+}
+
+// listenTCP - 테스트 수신 소켓
+func listenTCP(t *testing.T) *net.TCPListener {
+	t.Helper()
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+// acceptTCP - 워커의 연결 하나 수락
+func acceptTCP(t *testing.T, listener *net.TCPListener) net.Conn {
+	t.Helper()
+	listener.SetDeadline(time.Now().Add(5 * time.Second))
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readOctetFrame - RFC 6587 옥텟 카운팅 프레임 하나 읽기
+func readOctetFrame(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		t.Fatalf("잘못된 길이 %q", length)
+	}
+	frame := make([]byte, n)
+	if _, err := io.ReadFull(reader, frame); err != nil {
+		t.Fatal(err)
+	}
+	return string(frame)
+}
+
+func TestTCPOctetFraming(t *testing.T) {
+	listener := listenTCP(t)
+	w := newTestWorker(t, TransportOptions{Protocol: TransportTCP}, listener.Addr().(*net.TCPAddr).Port)
+	conn := acceptTCP(t, listener)
+	if limit := w.datagramLimit(); limit != 0 {
+		t.Fatalf("TCP 데이터그램 한도 %d, 기대값 0", limit)
+	}
+
+	batches := [][]string{
+		{"one", "multi\n\tline\n", ""},
+		{strings.Repeat("x", 70000), "last"}, // 스트림은 UDP 한도와 무관
+	}
+	for _, batch := range batches {
+		messages := make([][]byte, len(batch))
+		for i, text := range batch {
+			messages[i] = []byte(text)
+		}
+		if sent, err := w.sendMessages(messages); err != nil || sent != int64(len(batch)) {
+			t.Fatalf("sendMessages = (%d, %v)", sent, err)
+		}
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	for _, batch := range batches {
+		for _, want := range batch {
+			if got := readOctetFrame(t, reader); got != want {
+				t.Fatalf("프레임 %d바이트 %.40q, 기대값 %d바이트 %.40q", len(got), got, len(want), want)
+			}
+		}
+	}
+}
+
+func TestTCPLineFramingEndsBatch(t *testing.T) {
+	listener := listenTCP(t)
+	w := newTestWorker(t, TransportOptions{Protocol: TransportTCP, Framing: FramingEscape}, listener.Addr().(*net.TCPAddr).Port)
+	conn := acceptTCP(t, listener)
+
+	for _, batch := range [][][]byte{{[]byte("a"), []byte("b\nc")}, {[]byte("d")}} {
+		if _, err := w.sendMessages(batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	want := "a\nb#012c\nd\n" // 배치 끝에도 줄바꿈이 있어 다음 배치와 섞이지 않음
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(conn, got); err != nil || string(got) != want {
+		t.Fatalf("받은 스트림 %q (%v), 기대값 %q", got, err, want)
+	}
+}
+
+// 연결이 끊기면 전송은 기다리지 않고 바로 실패로 세며, 백그라운드에서 다시 연결한 뒤 이어서 보낸다.
+func TestTCPReconnect(t *testing.T) {
+	listener := listenTCP(t)
+	w := newTestWorker(t, TransportOptions{Protocol: TransportTCP}, listener.Addr().(*net.TCPAddr).Port)
+	first := acceptTCP(t, listener)
+	first.Close()
+
+	// 끊긴 것을 알 때까지 전송 (첫 쓰기는 상대가 닫았어도 성공할 수 있음)
+	message := [][]byte{[]byte("hello")}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := w.sendMessages(message); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("끊긴 연결에서 전송 오류가 나지 않음")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if w.reconnect == nil {
+		t.Fatal("재연결을 시작하지 않음")
+	}
+
+	// 재연결 중에는 기다리지 않고 바로 실패
+	start := time.Now()
+	if _, err := w.sendMessages(message); !errors.Is(err, errTCPDisconnected) {
+		t.Fatalf("재연결 중 오류 %v, 기대값 %v", err, errTCPDisconnected)
+	}
+	if elapsed := time.Since(start); elapsed > tcpReconnectMin/2 {
+		t.Fatalf("재연결 중 전송이 %v 동안 막힘", elapsed)
+	}
+
+	second := acceptTCP(t, listener)
+	for {
+		_, err := w.sendMessages([][]byte{[]byte("after")})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errTCPDisconnected) || time.Now().After(deadline) {
+			t.Fatalf("재연결 후 전송 실패: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if w.reconnect != nil {
+		t.Fatal("재연결 채널이 남아 있음")
+	}
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if got := readOctetFrame(t, bufio.NewReader(second)); got != "after" {
+		t.Fatalf("재연결 후 받은 프레임 %q", got)
+	}
+
+	// 수집기가 없어도 정지하면 재연결 시도를 멈춤 (정리 단계에서 대기)
+	listener.Close()
+	second.Close()
+	for {
+		if _, err := w.sendMessages(message); err != nil {
+			break
+		}
+		if time.Now().After(deadline.Add(5 * time.Second)) {
+			t.Fatal("끊긴 연결에서 전송 오류가 나지 않음")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -33,6 +33,12 @@ type WorkerMetrics struct {
 	CurrentEPS      int64         `json:"current_eps"`
 	TotalSent       int64         `json:"total_sent"`
 	ErrorCount      int64         `json:"error_count"`
+	DatagramsSent   int64         `json:"datagrams_sent"`
+	BytesSent       int64         `json:"bytes_sent"`
+	Oversized       int64         `json:"oversized,omitempty"` // 한 건만으로 최대 데이터그램 크기를 넘어 버린 로그 수
+	Mutations       map[string]int64 `json:"mutations,omitempty"` // 퍼징 변형 레이블별 건수
+	Scenarios       map[string]int64 `json:"scenarios,omitempty"` // 주입한 공격 시나리오 이름별 건수
+	LastSequence    uint64        `json:"last_sequence,omitempty"` // 워터마크 마지막 일련번호 (번호를 매긴 메시지 수)
 	PacketLoss      float64       `json:"packet_loss"`
 	LastSentTime    time.Time     `json:"last_sent_time"`
 	CPUUsage        float64       `json:"cpu_usage"`
@@ -52,12 +58,30 @@ type UDPWorker struct {
 	sendBufferSize int
 	recvBufferSize int
 	
-	// 네트워크 연결
-	conn        *net.UDPConn
-	remoteAddr  *net.UDPAddr
-	
-	// 로그 생성기
-	generator   *generator.SystemLogGenerator
+	// 네트워크 연결 (기본 UDP, 전송 옵션에 따라 TCP)
+	conn        net.Conn
+	reconnect   chan net.Conn // TCP 재연결 대기 중이면 백그라운드 재연결 결과 (nil = 연결됨)
+	transport   TransportOptions
+	framing     string // 해석된 프레이밍 방식 (auto 제외)
+	
+	// 로그 생성기 (형식별 포맷터)
+	generator   generator.LogFormatter
+	// 바이너리 패킷 포맷터 (NetFlow/IPFIX, 그 외 형식은 nil)
+	packetFormatter generator.PacketFormatter
+	// 청크 분할 포맷터 (GELF, 그 외 형식은 nil)
+	chunkedFormatter generator.ChunkedFormatter
+	remotePort      int
+	
+	// 주입 메시지 정답 기록 (기록기가 없거나 포맷터가 레이블을 내주지 않으면 nil)
+	groundTruth *GroundTruthWriter
+	injections  generator.InjectionSource
+	
+	// 메시지 워터마크 (실행 ID가 비어 있으면 끔, 일련번호는 전송 고루틴 전용)
+	watermarkRun    string
+	watermarkSeq    uint64
+	watermarkLast   atomic.Uint64 // 메트릭용 watermarkSeq 사본 (배치마다 갱신)
+	watermarkBuffer []byte
+	watermarked     [][]byte
 	
 	// 성능 최적화 필드
 	batchBuffer [][]byte
@@ -68,6 +92,9 @@ type UDPWorker struct {
 	currentEPS  atomic.Int64
 	totalSent   atomic.Int64
 	errorCount  atomic.Int64
+	datagramsSent atomic.Int64 // 실제 전송한 UDP 데이터그램 수
+	bytesSent     atomic.Int64
+	oversized     atomic.Int64 // 최대 데이터그램보다 커서 버린 로그 수
 	
 	// 메트릭 및 모니터링
 	metricsChannel chan WorkerMetrics
@@ -111,6 +138,8 @@ func NewUDPWorkerWithConfig(id, port int, targetHost string, metricsChannel chan
 		ID:             id,
 		Port:           port,
 		TargetHost:     targetHost,
+		remotePort:     514,
+		framing:        TransportOptions{}.framing(),
 		batchSize:      batchSize,
 		tickerInterval: tickerInterval,
 		sendBufferSize: UDP_SEND_BUFFER_SIZE,
@@ -144,20 +173,19 @@ func NewUDPWorkerWithConfig(id, port int, targetHost string, metricsChannel chan
 }
 
 func (w *UDPWorker) setupUDPConnection() error {
-	// 원격 주소 설정 (SIEM 시스템) - 포트 514는 표준 syslog 포트
-	remotePort := 514
-	var err error
-	w.remoteAddr, err = net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", w.TargetHost, remotePort))
+	// 원격 주소 설정 (SIEM 시스템) - 기본 514는 표준 syslog 포트, 플로 형식은 수집기 포트
+	remoteAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", w.TargetHost, w.remotePort))
 	if err != nil {
 		return fmt.Errorf("원격 주소 해결 실패: %v", err)
 	}
 	
 	// UDP 클라이언트 소켓 생성 (바인딩하지 않음 - 송신 전용)
 	// 로컬 포트는 OS가 자동 할당
-	w.conn, err = net.DialUDP("udp", nil, w.remoteAddr)
+	conn, err := net.DialUDP("udp", nil, remoteAddr)
 	if err != nil {
 		return fmt.Errorf("UDP 연결 생성 실패: %v", err)
 	}
+	w.conn = conn
 	
 	// 소켓 버퍼 크기 최적화 (PRD 명세 기반)
 	err = w.optimizeSocketBuffers()
@@ -170,7 +198,7 @@ func (w *UDPWorker) setupUDPConnection() error {
 
 func (w *UDPWorker) optimizeSocketBuffers() error {
 	// SO_SNDBUF 설정 (송신 버퍼)
-	rawConn, err := w.conn.SyscallConn()
+	rawConn, err := w.conn.(syscall.Conn).SyscallConn()
 	if err != nil {
 		return err
 	}
@@ -227,6 +255,18 @@ func (w *UDPWorker) sendLoop(ctx context.Context) {
 		return
 	}
 	
+	// NetFlow/IPFIX는 패킷 단위로 전송 (목표 속도 = 초당 플로 수)
+	if w.packetFormatter != nil {
+		w.sendLoopFlow(ctx)
+		return
+	}
+	
+	// GELF는 메시지마다 청크 단위로 전송 (목표 속도 = 초당 메시지 수)
+	if w.chunkedFormatter != nil {
+		w.sendLoopChunked(ctx)
+		return
+	}
+	
 	// 목표 EPS가 있으면 정밀도 모드에 따라 선택
 	if w.targetEPS > 0 && w.adaptiveControl {
 		switch w.precisionMode {
@@ -257,16 +297,15 @@ func (w *UDPWorker) sendLoop(ctx context.Context) {
 			
 			// 프로파일 기반 배치 크기까지 로그 생성
 			for i := 0; i < w.batchSize; i++ {
-				logData := w.generator.GenerateSystemLog()
+				logData := w.generator.Generate()
 				w.batchBuffer = append(w.batchBuffer, logData)
 			}
 			
 			// 배치 전송
-			err := w.sendBatch()
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
 			if err != nil {
 				w.errorCount.Add(1)
-			} else {
-				w.totalSent.Add(int64(w.batchSize))
 			}
 			
 			// 주기적으로 EPS 업데이트
@@ -348,18 +387,17 @@ func (w *UDPWorker) sendLoopPrecise_OLD(ctx context.Context) {
 			
 			// 배치 생성
 			for i := 0; i < actualBatchSize; i++ {
-				logData := w.generator.GenerateSystemLog()
+				logData := w.generator.Generate()
 				w.batchBuffer = append(w.batchBuffer, logData)
 			}
 			
 			// 전송
-			err := w.sendBatch()
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
+			totalSentInWindow += sent
 			if err != nil {
 				w.errorCount.Add(1)
 			} else {
-				sent := int64(actualBatchSize)
-				w.totalSent.Add(sent)
-				totalSentInWindow += sent
 				batchSentCount++
 				
 				// 1초마다 실제 배치 전송률 출력
@@ -471,14 +509,14 @@ func (w *UDPWorker) sendLoopPrecise(ctx context.Context) {
 			// Clear and create batch
 			w.batchBuffer = w.batchBuffer[:0]
 			for i := int64(0); i < logsPerBatch; i++ {
-				log := w.generator.GenerateSystemLog()
+				log := w.generator.Generate()
 				w.batchBuffer = append(w.batchBuffer, log)
 			}
 			
 			// Send batch
-			if err := w.sendBatch(); err == nil {
-				w.totalSent.Add(logsPerBatch)
-			} else {
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
+			if err != nil {
 				w.errorCount.Add(1)
 			}
 			
@@ -524,15 +562,13 @@ func (w *UDPWorker) sendLoopMedium(ctx context.Context) {
 			// 배치 생성 및 전송
 			w.batchBuffer = w.batchBuffer[:0]
 			for i := 0; i < currentBatchSize; i++ {
-				log := w.generator.GenerateSystemLog()
+				log := w.generator.Generate()
 				w.batchBuffer = append(w.batchBuffer, log)
 			}
 			
-			if err := w.sendBatch(); err == nil {
-				sent := int64(currentBatchSize)
-				w.totalSent.Add(sent)
-				totalSentInWindow += sent
-			}
+			sent, _ := w.sendBatch()
+			w.totalSent.Add(sent)
+			totalSentInWindow += sent
 			
 			// 200ms마다 피드백 조정
 			elapsed := time.Since(windowStartTime)
@@ -617,7 +653,7 @@ func (w *UDPWorker) sendLoopUltraPerformance(ctx context.Context) {
 			*nextBuffer = (*nextBuffer)[:0]
 			actualBatchSize := int64(float64(logsPerBatch) * adjustmentFactor)
 			for i := int64(0); i < actualBatchSize; i++ {
-				log := w.generator.GenerateSystemLog()
+				log := w.generator.Generate()
 				*nextBuffer = append(*nextBuffer, log)
 			}
 			
@@ -641,11 +677,10 @@ func (w *UDPWorker) sendLoopUltraPerformance(ctx context.Context) {
 			currentBuffer, nextBuffer = nextBuffer, currentBuffer
 			w.batchBuffer = *currentBuffer
 			
-			if err := w.sendBatch(); err == nil {
-				sent := int64(len(w.batchBuffer))
-				w.totalSent.Add(sent)
-				windowSent += sent
-			} else {
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
+			windowSent += sent
+			if err != nil {
 				w.errorCount.Add(1)
 			}
 			
@@ -740,17 +775,15 @@ func (w *UDPWorker) sendLoopPerformance(ctx context.Context) {
 			preallocBuffer = preallocBuffer[:0]
 			
 			for i := 0; i < batchSize; i++ {
-				log := w.generator.GenerateSystemLog()
+				log := w.generator.Generate()
 				preallocBuffer = append(preallocBuffer, log)
 			}
 			
 			// 배치 전송
 			w.batchBuffer = preallocBuffer
-			if err := w.sendBatch(); err == nil {
-				sent := int64(batchSize)
-				w.totalSent.Add(sent)
-				totalSentInWindow += sent
-			}
+			sent, _ := w.sendBatch()
+			w.totalSent.Add(sent)
+			totalSentInWindow += sent
 			
 			// 100ms마다 체크 (빠른 피드백)
 			elapsed := time.Since(windowStartTime)
@@ -794,71 +827,97 @@ func (w *UDPWorker) sendLoopPerformance(ctx context.Context) {
 	}
 }
 
-// sendBatch - 배치 전송 (시스템 콜 최소화)
-func (w *UDPWorker) sendBatch() error {
+// sendBatch - 배치 전송 (시스템 콜 최소화, 실제로 보낸 로그 수 반환)
+func (w *UDPWorker) sendBatch() (int64, error) {
 	if len(w.batchBuffer) == 0 {
-		return nil
+		return 0, nil
 	}
 	
 	// conn 상태 확인
 	if w.conn == nil {
-		return fmt.Errorf("worker %d: UDP connection is nil", w.ID)
-	}
-	
-	// 여러 로그를 하나의 패킷으로 결합 (네트워크 효율성 향상)
-	w.sendBuffer = w.sendBuffer[:0]
-	
-	for i, logData := range w.batchBuffer {
-		w.sendBuffer = append(w.sendBuffer, logData...)
-		if i < len(w.batchBuffer)-1 {
-			w.sendBuffer = append(w.sendBuffer, '\n')
-		}
+		return 0, fmt.Errorf("worker %d: UDP connection is nil", w.ID)
 	}
 	
-	// UDP 전송 (DialUDP 사용 시 Write 메서드 사용)
-	_, err := w.conn.Write(w.sendBuffer)
-	if err != nil && w.ID == 1 {
-		fmt.Printf("Worker 1: Send error: %v\n", err)
-	}
-	return err
+	return w.sendMessages(w.batchBuffer)
 }
 
 // sendBatchPartial - 부분 배치 전송 (정밀한 속도 제어)
-func (w *UDPWorker) sendBatchPartial(batch [][]byte) error {
-	if len(batch) == 0 {
-		return nil
-	}
-	
-	// 여러 로그를 하나의 패킷으로 결합
-	w.sendBuffer = w.sendBuffer[:0]
-	
-	for i, logData := range batch {
-		w.sendBuffer = append(w.sendBuffer, logData...)
-		if i < len(batch)-1 {
-			w.sendBuffer = append(w.sendBuffer, '\n')
-		}
-	}
-	
-	// UDP 전송 (DialUDP 사용 시 Write 메서드 사용)
-	_, err := w.conn.Write(w.sendBuffer)
-	return err
+func (w *UDPWorker) sendBatchPartial(batch [][]byte) (int64, error) {
+	return w.sendMessages(batch)
 }
 
 // sendBatchIndividual - 개별 로그 전송 (높은 정확도가 필요한 경우)
-func (w *UDPWorker) sendBatchIndividual() error {
+func (w *UDPWorker) sendBatchIndividual() (int64, error) {
+	var sent int64
 	var errors int
 	
-	for _, logData := range w.batchBuffer {
-		_, err := w.conn.Write(logData)
+	for i := range w.batchBuffer {
+		n, err := w.sendMessages(w.batchBuffer[i:i+1])
+		sent += n
 		if err != nil {
 			errors++
 		}
 	}
 	
 	if errors > 0 {
-		return fmt.Errorf("%d개 로그 전송 실패", errors)
+		return sent, fmt.Errorf("%d개 로그 전송 실패", errors)
 	}
 	
+	return sent, nil
+}
+
+// sendMessages - 로그 여러 건을 최대 데이터그램 크기에 맞춰 나눠 전송 (실제로 보낸 로그 수 반환)
+//
+// 여러 로그를 하나의 패킷으로 결합하고(네트워크 효율성 향상, 멀티라인 로그는 프레이밍 적용),
+// 다음 로그를 붙이면 한도를 넘을 때 데이터그램을 끊는다. 한 건만으로 한도를 넘는 로그는
+// 배치를 실패시키지 않도록 버리고 oversized로 따로 집계한다. 데이터그램 하나가 실패해도
+// 나머지는 계속 보내며, 마지막 전송 오류를 반환한다.
+func (w *UDPWorker) sendMessages(batch [][]byte) (int64, error) {
+	messages := w.watermarkBatch(batch)
+	limit := w.datagramLimit()
+	
+	var sent int64
+	var sendErr error
+	for start := 0; start < len(messages); {
+		var count int
+		var oversized bool
+		w.sendBuffer, count, oversized = w.appendDatagram(w.sendBuffer[:0], messages[start:], limit)
+		end := start + count
+		
+		if oversized {
+			w.oversized.Add(1)
+			w.recordInjections(batch[start:end], messages[start:end], errOversizedMessage)
+		} else {
+			// UDP 전송 (DialUDP 사용 시 Write 메서드 사용)
+			err := w.writeDatagram(w.sendBuffer)
+			w.recordInjections(batch[start:end], messages[start:end], err)
+			if err != nil {
+				sendErr = err
+			} else {
+				sent += int64(count)
+			}
+		}
+		start = end
+	}
+	return sent, sendErr
+}
+
+// writeDatagram - 데이터그램 하나 전송 (성공 시 데이터그램/바이트 수 집계, TCP는 쓰기 단위)
+//
+// TCP 연결이 끊기면 백그라운드에서 다시 연결하고, 재연결될 때까지는 기다리지 않고 바로 오류를 반환한다.
+func (w *UDPWorker) writeDatagram(payload []byte) error {
+	if w.reconnect != nil && !w.takeReconnected() {
+		return errTCPDisconnected
+	}
+	n, err := w.conn.Write(payload)
+	if err != nil {
+		if _, isTCP := w.conn.(*net.TCPConn); isTCP {
+			w.startReconnect(err)
+		}
+		return err
+	}
+	w.datagramsSent.Add(1)
+	w.bytesSent.Add(int64(n))
 	return nil
 }
 
@@ -933,6 +992,25 @@ func (w *UDPWorker) SetTargetEPS(targetEPS int64) {
 	}
 }
 
+// SetFormatter - 로그 포맷터 설정 (Start 전에 호출)
+//
+// 패킷/청크 포맷터(NetFlow/IPFIX, GELF)는 수집기 포트가 다르므로 해당 포트의 UDP로 다시 연결한다.
+func (w *UDPWorker) SetFormatter(formatter generator.LogFormatter) error {
+	w.generator = formatter
+	w.packetFormatter, _ = formatter.(generator.PacketFormatter)
+	w.chunkedFormatter, _ = formatter.(generator.ChunkedFormatter)
+	w.injections, _ = formatter.(generator.InjectionSource)
+	return w.connect()
+}
+
+// GetFormatName - 워커가 생성하는 로그 형식 이름
+func (w *UDPWorker) GetFormatName() string {
+	if w.generator == nil {
+		return ""
+	}
+	return w.generator.Name()
+}
+
 // SetPrecisionMode - 정밀도 모드 설정
 func (w *UDPWorker) SetPrecisionMode(mode string) {
 	w.precisionMode = mode
@@ -1056,6 +1134,12 @@ func (w *UDPWorker) collectMetrics() WorkerMetrics {
 		CurrentEPS:    w.currentEPS.Load(),
 		TotalSent:     totalSent,
 		ErrorCount:    errorCount,
+		DatagramsSent: w.datagramsSent.Load(),
+		BytesSent:     w.bytesSent.Load(),
+		Oversized:     w.oversized.Load(),
+		Mutations:     w.GetMutationCounts(),
+		Scenarios:     w.GetScenarioCounts(),
+		LastSequence:  w.watermarkLast.Load(),
 		PacketLoss:    packetLoss,
 		LastSentTime:  time.Now(),
 		CPUUsage:      w.getCPUUsage(),
@@ -1095,6 +1179,15 @@ func (w *UDPWorker) cleanup() {
 	if w.conn != nil {
 		w.conn.Close()
 	}
+	// 정지 직전에 끝난 TCP 재연결 결과
+	if w.reconnect != nil {
+		select {
+		case conn := <-w.reconnect:
+			conn.Close()
+		default:
+		}
+		w.reconnect = nil
+	}
 }
 
 // GetCurrentEPS - 현재 EPS 반환
@@ -1107,6 +1200,39 @@ func (w *UDPWorker) GetTotalSent() int64 {
 	return w.totalSent.Load()
 }
 
+// GetDatagramsSent - 전송한 UDP 데이터그램 수 반환
+func (w *UDPWorker) GetDatagramsSent() int64 {
+	return w.datagramsSent.Load()
+}
+
+// GetOversized - 최대 데이터그램 크기를 넘어 버린 로그 수 반환
+func (w *UDPWorker) GetOversized() int64 {
+	return w.oversized.Load()
+}
+
+// GetBytesSent - 전송한 바이트 수 반환
+func (w *UDPWorker) GetBytesSent() int64 {
+	return w.bytesSent.Load()
+}
+
+// GetMutationCounts - 퍼징 변형 레이블별 건수 (퍼징 모드가 아니면 nil)
+func (w *UDPWorker) GetMutationCounts() map[string]int64 {
+	counter, ok := w.generator.(generator.MutationCounter)
+	if !ok {
+		return nil
+	}
+	return counter.MutationCounts()
+}
+
+// GetScenarioCounts - 주입한 공격 시나리오 이름별 건수 (시나리오 주입 모드가 아니면 nil)
+func (w *UDPWorker) GetScenarioCounts() map[string]int64 {
+	counter, ok := w.generator.(generator.ScenarioCounter)
+	if !ok {
+		return nil
+	}
+	return counter.ScenarioCounts()
+}
+
 // IsRunning - 실행 상태 확인
 func (w *UDPWorker) IsRunning() bool {
 	return w.isRunning.Load()
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// sendLoopFlow - NetFlow/IPFIX 내보내기 루프 (목표 EPS = 초당 플로 수)
+//
+// 패킷마다 레코드 수가 고정이므로 목표 EPS를 초당 패킷 수로 바꿔 보내고,
+// 패킷은 묶지 않고 하나씩 별도 데이터그램으로 전송한다.
+func (w *UDPWorker) sendLoopFlow(ctx context.Context) {
+	if w.conn == nil {
+		fmt.Printf("Worker %d: ERROR - UDP connection is nil!\n", w.ID)
+		return
+	}
+
+	targetFPS := w.targetEPS
+	if targetFPS == 0 {
+		targetFPS = 25000
+	}
+	recordsPerPacket := int64(w.packetFormatter.RecordsPerPacket())
+	packetsPerSecond := float64(targetFPS) / float64(recordsPerPacket)
+
+	fmt.Printf("Worker %d: Flow mode (%s) - %d flows/sec = %.0f packets/sec to port %d\n",
+		w.ID, w.packetFormatter.Name(), targetFPS, packetsPerSecond, w.remotePort)
+
+	w.sendLoopPaced(ctx, packetsPerSecond, func() {
+		if err := w.writeDatagram(w.packetFormatter.Generate()); err != nil {
+			w.errorCount.Add(1)
+		} else {
+			w.totalSent.Add(recordsPerPacket)
+		}
+	})
+}
+
+// sendLoopPaced - 초당 unitsPerSecond번 send를 호출하는 10ms 틱 루프
+//
+// 경과 시간으로 보내야 할 횟수를 계산해 누적 오차 없이 맞추되, 지연 후 한 번에
+// 몰아 보내지 않도록 틱당 최대 100ms 분량까지만 따라잡는다.
+func (w *UDPWorker) sendLoopPaced(ctx context.Context, unitsPerSecond float64, send func()) {
+	maxBurst := int64(unitsPerSecond/10) + 1
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	startTime := time.Now()
+	unitsSent := int64(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopChan:
+			return
+		case now := <-ticker.C:
+			due := int64(now.Sub(startTime).Seconds()*unitsPerSecond) - unitsSent
+			if due > maxBurst {
+				// 밀린 분량은 버리고 일정을 현재 시점에 맞춤
+				unitsSent += due - maxBurst
+				due = maxBurst
+			}
+
+			for i := int64(0); i < due; i++ {
+				send()
+			}
+			unitsSent += due
+
+			w.updateEPSMetrics()
+		}
+	}
+}
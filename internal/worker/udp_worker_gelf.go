@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+)
+
+// sendLoopChunked - GELF 전송 루프 (목표 EPS = 초당 메시지 수)
+//
+// 메시지마다 청크 크기에 따라 하나 이상의 데이터그램을 보낸다. 메시지는 모든
+// 청크가 전송된 경우에만 전송 건수에 더하고, 오류는 실패한 데이터그램마다 센다.
+func (w *UDPWorker) sendLoopChunked(ctx context.Context) {
+	if w.conn == nil {
+		fmt.Printf("Worker %d: ERROR - UDP connection is nil!\n", w.ID)
+		return
+	}
+
+	targetEPS := w.targetEPS
+	if targetEPS == 0 {
+		targetEPS = 25000
+	}
+
+	fmt.Printf("Worker %d: Chunked mode (%s) - %d messages/sec to port %d\n",
+		w.ID, w.chunkedFormatter.Name(), targetEPS, w.remotePort)
+
+	chunks := make([][]byte, 0, 4)
+	w.sendLoopPaced(ctx, float64(targetEPS), func() {
+		chunks = w.chunkedFormatter.AppendChunks(chunks[:0], w.generateChunked())
+		if len(chunks) == 0 {
+			// 최대 청크 수를 넘는 메시지 (수집기가 버리므로 전송하지 않음)
+			w.errorCount.Add(1)
+			return
+		}
+
+		failed := false
+		for _, chunk := range chunks {
+			if err := w.writeDatagram(chunk); err != nil {
+				w.errorCount.Add(1)
+				failed = true
+			}
+		}
+		if !failed {
+			w.totalSent.Add(1)
+		}
+	})
+}
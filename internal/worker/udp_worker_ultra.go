@@ -54,18 +54,16 @@ func (w *UDPWorker) sendLoopUltra(ctx context.Context) {
 			// 배치 생성
 			w.batchBuffer = w.batchBuffer[:0]
 			for i := int64(0); i < logsPerBatch; i++ {
-				log := w.generator.GenerateSystemLog()
+				log := w.generator.Generate()
 				w.batchBuffer = append(w.batchBuffer, log)
 			}
 			
 			// 전송
-			err := w.sendBatch()
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
+			totalSentInWindow += sent
 			if err != nil {
 				w.errorCount.Add(1)
-			} else {
-				sent := logsPerBatch
-				w.totalSent.Add(sent)
-				totalSentInWindow += sent
 			}
 			
 			// 다음 전송 시간 계산
@@ -150,7 +148,7 @@ func (w *UDPWorker) sendLoopRealtime(ctx context.Context) {
 				// 다음 배치 미리 생성
 				batch := make([][]byte, 0, logsPerBatch)
 				for i := int64(0); i < logsPerBatch; i++ {
-					log := w.generator.GenerateSystemLog()
+					log := w.generator.Generate()
 					batch = append(batch, log)
 				}
 				genChan <- batch
@@ -170,11 +168,10 @@ func (w *UDPWorker) sendLoopRealtime(ctx context.Context) {
 		case <-ticker.C:
 			// 현재 배치 전송
 			w.batchBuffer = *currentBuffer
-			err := w.sendBatch()
+			sent, err := w.sendBatch()
+			w.totalSent.Add(sent)
 			if err != nil {
 				w.errorCount.Add(1)
-			} else {
-				w.totalSent.Add(logsPerBatch)
 			}
 			
 			// 버퍼 스왑
@@ -187,7 +184,7 @@ func (w *UDPWorker) sendLoopRealtime(ctx context.Context) {
 				// 생성이 늦으면 직접 생성
 				(*currentBuffer) = (*currentBuffer)[:0]
 				for i := int64(0); i < logsPerBatch; i++ {
-					log := w.generator.GenerateSystemLog()
+					log := w.generator.Generate()
 					*currentBuffer = append(*currentBuffer, log)
 				}
 			}
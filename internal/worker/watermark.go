@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log-generator/internal/generator"
+)
+
+// NewRunID - 무작위 실행 ID (8자리 16진수, 워터마크와 정답 파일이 같은 값을 쓸 수 있음)
+func NewRunID() string {
+	var id [4]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// SetWatermark - 메시지 워터마크 실행 ID 설정 (Start 전에 호출, 빈 값이면 끔)
+func (w *UDPWorker) SetWatermark(runID string) {
+	w.watermarkRun = runID
+	w.watermarkSeq = 0
+	w.watermarkLast.Store(0)
+}
+
+// watermarkBatch - 배치의 메시지마다 워터마크를 넣은 사본 반환 (끄면 batch 그대로)
+//
+// 일련번호는 전송 고루틴에서만 증가하므로 락이 필요 없다. 사본은 워커의 작업 버퍼를
+// 나눠 쓰므로 다음 배치 전까지만 유효하다. 전송에 실패한 배치의 번호도 소모되므로
+// 수신 측의 빈 번호에는 워커 error_count에 잡힌 로컬 전송 실패가 포함된다.
+func (w *UDPWorker) watermarkBatch(batch [][]byte) [][]byte {
+	if w.watermarkRun == "" {
+		return batch
+	}
+
+	w.watermarkBuffer = w.watermarkBuffer[:0]
+	w.watermarked = w.watermarked[:0]
+	for _, message := range batch {
+		if len(message) == 0 || generator.IsDirective(message) {
+			// 빈 메시지(퍼징 empty 변형)와 IIS #Fields 지시문은 그대로 보내고 번호를 소모하지 않음
+			w.watermarked = append(w.watermarked, message)
+			continue
+		}
+		w.watermarkSeq++
+		start := len(w.watermarkBuffer)
+		w.watermarkBuffer = generator.AppendWatermark(w.watermarkBuffer, message, generator.Watermark{
+			Run:    w.watermarkRun,
+			Worker: w.ID,
+			Seq:    w.watermarkSeq,
+		})
+		// 버퍼가 커지며 재할당되어도 앞서 나눈 사본은 이전 배열을 그대로 가리킴
+		end := len(w.watermarkBuffer)
+		w.watermarked = append(w.watermarked, w.watermarkBuffer[start:end:end])
+	}
+	w.watermarkLast.Store(w.watermarkSeq)
+	return w.watermarked
+}
+
+// generateChunked - GELF 메시지 생성 (워터마크를 켜면 압축 전에 추가 필드로 넣음)
+func (w *UDPWorker) generateChunked() []byte {
+	if w.watermarkRun == "" {
+		return w.chunkedFormatter.Generate()
+	}
+	w.watermarkSeq++
+	w.watermarkLast.Store(w.watermarkSeq)
+	return w.chunkedFormatter.GenerateWatermarked(generator.Watermark{
+		Run:    w.watermarkRun,
+		Worker: w.ID,
+		Seq:    w.watermarkSeq,
+	})
+}
+
+// GetLastSequence - 워터마크 마지막 일련번호 (워터마크를 끄면 0)
+func (w *UDPWorker) GetLastSequence() uint64 {
+	return w.watermarkLast.Load()
+}
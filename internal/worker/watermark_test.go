@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"fmt"
+	"log-generator/internal/config"
+	"log-generator/internal/generator"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+var watermarkSeqPattern = regexp.MustCompile(`lg_seq=(\d+)$`)
+
+// watermarkSeqOf - 텍스트 워터마크의 일련번호 (없으면 0)
+func watermarkSeqOf(t *testing.T, message []byte) uint64 {
+	t.Helper()
+	m := watermarkSeqPattern.FindSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	seq, err := strconv.ParseUint(string(m[1]), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seq
+}
+
+func TestWatermarkBatchSequence(t *testing.T) {
+	w := &UDPWorker{ID: 7}
+	w.SetWatermark("0badc0de")
+
+	batches := [][]string{
+		{"a", "b", "c"},
+		{"", "d", "#Fields: date time", "e"}, // 빈 메시지와 지시문은 번호를 소모하지 않음
+		{},
+		{"f"},
+	}
+	var want uint64
+	var sent [][]byte
+	for i, batch := range batches {
+		messages := make([][]byte, len(batch))
+		for j, text := range batch {
+			messages[j] = []byte(text)
+		}
+		out := w.watermarkBatch(messages)
+		if len(out) != len(messages) {
+			t.Fatalf("배치 %d: 결과 %d건, 입력 %d건", i, len(out), len(messages))
+		}
+		for j, message := range out {
+			if len(batch[j]) == 0 || generator.IsDirective(messages[j]) {
+				if string(message) != batch[j] {
+					t.Fatalf("배치 %d 메시지 %d: 그대로 보내야 하는데 %q", i, j, message)
+				}
+				continue
+			}
+			want++
+			prefix := fmt.Sprintf("%s lg_run=0badc0de lg_worker=7 lg_seq=", batch[j])
+			if got := string(message); got != prefix+strconv.FormatUint(want, 10) {
+				t.Fatalf("배치 %d 메시지 %d: %q", i, j, got)
+			}
+		}
+		if got := w.watermarkLast.Load(); got != want {
+			t.Fatalf("배치 %d: 마지막 번호 %d, 기대값 %d", i, got, want)
+		}
+		// 같은 배치 안의 사본은 다음 메시지가 버퍼를 늘려도 바뀌지 않아야 함
+		for _, message := range out {
+			sent = append(sent, append([]byte(nil), message...))
+		}
+		for j := range out {
+			if string(out[j]) != string(sent[len(sent)-len(out)+j]) {
+				t.Fatalf("배치 %d 메시지 %d: 사본이 바뀜", i, j)
+			}
+		}
+	}
+
+	// 다시 설정하면 번호가 1부터 시작
+	w.SetWatermark("0badc0de")
+	out := w.watermarkBatch([][]byte{[]byte("g")})
+	if seq := watermarkSeqOf(t, out[0]); seq != 1 {
+		t.Fatalf("재설정 후 번호 %d, 기대값 1", seq)
+	}
+}
+
+func TestWatermarkBatchDisabled(t *testing.T) {
+	w := &UDPWorker{ID: 1}
+	batch := [][]byte{[]byte("a"), []byte("b")}
+	out := w.watermarkBatch(batch)
+	if &out[0] != &batch[0] || w.watermarkLast.Load() != 0 {
+		t.Fatal("워터마크를 끄면 배치를 그대로 반환해야 함")
+	}
+}
+
+// 생성기에 넘기는 한도에서 워터마크 최대 길이를 빼므로, 한도까지 채운 메시지에 워터마크를 붙여도
+// 데이터그램 한도를 넘지 않아야 한다.
+func TestWatermarkMessageLimit(t *testing.T) {
+	const maxDatagram = 4000 // 모든 형식의 원래 크기보다 큼
+	runID := NewRunID()
+	overhead := generator.MaxWatermarkSize(runID, MAX_WORKERS)
+
+	tests := []struct {
+		format string
+		style  string
+	}{
+		{"syslog", "rfc5424"},
+		{"syslog", "rfc3164"},
+		{"ecs", "rfc3164"},
+		{"win_xml", "rfc3164"},
+		{"apache", "rfc3164"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.style, func(t *testing.T) {
+			pool := NewWorkerPoolWithProfile("127.0.0.1", config.CalculateCustomProfile(1000))
+			options := generator.DefaultGeneratorOptions()
+			options.SyslogFormat = generator.SyslogFormat(tt.style)
+			options.Size = generator.SizeOptions{Distribution: generator.SizeFixed, Mean: maxDatagram - overhead, Min: 1, Max: maxDatagram - overhead}
+			if err := pool.SetGeneratorOptions(options); err != nil {
+				t.Fatal(err)
+			}
+			if err := pool.SetTransportOptions(TransportOptions{MaxDatagram: maxDatagram}); err != nil {
+				t.Fatal(err)
+			}
+			if err := pool.SetWatermark(runID); err != nil {
+				t.Fatal(err)
+			}
+			if got := pool.messageLimit(); got != maxDatagram-overhead {
+				t.Fatalf("생성기 한도 %d, 기대값 %d", got, maxDatagram-overhead)
+			}
+
+			options.MessageLimit = pool.messageLimit()
+			formatter, err := generator.NewFormatter(tt.format, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := &UDPWorker{ID: MAX_WORKERS}
+			w.SetWatermark(runID)
+			w.watermarkSeq = 1<<63 - 1 // 자릿수가 많은 번호
+			for i := 0; i < 200; i++ {
+				message := formatter.Generate()
+				out := w.watermarkBatch([][]byte{message})
+				if len(out[0]) > maxDatagram {
+					t.Fatalf("워터마크 후 %d바이트 (메시지 %d바이트, 한도 %d)", len(out[0]), len(message), maxDatagram)
+				}
+			}
+		})
+	}
+}
+
+func TestSetWatermarkRejectsSizeMax(t *testing.T) {
+	runID := NewRunID()
+	overhead := generator.MaxWatermarkSize(runID, MAX_WORKERS)
+
+	tests := []struct {
+		name      string
+		transport TransportOptions
+		max       int
+		ok        bool
+	}{
+		{"한도 이하", TransportOptions{MaxDatagram: 2000}, 2000 - overhead, true},
+		{"워터마크 때문에 초과", TransportOptions{MaxDatagram: 2000}, 2000 - overhead + 1, false},
+		{"TCP는 무제한", TransportOptions{Protocol: TransportTCP}, 100000, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewWorkerPoolWithProfile("127.0.0.1", config.CalculateCustomProfile(1000))
+			options := generator.DefaultGeneratorOptions()
+			options.Size = generator.SizeOptions{Distribution: generator.SizeFixed, Mean: tt.max, Min: 1, Max: tt.max}
+			if err := pool.SetGeneratorOptions(options); err != nil {
+				t.Fatal(err)
+			}
+			if err := pool.SetTransportOptions(tt.transport); err != nil {
+				t.Fatal(err)
+			}
+			if err := pool.SetWatermark(runID); (err == nil) != tt.ok {
+				t.Fatalf("SetWatermark() = %v, 성공 기대 %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log-generator/internal/config"
+	"log-generator/internal/generator"
+	"math"
 	"runtime"
 	"runtime/debug"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +17,9 @@ import (
 	"github.com/shirou/gopsutil/v3/cpu"
 )
 
+// 바이트 목표 환산 시 형식별 샘플 메시지 수
+const byteRateSamples = 2000
+
 const (
 	// PRD 명세: 400만 EPS = 40개 워커 × 10만 EPS
 	TOTAL_WORKERS = 40
@@ -27,6 +34,12 @@ type WorkerPoolMetrics struct {
 	TotalEPS        int64                    `json:"total_eps"`
 	TotalSent       int64                    `json:"total_sent"`
 	TotalErrors     int64                    `json:"total_errors"`
+	TotalDatagrams  int64                    `json:"total_datagrams"`
+	TotalBytes      int64                    `json:"total_bytes"`
+	TotalOversized  int64                    `json:"total_oversized,omitempty"` // 최대 데이터그램 크기를 넘어 버린 로그 수
+	BytesPerSec     int64                    `json:"bytes_per_sec"`   // 직전 1초간 전송 바이트
+	TotalMutations  map[string]int64         `json:"total_mutations,omitempty"` // 퍼징 변형 레이블별 건수
+	TotalScenarios  map[string]int64         `json:"total_scenarios,omitempty"` // 주입한 공격 시나리오 이름별 건수
 	ActiveWorkers   int                      `json:"active_workers"`
 	AverageEPS      int64                    `json:"average_eps"`
 	PacketLossRate  float64                  `json:"packet_loss_rate"`
@@ -58,6 +71,13 @@ type WorkerPool struct {
 	// 프로파일 설정
 	profile         *config.EPSProfile
 	
+	// 로그 생성기 출력 옵션 (syslog 형식 등)
+	generatorOptions generator.GeneratorOptions
+	logFormats       []string // 워커별 로그 형식 (워커 순서대로 순환 배정)
+	transport        TransportOptions // 전송 프로토콜/프레이밍
+	groundTruth      *GroundTruthWriter // 주입 메시지 정답 기록기 (nil이면 끔)
+	watermarkRun     string // 메시지 워터마크 실행 ID (빈 값이면 끔)
+	
 	// 메트릭 수집
 	metricsChannel  chan WorkerMetrics
 	poolMetrics     atomic.Value  // WorkerPoolMetrics 저장
@@ -100,6 +120,8 @@ func NewWorkerPool(targetHost string) *WorkerPool {
 		epsHistory:     make([]int64, 300), // 5분간 이력
 		targetEPS:      int64(defaultProfile.TargetEPS),
 		autoTuning:     true,
+		generatorOptions: generator.DefaultGeneratorOptions(),
+		logFormats:       []string{"syslog"},
 	}
 	
 	// 초기 메트릭 설정
@@ -133,6 +155,8 @@ func NewWorkerPoolWithProfile(targetHost string, profile *config.EPSProfile) *Wo
 		epsHistory:     make([]int64, 300),
 		targetEPS:      int64(profile.TargetEPS),
 		autoTuning:     false, // 프로파일 모드에서는 자동 튜닝 비활성화
+		generatorOptions: generator.DefaultGeneratorOptions(),
+		logFormats:       []string{"syslog"},
 	}
 	
 	// 초기 메트릭 설정
@@ -186,6 +210,24 @@ func (wp *WorkerPool) Initialize() error {
 			worker.SetPrecisionMode(wp.profile.PrecisionMode)
 		}
 		
+		// 로그 형식 배정 (형식 목록을 워커 순서대로 순환)
+		formatName := wp.logFormats[i%len(wp.logFormats)]
+		options := wp.generatorOptions
+		options.TrackInjections = wp.groundTruth != nil
+		options.MessageLimit = wp.messageLimit()
+		formatter, err := generator.NewFormatter(formatName, options)
+		if err != nil {
+			return fmt.Errorf("워커 %d 포맷터 생성 실패: %v", workerID, err)
+		}
+		if err := worker.SetFormatter(formatter); err != nil {
+			return err
+		}
+		if err := worker.SetTransport(wp.transport); err != nil {
+			return err
+		}
+		worker.SetGroundTruth(wp.groundTruth)
+		worker.SetWatermark(wp.watermarkRun)
+		
 		wp.workers = append(wp.workers, worker)
 	}
 	
@@ -203,6 +245,8 @@ func (wp *WorkerPool) Initialize() error {
 		"performance": "성능 우선 (오차 <10%)",
 	}
 	fmt.Printf("  🎯 Adaptive Rate Control 활성화 - %s 모드: %s\n", precisionMode, modeDescription[precisionMode])
+	fmt.Printf("  📝 로그 형식: %s\n", strings.Join(wp.logFormats, ", "))
+	fmt.Printf("  🔌 전송: %s (프레이밍: %s)\n", wp.transport.protocol(), wp.transport.framing())
 	
 	return nil
 }
@@ -264,6 +308,7 @@ func (wp *WorkerPool) metricsAggregator() {
 	defer ticker.Stop()
 	
 	workerMetricsMap := make(map[int]WorkerMetrics)
+	var lastTotalBytes int64
 	
 	for {
 		select {
@@ -275,6 +320,7 @@ func (wp *WorkerPool) metricsAggregator() {
 			
 			// 워커 메트릭 집계
 			var totalEPS, totalSent, totalErrors int64
+			var totalDatagrams, totalBytes, totalOversized int64
 			var activeWorkers int
 			var totalPacketLoss float64
 			
@@ -283,6 +329,9 @@ func (wp *WorkerPool) metricsAggregator() {
 					activeWorkers++
 					totalEPS += worker.GetCurrentEPS()
 					totalSent += worker.GetTotalSent()
+					totalDatagrams += worker.GetDatagramsSent()
+					totalBytes += worker.GetBytesSent()
+					totalOversized += worker.GetOversized()
 				}
 			}
 			
@@ -295,11 +344,24 @@ func (wp *WorkerPool) metricsAggregator() {
 			// EPS 이력 업데이트
 			wp.updateEPSHistory(totalEPS)
 			
+			// 바이트 속도 (1초 주기 차분, 워커가 줄어 총합이 감소하면 0)
+			bytesPerSec := totalBytes - lastTotalBytes
+			if bytesPerSec < 0 {
+				bytesPerSec = 0
+			}
+			lastTotalBytes = totalBytes
+			
 			// 풀 메트릭 업데이트
 			poolMetrics := WorkerPoolMetrics{
 				TotalEPS:       totalEPS,
 				TotalSent:      totalSent,
 				TotalErrors:    totalErrors,
+				TotalDatagrams: totalDatagrams,
+				TotalBytes:     totalBytes,
+				TotalOversized: totalOversized,
+				BytesPerSec:    bytesPerSec,
+				TotalMutations: wp.GetMutationCounts(),
+				TotalScenarios: wp.GetScenarioCounts(),
 				ActiveWorkers:  activeWorkers,
 				AverageEPS:     averageEPS,
 				PacketLossRate: totalPacketLoss / float64(activeWorkers),
@@ -344,8 +406,8 @@ func (wp *WorkerPool) collectSystemMetrics() SystemMetrics {
 	
 	// 현재 메트릭에서 정보 가져오기
 	currentMetrics := wp.GetMetrics()
-	totalTxPackets = currentMetrics.TotalSent
-	totalTxBytes = currentMetrics.TotalSent * 512 // 평균 패킷 크기 추정 (512 bytes)
+	totalTxPackets = currentMetrics.TotalDatagrams
+	totalTxBytes = currentMetrics.TotalBytes
 	
 	// 네트워크 처리량 계산 (Mbps)
 	elapsedSeconds := time.Since(wp.startTime).Seconds()
@@ -466,6 +528,11 @@ func (wp *WorkerPool) Stop() error {
 	// 고루틴 정리 대기
 	wp.wg.Wait()
 	
+	// 정답 기록기 버퍼 비우기 (닫기는 기록기를 만든 쪽에서)
+	if wp.groundTruth != nil {
+		wp.groundTruth.Flush()
+	}
+	
 	// 최종 성능 리포트
 	finalMetrics := wp.GetMetrics()
 	_ = finalMetrics
@@ -489,6 +556,12 @@ func (wp *WorkerPool) GetMetrics() WorkerPoolMetrics {
 			TotalEPS:       original.TotalEPS,
 			TotalSent:      original.TotalSent,
 			TotalErrors:    original.TotalErrors,
+			TotalDatagrams: original.TotalDatagrams,
+			TotalBytes:     original.TotalBytes,
+			TotalOversized: original.TotalOversized,
+			BytesPerSec:    original.BytesPerSec,
+			TotalMutations: original.TotalMutations,
+			TotalScenarios: original.TotalScenarios,
 			ActiveWorkers:  original.ActiveWorkers,
 			AverageEPS:     original.AverageEPS,
 			PacketLossRate: original.PacketLossRate,
@@ -504,6 +577,57 @@ func (wp *WorkerPool) GetMetrics() WorkerPoolMetrics {
 	}
 }
 
+// GetMutationCounts - 전체 워커의 퍼징 변형 레이블별 건수 합계 (정지 후에도 최종 값)
+func (wp *WorkerPool) GetMutationCounts() map[string]int64 {
+	var total map[string]int64
+	for _, worker := range wp.workers {
+		for label, count := range worker.GetMutationCounts() {
+			if total == nil {
+				total = make(map[string]int64)
+			}
+			total[label] += count
+		}
+	}
+	return total
+}
+
+// GetOversized - 전체 워커가 최대 데이터그램 크기를 넘어 버린 로그 수 합계 (정지 후에도 최종 값)
+func (wp *WorkerPool) GetOversized() int64 {
+	var total int64
+	for _, worker := range wp.workers {
+		total += worker.GetOversized()
+	}
+	return total
+}
+
+// GetScenarioCounts - 전체 워커의 공격 시나리오 이름별 주입 건수 합계 (정지 후에도 최종 값)
+func (wp *WorkerPool) GetScenarioCounts() map[string]int64 {
+	var total map[string]int64
+	for _, worker := range wp.workers {
+		for name, count := range worker.GetScenarioCounts() {
+			if total == nil {
+				total = make(map[string]int64)
+			}
+			total[name] += count
+		}
+	}
+	return total
+}
+
+// GetLastSequences - 워커 ID별 워터마크 마지막 일련번호 (정지 후에도 최종 값, 워터마크를 끄면 nil)
+//
+// 수신 측에서 (실행 ID, 워커 ID)별로 받은 메시지 수를 이 값과 비교하면 끝부분 유실까지 알 수 있다.
+func (wp *WorkerPool) GetLastSequences() map[int]uint64 {
+	if wp.watermarkRun == "" {
+		return nil
+	}
+	sequences := make(map[int]uint64, len(wp.workers))
+	for _, worker := range wp.workers {
+		sequences[worker.ID] = worker.GetLastSequence()
+	}
+	return sequences
+}
+
 // GetEPSHistory - EPS 이력 반환 (모니터링용)
 func (wp *WorkerPool) GetEPSHistory() []int64 {
 	wp.mutex.RLock()
@@ -549,6 +673,160 @@ func (wp *WorkerPool) SetProfile(profile *config.EPSProfile) error {
 	return nil
 }
 
+// SetGeneratorOptions - 로그 생성기 출력 옵션 설정 (Initialize 전에 호출)
+func (wp *WorkerPool) SetGeneratorOptions(options generator.GeneratorOptions) error {
+	if wp.isRunning.Load() {
+		return fmt.Errorf("워커 풀 실행 중에는 출력 옵션을 변경할 수 없습니다")
+	}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	
+	wp.generatorOptions = options
+	return nil
+}
+
+// SetGroundTruth - 주입 메시지 정답 기록기 설정 (Initialize 전에 호출, nil이면 끔)
+func (wp *WorkerPool) SetGroundTruth(writer *GroundTruthWriter) error {
+	if wp.isRunning.Load() {
+		return fmt.Errorf("워커 풀 실행 중에는 정답 기록기를 변경할 수 없습니다")
+	}
+	
+	wp.groundTruth = writer
+	return nil
+}
+
+// SetWatermark - 메시지 워터마크 실행 ID 설정 (Initialize 전에 호출, 빈 값이면 끔)
+//
+// 워커마다 1부터 시작하는 일련번호를 매기므로 (실행 ID, 워커 ID, 일련번호)로 메시지가 유일하다.
+func (wp *WorkerPool) SetWatermark(runID string) error {
+	if wp.isRunning.Load() {
+		return fmt.Errorf("워커 풀 실행 중에는 워터마크를 변경할 수 없습니다")
+	}
+	
+	if runID != "" {
+		overhead := generator.MaxWatermarkSize(runID, MAX_WORKERS)
+		if limit := wp.transport.MessageLimit(); limit > 0 && wp.generatorOptions.Size.Distribution != "" && wp.generatorOptions.Size.Max > limit-overhead {
+			return fmt.Errorf("워터마크는 메시지를 최대 %d바이트 늘리므로 UDP 전송에서 최대 메시지 크기는 %d바이트 이하여야 합니다: %d", overhead, limit-overhead, wp.generatorOptions.Size.Max)
+		}
+	}
+	
+	wp.watermarkRun = runID
+	return nil
+}
+
+// messageLimit - 생성기에 넘길 로그 한 건의 최대 바이트 수 (0 = 무제한)
+//
+// 워터마크는 생성 후 워커가 붙이므로 전송 한도에서 워터마크 최대 길이를 미리 뺀다.
+// 그러지 않으면 한도 근처 메시지가 워터마크 때문에 oversized로 버려지고 번호만 소모한다.
+func (wp *WorkerPool) messageLimit() int {
+	limit := wp.transport.MessageLimit()
+	if limit > 0 && wp.watermarkRun != "" {
+		limit -= generator.MaxWatermarkSize(wp.watermarkRun, MAX_WORKERS)
+	}
+	return limit
+}
+
+// GetWatermarkRunID - 현재 워터마크 실행 ID (꺼져 있으면 빈 값)
+func (wp *WorkerPool) GetWatermarkRunID() string {
+	return wp.watermarkRun
+}
+
+// SetLogFormats - 워커에 배정할 로그 형식 목록 설정 (Initialize 전에 호출)
+func (wp *WorkerPool) SetLogFormats(formats []string) error {
+	if wp.isRunning.Load() {
+		return fmt.Errorf("워커 풀 실행 중에는 로그 형식을 변경할 수 없습니다")
+	}
+	if err := generator.ValidateFormats(formats); err != nil {
+		return err
+	}
+	
+	wp.logFormats = append([]string(nil), formats...)
+	return nil
+}
+
+// SetTransportOptions - 전송 프로토콜/프레이밍 설정 (SetGeneratorOptions 이후, Initialize 전에 호출)
+func (wp *WorkerPool) SetTransportOptions(options TransportOptions) error {
+	if wp.isRunning.Load() {
+		return fmt.Errorf("워커 풀 실행 중에는 전송 설정을 변경할 수 없습니다")
+	}
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	if err := wp.generatorOptions.Size.ValidateLimit(options.MessageLimit()); err != nil {
+		return err
+	}
+	if err := wp.generatorOptions.Fuzz.ValidateLimit(options.MessageLimit()); err != nil {
+		return err
+	}
+	
+	wp.transport = options
+	return nil
+}
+
+// EPSForByteRate - 초당 바이트 목표를 현재 형식/출력/전송 설정의 목표 EPS로 환산
+//
+// 형식별로 메시지를 샘플링해 잰 평균 크기에 워터마크와 프레이밍 구분자를 더해 나눈다.
+// SetLogFormats, SetGeneratorOptions, SetTransportOptions, SetWatermark 이후에 호출하며,
+// 반환한 EPS로 만든 커스텀 프로파일을 SetProfile로 적용한다.
+func (wp *WorkerPool) EPSForByteRate(bytesPerSec int64) (int, float64, error) {
+	if bytesPerSec <= 0 {
+		return 0, 0, fmt.Errorf("초당 바이트 목표는 0보다 커야 합니다: %d", bytesPerSec)
+	}
+	options := wp.generatorOptions
+	options.MessageLimit = wp.messageLimit()
+	size, err := generator.EstimateMessageSize(wp.logFormats, options, byteRateSamples)
+	if err != nil {
+		return 0, 0, err
+	}
+	
+	// 워터마크 부가 바이트 (일련번호 7자리 기준 텍스트 형태로 근사)
+	if wp.watermarkRun != "" {
+		mark := generator.Watermark{Run: wp.watermarkRun, Worker: wp.profile.WorkerCount, Seq: 1000000}
+		size += float64(len(generator.AppendWatermark(nil, nil, mark)))
+	}
+	
+	// 메시지 하나당 프레이밍 부가 바이트 (옥텟 카운팅 "길이 SP", 그 외 줄바꿈)
+	if wp.transport.framing() == FramingOctet {
+		size += float64(len(strconv.Itoa(int(size)))) + 1
+	} else {
+		size++
+	}
+	eps := int(math.Ceil(float64(bytesPerSec) / size))
+	return eps, size, nil
+}
+
+// EstimateEPSForByteRate - 워커 풀 없이 형식/출력/전송 설정만으로 초당 바이트 목표를 목표 EPS로 환산 (설정 검증용)
+//
+// 워터마크 부가 바이트는 최대 워커 수 기준으로 근사한다.
+func EstimateEPSForByteRate(bytesPerSec int64, formats []string, options generator.GeneratorOptions, transport TransportOptions, watermark bool) (int, float64, error) {
+	wp := &WorkerPool{
+		profile:          &config.EPSProfile{WorkerCount: MAX_WORKERS},
+		generatorOptions: options,
+		logFormats:       formats,
+		transport:        transport,
+	}
+	if watermark {
+		wp.watermarkRun = NewRunID()
+	}
+	return wp.EPSForByteRate(bytesPerSec)
+}
+
+// GetTransportOptions - 현재 전송 설정 반환
+func (wp *WorkerPool) GetTransportOptions() TransportOptions {
+	return wp.transport
+}
+
+// GetLogFormats - 현재 로그 형식 목록 반환
+func (wp *WorkerPool) GetLogFormats() []string {
+	return append([]string(nil), wp.logFormats...)
+}
+
+// GetGeneratorOptions - 현재 로그 생성기 출력 옵션 반환
+func (wp *WorkerPool) GetGeneratorOptions() generator.GeneratorOptions {
+	return wp.generatorOptions
+}
+
 // EnableAutoTuning - 자동 튜닝 활성화/비활성화
 func (wp *WorkerPool) EnableAutoTuning(enabled bool) {
 	wp.tuningEnabled.Store(enabled)